@@ -19,10 +19,12 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MicahParks/keyfunc"
 	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/api/option"
 
 	"firebase.google.com/go/v4/internal"
 )
@@ -66,6 +68,14 @@ type DecodedAppCheckToken struct {
 type Client struct {
 	projectID string
 	jwks      *keyfunc.JWKS
+	cache     *verificationCache
+	endpoint  string
+	opts      []option.ClientOption
+	version   string
+	metricsFn internal.MetricsFn
+
+	httpClientMu sync.Mutex
+	httpClient   *internal.HTTPClient
 }
 
 // NewClient creates a new instance of the Firebase App Check Client.
@@ -73,8 +83,15 @@ type Client struct {
 // This function can only be invoked from within the SDK. Client applications should access the
 // the App Check service through firebase.App.
 func NewClient(ctx context.Context, conf *internal.AppCheckConfig) (*Client, error) {
+	jwksURL := JWKSUrl
+	endpoint := appCheckEndpoint
+	if conf.Endpoint != "" {
+		jwksURL = conf.Endpoint + "/v1beta/jwks"
+		endpoint = conf.Endpoint + "/v1"
+	}
+
 	// TODO: Add support for overriding the HTTP client using the App one.
-	jwks, err := keyfunc.Get(JWKSUrl, keyfunc.Options{
+	jwks, err := keyfunc.Get(jwksURL, keyfunc.Options{
 		Ctx:             ctx,
 		RefreshInterval: 6 * time.Hour,
 	})
@@ -85,6 +102,10 @@ func NewClient(ctx context.Context, conf *internal.AppCheckConfig) (*Client, err
 	return &Client{
 		projectID: conf.ProjectID,
 		jwks:      jwks,
+		endpoint:  endpoint,
+		opts:      conf.Opts,
+		version:   conf.Version,
+		metricsFn: conf.MetricsFn,
 	}, nil
 }
 
@@ -100,7 +121,26 @@ func NewClient(ctx context.Context, conf *internal.AppCheckConfig) (*Client, err
 //
 // If any of the above conditions are not met, an error is returned. Otherwise a pointer to a
 // decoded App Check token is returned.
+//
+// If caching has been enabled via SetVerificationCacheTTL, tokens that were recently verified
+// are not verified again.
 func (c *Client) VerifyToken(token string) (*DecodedAppCheckToken, error) {
+	cache := c.cache
+	if cache == nil {
+		return c.verifyToken(token)
+	}
+	if decoded, ok := cache.get(token); ok {
+		return decoded, nil
+	}
+	decoded, err := c.verifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	cache.set(token, decoded)
+	return decoded, nil
+}
+
+func (c *Client) verifyToken(token string) (*DecodedAppCheckToken, error) {
 	// References for checks:
 	// https://firebase.googleblog.com/2021/10/protecting-backends-with-app-check.html
 	// https://github.com/firebase/firebase-admin-node/blob/master/src/app-check/token-verifier.ts#L106
@@ -166,6 +206,35 @@ func (c *Client) VerifyToken(token string) (*DecodedAppCheckToken, error) {
 	return &appCheckToken, nil
 }
 
+// IsTokenInvalid checks if the given error was returned by VerifyToken because the token is
+// invalid, as opposed to a failure to verify it, such as a failure to obtain the public keys used
+// to verify its signature.
+func IsTokenInvalid(err error) bool {
+	for _, e := range tokenErrors {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	// Other errors returned while looking up the key of the token make it unverifiable.
+	var ve *jwt.ValidationError
+	return errors.As(err, &ve) && ve.Errors&jwt.ValidationErrorUnverifiable == 0
+}
+
+// tokenErrors are the errors that indicate that a token is invalid, including the errors
+// returned while looking up its key that are caused by the token.
+var tokenErrors = []error{
+	ErrIncorrectAlgorithm,
+	ErrTokenType,
+	ErrTokenClaims,
+	ErrTokenAudience,
+	ErrTokenIssuer,
+	ErrTokenSubject,
+	keyfunc.ErrKID,
+	keyfunc.ErrKIDNotFound,
+	keyfunc.ErrJWKAlgMismatch,
+	keyfunc.ErrJWKUseWhitelist,
+}
+
 func contains(s []string, str string) bool {
 	for _, v := range s {
 		if v == str {
@@ -287,3 +287,45 @@ func loadPrivateKey() (*rsa.PrivateKey, error) {
 	}
 	return privateKey, nil
 }
+
+func TestIsTokenInvalid(t *testing.T) {
+	ts, err := setupFakeJWKS()
+	if err != nil {
+		t.Fatalf("Error setting up fake JWKS server: %v", err)
+	}
+	defer ts.Close()
+
+	JWKSUrl = ts.URL
+	client, err := NewClient(context.Background(), &internal.AppCheckConfig{ProjectID: "project_id"})
+	if err != nil {
+		t.Fatalf("Error creating NewClient: %v", err)
+	}
+
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		t.Fatalf("Error loading private key: %v", err)
+	}
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{})
+	jwtToken.Header["kid"] = "unknown-kid"
+	unknownKID, err := jwtToken.SignedString(privateKey)
+	if err != nil {
+		t.Fatalf("error generating JWT: %v", err)
+	}
+
+	for _, token := range []string{"", "-", unknownKID} {
+		if _, err := client.VerifyToken(token); !IsTokenInvalid(err) {
+			t.Errorf("IsTokenInvalid(%v) = false; want = true", err)
+		}
+	}
+
+	keyFetch := &jwt.ValidationError{
+		Inner:  errors.New("failed to fetch the public keys"),
+		Errors: jwt.ValidationErrorUnverifiable,
+	}
+	for _, err := range []error{ErrTokenAudience, keyFetch, errors.New("unexpected error"), nil} {
+		want := err == ErrTokenAudience
+		if got := IsTokenInvalid(err); got != want {
+			t.Errorf("IsTokenInvalid(%v) = %v; want = %v", err, got, want)
+		}
+	}
+}
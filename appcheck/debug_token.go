@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const appCheckEndpoint = "https://firebaseappcheck.googleapis.com/v1"
+
+// AppCheckToken is an App Check token issued by the Firebase App Check backend.
+type AppCheckToken struct {
+	// Token is the App Check token, which can be sent in the X-Firebase-AppCheck header of requests
+	// to protected backends, and verified with VerifyToken.
+	Token string
+
+	// TTL is the duration for which the token is valid, starting from the time it was issued.
+	TTL time.Duration
+}
+
+// ExchangeDebugToken exchanges an App Check debug token for an App Check token of the given app.
+//
+// Debug tokens are registered for an app in the Firebase console, and allow clients that cannot
+// be attested, such as web apps running in CI, to obtain App Check tokens. ExchangeDebugToken lets
+// test harnesses and backends obtain the same tokens on behalf of those clients, so that flows
+// protected by App Check can be exercised end-to-end. The returned token is signed by the App
+// Check backend, and passes VerifyToken like any other App Check token.
+//
+// The appID is the Firebase app ID of the app for which the debug token is registered, such as
+// "1:1234567890:web:abcdef". The client must be initialized with credentials that are authorized
+// to call the Firebase App Check API of the project.
+func (c *Client) ExchangeDebugToken(ctx context.Context, appID, debugToken string) (*AppCheckToken, error) {
+	if appID == "" {
+		return nil, errors.New("app id must not be empty")
+	}
+	if debugToken == "" {
+		return nil, errors.New("debug token must not be empty")
+	}
+
+	hc, err := c.getHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &internal.Request{
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s/projects/%s/apps/%s:exchangeDebugToken", c.endpoint, c.projectID, appID),
+		Body: internal.NewJSONEntity(map[string]string{
+			"debugToken": debugToken,
+		}),
+	}
+	var result struct {
+		Token string `json:"token"`
+		TTL   string `json:"ttl"`
+	}
+	if _, err := hc.DoAndUnmarshal(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	ttl, err := time.ParseDuration(result.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token ttl %q: %v", result.TTL, err)
+	}
+	return &AppCheckToken{Token: result.Token, TTL: ttl}, nil
+}
+
+// getHTTPClient returns the HTTP client used to call the App Check API. The client is created
+// on first use, so that clients that only verify tokens do not require credentials. Failures to
+// create the client are not cached, and are retried on the next call.
+func (c *Client) getHTTPClient() (*internal.HTTPClient, error) {
+	c.httpClientMu.Lock()
+	defer c.httpClientMu.Unlock()
+	if c.httpClient != nil {
+		return c.httpClient, nil
+	}
+
+	// The client outlives the request that creates it, and must not be bound to its context.
+	hc, _, err := internal.NewHTTPClient(context.Background(), c.opts...)
+	if err != nil {
+		return nil, err
+	}
+	hc.Opts = []internal.HTTPOption{
+		internal.WithHeader("X-Client-Version", fmt.Sprintf("Go/Admin/%s", c.version)),
+	}
+	hc.MetricsFn = c.metricsFn
+	c.httpClient = hc
+	return hc, nil
+}
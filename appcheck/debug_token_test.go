@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+)
+
+func newDebugTokenClient(ts *httptest.Server) *Client {
+	return &Client{
+		projectID: "project_id",
+		endpoint:  ts.URL,
+		opts: []option.ClientOption{
+			option.WithTokenSource(&internal.MockTokenSource{AccessToken: "test-token"}),
+		},
+		version: "test-version",
+	}
+}
+
+func TestExchangeDebugToken(t *testing.T) {
+	var req *http.Request
+	var body map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "app-check-token", "ttl": "3600s"}`))
+	}))
+	defer ts.Close()
+
+	client := newDebugTokenClient(ts)
+	token, err := client.ExchangeDebugToken(context.Background(), "1:123:web:abc", "debug-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.Token != "app-check-token" || token.TTL != time.Hour {
+		t.Errorf("ExchangeDebugToken() = %#v; want = {app-check-token, 1h}", token)
+	}
+	if req.Method != http.MethodPost {
+		t.Errorf("Method = %q; want = %q", req.Method, http.MethodPost)
+	}
+	wantPath := "/projects/project_id/apps/1:123:web:abc:exchangeDebugToken"
+	if req.URL.Path != wantPath {
+		t.Errorf("Path = %q; want = %q", req.URL.Path, wantPath)
+	}
+	if body["debugToken"] != "debug-token" {
+		t.Errorf("debugToken = %q; want = %q", body["debugToken"], "debug-token")
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer test-token" {
+		t.Errorf("Authorization = %q; want = %q", got, "Bearer test-token")
+	}
+	if got := req.Header.Get("X-Client-Version"); got != "Go/Admin/test-version" {
+		t.Errorf("X-Client-Version = %q; want = %q", got, "Go/Admin/test-version")
+	}
+}
+
+func TestExchangeDebugTokenError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"status": "PERMISSION_DENIED", "message": "invalid debug token"}}`))
+	}))
+	defer ts.Close()
+
+	client := newDebugTokenClient(ts)
+	token, err := client.ExchangeDebugToken(context.Background(), "1:123:web:abc", "debug-token")
+	if token != nil || !errorutils.IsPermissionDenied(err) {
+		t.Errorf("ExchangeDebugToken() = (%v, %v); want = (nil, PermissionDenied)", token, err)
+	}
+}
+
+func TestExchangeDebugTokenInvalidTTL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "app-check-token", "ttl": "forever"}`))
+	}))
+	defer ts.Close()
+
+	client := newDebugTokenClient(ts)
+	token, err := client.ExchangeDebugToken(context.Background(), "1:123:web:abc", "debug-token")
+	if token != nil || err == nil {
+		t.Errorf("ExchangeDebugToken() = (%v, %v); want = (nil, error)", token, err)
+	}
+}
+
+func TestExchangeDebugTokenClientErrorNotCached(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "app-check-token", "ttl": "3600s"}`))
+	}))
+	defer ts.Close()
+
+	client := newDebugTokenClient(ts)
+	valid := client.opts
+	client.opts = []option.ClientOption{option.WithCredentialsFile("non_existing.json")}
+	token, err := client.ExchangeDebugToken(context.Background(), "1:123:web:abc", "debug-token")
+	if token != nil || err == nil {
+		t.Fatalf("ExchangeDebugToken() = (%v, %v); want = (nil, error)", token, err)
+	}
+
+	client.opts = valid
+	if _, err := client.ExchangeDebugToken(context.Background(), "1:123:web:abc", "debug-token"); err != nil {
+		t.Errorf("ExchangeDebugToken() = %v; want = nil", err)
+	}
+}
+
+func TestExchangeDebugTokenInvalidArgs(t *testing.T) {
+	client := &Client{projectID: "project_id"}
+	cases := []struct {
+		appID      string
+		debugToken string
+	}{
+		{"", "debug-token"},
+		{"1:123:web:abc", ""},
+	}
+	for _, tc := range cases {
+		token, err := client.ExchangeDebugToken(context.Background(), tc.appID, tc.debugToken)
+		if token != nil || err == nil {
+			t.Errorf("ExchangeDebugToken(%q, %q) = (%v, %v); want = (nil, error)",
+				tc.appID, tc.debugToken, token, err)
+		}
+	}
+}
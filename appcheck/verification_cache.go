@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appcheck
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// maxCachedTokens bounds the number of verified tokens held in memory by a client.
+const maxCachedTokens = 10000
+
+// SetVerificationCacheTTL enables caching of successfully verified App Check tokens for the
+// specified duration.
+//
+// While a token is cached, subsequent calls to VerifyToken with the same token string return the
+// cached result without verifying the token again. A token is never cached beyond its expiry
+// time. Tokens are cached in memory, keyed by their SHA-256 hash. Failed verifications are not
+// cached.
+//
+// A zero or negative TTL disables the cache and discards any cached tokens. This method should be
+// called before the client is used concurrently.
+func (c *Client) SetVerificationCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = &verificationCache{
+		ttl:     ttl,
+		entries: make(map[[sha256.Size]byte]*cacheEntry),
+	}
+}
+
+type cacheEntry struct {
+	token     *DecodedAppCheckToken
+	expiresAt time.Time
+}
+
+type verificationCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*cacheEntry
+}
+
+func (vc *verificationCache) get(token string) (*DecodedAppCheckToken, bool) {
+	key := sha256.Sum256([]byte(token))
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	entry, ok := vc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !jwt.TimeFunc().Before(entry.expiresAt) {
+		delete(vc.entries, key)
+		return nil, false
+	}
+	return entry.token.copy(), true
+}
+
+func (vc *verificationCache) set(token string, decoded *DecodedAppCheckToken) {
+	now := jwt.TimeFunc()
+	expiresAt := now.Add(vc.ttl)
+	if decoded.ExpiresAt.Before(expiresAt) {
+		expiresAt = decoded.ExpiresAt
+	}
+	if !now.Before(expiresAt) {
+		return
+	}
+
+	key := sha256.Sum256([]byte(token))
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	if len(vc.entries) >= maxCachedTokens {
+		vc.evict(now)
+	}
+	vc.entries[key] = &cacheEntry{
+		token:     decoded.copy(),
+		expiresAt: expiresAt,
+	}
+}
+
+// evict removes the expired entries from the cache, or all of them if none have expired.
+func (vc *verificationCache) evict(now time.Time) {
+	for k, entry := range vc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(vc.entries, k)
+		}
+	}
+	if len(vc.entries) >= maxCachedTokens {
+		vc.entries = make(map[[sha256.Size]byte]*cacheEntry)
+	}
+}
+
+func (t *DecodedAppCheckToken) copy() *DecodedAppCheckToken {
+	result := *t
+	result.Audience = append([]string(nil), t.Audience...)
+	result.Claims = make(map[string]interface{}, len(t.Claims))
+	for k, v := range t.Claims {
+		result.Claims[k] = v
+	}
+	return &result
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestVerifyTokenWithCache(t *testing.T) {
+	ts, err := setupFakeJWKS()
+	if err != nil {
+		t.Fatalf("Error setting up fake JWKS server: %v", err)
+	}
+	defer ts.Close()
+
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		t.Fatalf("Error loading private key: %v", err)
+	}
+
+	JWKSUrl = ts.URL
+	client, err := NewClient(context.Background(), &internal.AppCheckConfig{
+		ProjectID: "project_id",
+	})
+	if err != nil {
+		t.Fatalf("Error creating NewClient: %v", err)
+	}
+
+	mockTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := mockTime
+	jwt.TimeFunc = func() time.Time {
+		return now
+	}
+	defer func() {
+		jwt.TimeFunc = time.Now
+	}()
+
+	newToken := func(expiresAt time.Time) string {
+		claims := struct {
+			Aud []string `json:"aud"`
+			jwt.RegisteredClaims
+		}{
+			[]string{"projects/12345678", "projects/project_id"},
+			jwt.RegisteredClaims{
+				Issuer:    "https://firebaseappcheck.googleapis.com/12345678",
+				Subject:   "12345678:app:ID",
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+				IssuedAt:  jwt.NewNumericDate(mockTime),
+			},
+		}
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		jwtToken.Header["kid"] = "FGQdnRlzAmKyKr6-Hg_kMQrBkj_H6i6ADnBQz4OI6BU"
+		token, err := jwtToken.SignedString(privateKey)
+		if err != nil {
+			t.Fatalf("error generating JWT: %v", err)
+		}
+		return token
+	}
+
+	client.SetVerificationCacheTTL(5 * time.Minute)
+	token := newToken(mockTime.Add(time.Hour))
+	shortLived := newToken(mockTime.Add(time.Minute))
+	for _, tok := range []string{token, shortLived} {
+		if _, err := client.VerifyToken(tok); err != nil {
+			t.Fatalf("VerifyToken() = %v; want = nil", err)
+		}
+	}
+
+	// Changing the project ID makes any fresh verification fail, so only cached tokens pass.
+	client.projectID = "other_project_id"
+	now = mockTime.Add(30 * time.Second)
+	decoded, err := client.VerifyToken(token)
+	if err != nil || decoded.AppID != "12345678:app:ID" {
+		t.Errorf("VerifyToken(cached) = (%v, %v); want = (token, nil)", decoded, err)
+	}
+	decoded.Claims["foo"] = "bar"
+	if decoded, err := client.VerifyToken(token); err != nil || decoded.Claims["foo"] != nil {
+		t.Errorf("VerifyToken(cached) = (%v, %v); want = (unmodified token, nil)", decoded, err)
+	}
+
+	// Entries never outlive the token expiry time.
+	now = mockTime.Add(2 * time.Minute)
+	if _, err := client.VerifyToken(shortLived); err == nil {
+		t.Errorf("VerifyToken(expired) = nil; want = error")
+	}
+
+	// Entries expire after the TTL.
+	now = mockTime.Add(6 * time.Minute)
+	if _, err := client.VerifyToken(token); !errors.Is(err, ErrTokenAudience) {
+		t.Errorf("VerifyToken(after TTL) = %v; want = %v", err, ErrTokenAudience)
+	}
+
+	// Failed verifications are not cached, and disabling the cache discards cached tokens.
+	client.projectID = "project_id"
+	if _, err := client.VerifyToken(token); err != nil {
+		t.Fatalf("VerifyToken() = %v; want = nil", err)
+	}
+	client.SetVerificationCacheTTL(0)
+	client.projectID = "other_project_id"
+	if _, err := client.VerifyToken(token); !errors.Is(err, ErrTokenAudience) {
+		t.Errorf("VerifyToken(cache disabled) = %v; want = %v", err, ErrTokenAudience)
+	}
+}
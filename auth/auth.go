@@ -49,6 +49,10 @@ var reservedClaims = []string{
 	"exp", "firebase", "iat", "iss", "jti", "nbf", "nonce", "sub",
 }
 
+// tenantIDClaim is additionally reserved in custom tokens minted by tenant-aware clients, where it
+// is populated by the SDK.
+const tenantIDClaim = "tenant_id"
+
 var emulatorToken = &oauth2.Token{
 	AccessToken: "owner",
 }
@@ -73,7 +77,10 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 		err        error
 	)
 
-	authEmulatorHost := os.Getenv(emulatorHostEnvVar)
+	authEmulatorHost := conf.EmulatorHost
+	if authEmulatorHost == "" {
+		authEmulatorHost = os.Getenv(emulatorHostEnvVar)
+	}
 	if authEmulatorHost != "" {
 		isEmulator = true
 		signer = emulatedSigner{}
@@ -120,6 +127,15 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 		return nil, err
 	}
 
+	googleOIDCVerifier, err := newGoogleOIDCTokenVerifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idTokenVerifier.codec = conf.JSONCodec
+	cookieVerifier.codec = conf.JSONCodec
+	googleOIDCVerifier.codec = conf.JSONCodec
+
 	var opts []option.ClientOption
 	if isEmulator {
 		ts := oauth2.StaticTokenSource(emulatorToken)
@@ -135,6 +151,8 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 
 	hc := internal.WithDefaultRetryConfig(transport)
 	hc.CreateErrFn = handleHTTPError
+	hc.Codec = conf.JSONCodec
+	hc.MetricsFn = conf.MetricsFn
 	hc.Opts = []internal.HTTPOption{
 		internal.WithHeader("X-Client-Version", fmt.Sprintf("Go/Admin/%s", conf.Version)),
 	}
@@ -142,6 +160,8 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 	baseURL := defaultAuthURL
 	if isEmulator {
 		baseURL = fmt.Sprintf("http://%s/identitytoolkit.googleapis.com", authEmulatorHost)
+	} else if conf.Endpoint != "" {
+		baseURL = conf.Endpoint
 	}
 	idToolkitV1Endpoint := fmt.Sprintf("%s/v1", baseURL)
 	idToolkitV2Endpoint := fmt.Sprintf("%s/v2", baseURL)
@@ -150,6 +170,11 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 	tenantMgtEndpoint := idToolkitV2Endpoint
 	projectMgtEndpoint := idToolkitV2Endpoint
 
+	if conf.WarmUp && !isEmulator {
+		// Warm-up is best effort. Any connection or credential errors resurface on first use.
+		internal.WarmUp(ctx, transport, baseURL)
+	}
+
 	base := &baseClient{
 		userManagementEndpoint: userManagementEndpoint,
 		providerConfigEndpoint: providerConfigEndpoint,
@@ -159,9 +184,11 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 		httpClient:             hc,
 		idTokenVerifier:        idTokenVerifier,
 		cookieVerifier:         cookieVerifier,
+		googleOIDCVerifier:     googleOIDCVerifier,
 		signer:                 signer,
 		clock:                  internal.SystemClock,
 		isEmulator:             isEmulator,
+		userCache:              &userCacheConfig{},
 	}
 	return &Client{
 		baseClient:    base,
@@ -194,6 +221,10 @@ func (c *baseClient) CustomToken(ctx context.Context, uid string) (string, error
 
 // CustomTokenWithClaims is similar to CustomToken, but in addition to the user ID, it also encodes
 // all the key-value pairs in the provided map as claims in the resulting JWT.
+//
+// When called on a TenantClient, the resulting JWT also carries the tenant_id claim, which allows
+// tenant-aware client SDKs to exchange it for an ID token of the corresponding tenant. In that case
+// tenant_id cannot be specified as a developer claim.
 func (c *baseClient) CustomTokenWithClaims(ctx context.Context, uid string, devClaims map[string]interface{}) (string, error) {
 	iss, err := c.signer.Email(ctx)
 	if err != nil {
@@ -204,8 +235,13 @@ func (c *baseClient) CustomTokenWithClaims(ctx context.Context, uid string, devC
 		return "", errors.New("uid must be non-empty, and not longer than 128 characters")
 	}
 
+	reserved := reservedClaims
+	if c.tenantID != "" {
+		reserved = append([]string{tenantIDClaim}, reservedClaims...)
+	}
+
 	var disallowed []string
-	for _, k := range reservedClaims {
+	for _, k := range reserved {
 		if _, contains := devClaims[k]; contains {
 			disallowed = append(disallowed, k)
 		}
@@ -266,9 +302,14 @@ type Token struct {
 //
 // This data is provided by the Firebase Auth service and is a reserved claim in the ID token.
 type FirebaseInfo struct {
-	SignInProvider string                 `json:"sign_in_provider"`
-	Tenant         string                 `json:"tenant"`
-	Identities     map[string]interface{} `json:"identities"`
+	SignInProvider string `json:"sign_in_provider"`
+	// SignInSecondFactor is the type of the second factor used to sign in (e.g. "phone"), if the
+	// user signed in with multi-factor authentication.
+	SignInSecondFactor string `json:"sign_in_second_factor,omitempty"`
+	// SecondFactorIdentifier is the UID of the enrolled second factor used to sign in, if any.
+	SecondFactorIdentifier string                 `json:"second_factor_identifier,omitempty"`
+	Tenant                 string                 `json:"tenant"`
+	Identities             map[string]interface{} `json:"identities"`
 }
 
 // baseClient exposes the APIs common to both auth.Client and auth.TenantClient.
@@ -282,14 +323,18 @@ type baseClient struct {
 	httpClient             *internal.HTTPClient
 	idTokenVerifier        *tokenVerifier
 	cookieVerifier         *tokenVerifier
+	googleOIDCVerifier     *tokenVerifier
 	signer                 cryptoSigner
 	clock                  internal.Clock
 	isEmulator             bool
+	userCache              *userCacheConfig
+	requestAnnotation      string
 }
 
 func (c *baseClient) withTenantID(tenantID string) *baseClient {
 	copy := *c
 	copy.tenantID = tenantID
+	copy.userCache = c.userCache.clone()
 	return &copy
 }
 
@@ -329,13 +374,10 @@ func (c *baseClient) verifyIDToken(ctx context.Context, idToken string, checkRev
 	}
 
 	if c.tenantID != "" && c.tenantID != decoded.Firebase.Tenant {
-		return nil, &internal.FirebaseError{
+		return nil, withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    fmt.Sprintf("invalid tenant id: %q", decoded.Firebase.Tenant),
-			Ext: map[string]interface{}{
-				authErrorCode: tenantIDMismatch,
-			},
-		}
+		}, tenantIDMismatch)
 	}
 
 	if c.isEmulator || checkRevokedOrDisabled {
@@ -420,38 +462,51 @@ func IsSessionCookieRevoked(err error) bool {
 
 // checkRevokedOrDisabled checks whether the input token has been revoked or disabled.
 func (c *baseClient) checkRevokedOrDisabled(ctx context.Context, token *Token, errCode string, errMessage string) error {
-	user, err := c.GetUser(ctx, token.UID)
+	user, err := c.getUserByUID(ctx, token.UID)
 	if err != nil {
 		return err
 	}
 	if user.Disabled {
-		return &internal.FirebaseError{
+		return withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    "user has been disabled",
-			Ext: map[string]interface{}{
-				authErrorCode: userDisabled,
-			},
-		}
+		}, userDisabled)
 
 	}
 	if token.IssuedAt*1000 < user.TokensValidAfterMillis {
-		return &internal.FirebaseError{
+		return withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    errMessage,
-			Ext: map[string]interface{}{
-				authErrorCode: errCode,
-			},
-		}
+		}, errCode)
 	}
 	return nil
 }
 
 func hasAuthErrorCode(err error, code string) bool {
-	fe, ok := err.(*internal.FirebaseError)
-	if !ok {
-		return false
+	var ae *Error
+	if errors.As(err, &ae) {
+		return ae.Code == ErrorCode(code)
 	}
 
+	var fe *internal.FirebaseError
+	if !errors.As(err, &fe) {
+		return false
+	}
 	got, ok := fe.Ext[authErrorCode]
 	return ok && got == code
 }
+
+// withAuthErrorCode tags the error with the given auth error code, and attaches the corresponding
+// Error so that it can be extracted via errors.As.
+func withAuthErrorCode(fe *internal.FirebaseError, code string) *internal.FirebaseError {
+	if fe.Ext == nil {
+		fe.Ext = make(map[string]interface{})
+	}
+	fe.Ext[authErrorCode] = code
+	fe.Details = &Error{
+		Code:     ErrorCode(code),
+		Message:  fe.String,
+		Response: fe.Response,
+	}
+	return fe
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+)
+
+// SignInConfig represents the sign-in providers enabled for a project.
+type SignInConfig struct {
+	// The email sign-in provider configuration.
+	Email *EmailSignInConfig `json:"email,omitempty"`
+	// The anonymous sign-in provider configuration.
+	Anonymous *AnonymousSignInConfig `json:"anonymous,omitempty"`
+}
+
+// EmailSignInConfig represents the email sign-in provider configuration.
+type EmailSignInConfig struct {
+	// Whether email sign-in is enabled.
+	Enabled bool `json:"enabled"`
+	// Whether a password is required for email sign-in. If false, users may sign in via either
+	// email/password or email link.
+	PasswordRequired bool `json:"passwordRequired"`
+}
+
+// AnonymousSignInConfig represents the anonymous sign-in provider configuration.
+type AnonymousSignInConfig struct {
+	// Whether anonymous sign-in is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// SMSRegionConfig configures the regions where users are allowed to send verification SMS.
+//
+// Exactly one of AllowByDefault and AllowlistOnly must be set.
+type SMSRegionConfig struct {
+	// Allows SMS to be sent to any region except the disallowed ones.
+	AllowByDefault *AllowByDefault `json:"allowByDefault,omitempty"`
+	// Only allows SMS to be sent to the allowed regions.
+	AllowlistOnly *AllowlistOnly `json:"allowlistOnly,omitempty"`
+}
+
+// AllowByDefault allows SMS to be sent to any region except the specified ones.
+type AllowByDefault struct {
+	// Two-letter unicode region codes (as defined by https://cldr.unicode.org/) to disallow.
+	DisallowedRegions []string `json:"disallowedRegions,omitempty"`
+}
+
+// AllowlistOnly only allows SMS to be sent to the specified regions.
+type AllowlistOnly struct {
+	// Two-letter unicode region codes (as defined by https://cldr.unicode.org/) to allow.
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+}
+
+func validateSMSRegionConfig(req map[string]interface{}) error {
+	val, ok := req[smsRegionConfigKey]
+	if !ok {
+		return nil
+	}
+	smsRegionConfig, ok := val.(SMSRegionConfig)
+	if !ok {
+		return fmt.Errorf("invalid type for SMSRegionConfig: %v", val)
+	}
+	return smsRegionConfig.validate()
+}
+
+func (src *SMSRegionConfig) validate() error {
+	if src == nil {
+		return nil
+	}
+	if (src.AllowByDefault == nil) == (src.AllowlistOnly == nil) {
+		return fmt.Errorf("exactly one of \"AllowByDefault\" or \"AllowlistOnly\" must be specified")
+	}
+	var regions []string
+	if src.AllowByDefault != nil {
+		regions = src.AllowByDefault.DisallowedRegions
+	} else {
+		regions = src.AllowlistOnly.AllowedRegions
+	}
+	for _, region := range regions {
+		if len(region) != 2 {
+			return fmt.Errorf("invalid region code: %q", region)
+		}
+	}
+	return nil
+}
+
+// RecaptchaProviderEnforcementState represents the enforcement state of reCAPTCHA protection.
+type RecaptchaProviderEnforcementState string
+
+// These constants represent the possible values for the RecaptchaProviderEnforcementState type.
+const (
+	RecaptchaOff     RecaptchaProviderEnforcementState = "OFF"
+	RecaptchaAudit   RecaptchaProviderEnforcementState = "AUDIT"
+	RecaptchaEnforce RecaptchaProviderEnforcementState = "ENFORCE"
+)
+
+// RecaptchaAction represents the action taken on requests that fall within a managed rule.
+type RecaptchaAction string
+
+// These constants represent the possible values for the RecaptchaAction type.
+const (
+	RecaptchaActionBlock RecaptchaAction = "BLOCK"
+)
+
+// RecaptchaKeyClientType represents the client platform of a reCAPTCHA key.
+type RecaptchaKeyClientType string
+
+// These constants represent the possible values for the RecaptchaKeyClientType type.
+const (
+	RecaptchaKeyWeb     RecaptchaKeyClientType = "WEB"
+	RecaptchaKeyIOS     RecaptchaKeyClientType = "IOS"
+	RecaptchaKeyAndroid RecaptchaKeyClientType = "ANDROID"
+)
+
+// RecaptchaConfig configures the reCAPTCHA Enterprise protection of the sign-in flows.
+type RecaptchaConfig struct {
+	// The reCAPTCHA enforcement state for the email password provider.
+	EmailPasswordEnforcementState RecaptchaProviderEnforcementState `json:"emailPasswordEnforcementState,omitempty"`
+	// The reCAPTCHA enforcement state for the phone provider.
+	PhoneEnforcementState RecaptchaProviderEnforcementState `json:"phoneEnforcementState,omitempty"`
+	// The rules that determine the action taken based on the reCAPTCHA score of a request.
+	ManagedRules []*RecaptchaManagedRule `json:"managedRules,omitempty"`
+	// Whether to use the reCAPTCHA account defender for account protection.
+	UseAccountDefender bool `json:"useAccountDefender,omitempty"`
+	// The reCAPTCHA keys provisioned for the project. This field is output only, and is ignored in
+	// updates.
+	RecaptchaKeys []*RecaptchaKey `json:"recaptchaKeys,omitempty"`
+}
+
+// RecaptchaManagedRule configures the action taken on requests with a reCAPTCHA score up to
+// EndScore.
+type RecaptchaManagedRule struct {
+	// The end of the score range, between 0.0 and 1.0 (inclusive).
+	EndScore float64 `json:"endScore"`
+	// The action taken on requests that fall within the score range.
+	Action RecaptchaAction `json:"action,omitempty"`
+}
+
+// RecaptchaKey describes a reCAPTCHA Enterprise site key.
+type RecaptchaKey struct {
+	Type RecaptchaKeyClientType `json:"type,omitempty"`
+	Key  string                 `json:"key,omitempty"`
+}
+
+func (rc *RecaptchaConfig) validate() error {
+	if rc == nil {
+		return nil
+	}
+	for _, state := range []RecaptchaProviderEnforcementState{rc.EmailPasswordEnforcementState, rc.PhoneEnforcementState} {
+		if state != "" && state != RecaptchaOff && state != RecaptchaAudit && state != RecaptchaEnforce {
+			return fmt.Errorf("reCAPTCHA enforcement state must be 'OFF', 'AUDIT' or 'ENFORCE'")
+		}
+	}
+	for _, rule := range rc.ManagedRules {
+		if rule == nil {
+			return fmt.Errorf("\"ManagedRules\" must not contain nil rules")
+		}
+		if rule.EndScore < 0 || rule.EndScore > 1 {
+			return fmt.Errorf("\"EndScore\" must be a number between 0.0 and 1.0 (inclusive)")
+		}
+		if rule.Action != RecaptchaActionBlock {
+			return fmt.Errorf("\"Action\" must be 'BLOCK'")
+		}
+	}
+	return nil
+}
+
+// EmailPrivacyConfig configures the email privacy settings.
+type EmailPrivacyConfig struct {
+	// Whether improved email privacy is enabled. When enabled, the existence of accounts is not
+	// revealed by the email sign-in flows.
+	EnableImprovedEmailPrivacy bool `json:"enableImprovedEmailPrivacy"`
+}
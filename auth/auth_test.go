@@ -316,6 +316,26 @@ func TestNewClientEmulatorHostEnvVar(t *testing.T) {
 	}
 }
 
+func TestNewClientEmulatorHost(t *testing.T) {
+	os.Setenv(emulatorHostEnvVar, "localhost:9000")
+	defer os.Unsetenv(emulatorHostEnvVar)
+
+	client, err := NewClient(context.Background(), &internal.AuthConfig{
+		EmulatorHost: "localhost:9099",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "http://localhost:9099/identitytoolkit.googleapis.com/v1"
+	if client.userManagementEndpoint != want {
+		t.Errorf("userManagementEndpoint = %q; want = %q", client.userManagementEndpoint, want)
+	}
+	if !client.isEmulator {
+		t.Errorf("isEmulator = false; want = true")
+	}
+}
+
 func TestCustomToken(t *testing.T) {
 	client := &Client{
 		baseClient: &baseClient{
@@ -390,6 +410,55 @@ func TestCustomTokenForTenant(t *testing.T) {
 	}
 }
 
+func TestTenantClientCustomToken(t *testing.T) {
+	base := &baseClient{
+		signer: testSigner,
+		clock:  testClock,
+	}
+	client := &TenantClient{
+		baseClient: base.withTenantID("tenantID"),
+	}
+	claims := map[string]interface{}{
+		"foo": "bar",
+	}
+	token, err := client.CustomTokenWithClaims(context.Background(), "user1", claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCustomToken(context.Background(), token, claims, "tenantID"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCustomTokenForTenantReservedClaim(t *testing.T) {
+	client := &baseClient{
+		tenantID: "tenantID",
+		signer:   testSigner,
+		clock:    testClock,
+	}
+	claims := map[string]interface{}{
+		"tenant_id": "otherTenantID",
+	}
+	token, err := client.CustomTokenWithClaims(context.Background(), "user1", claims)
+	want := `developer claim "tenant_id" is reserved and cannot be specified`
+	if token != "" || err == nil || err.Error() != want {
+		t.Errorf("CustomTokenWithClaims() = (%q, %v); want = (\"\", %q)", token, err, want)
+	}
+
+	// tenant_id is not reserved when minting tokens for the default tenant.
+	client = &baseClient{
+		signer: testSigner,
+		clock:  testClock,
+	}
+	token, err = client.CustomTokenWithClaims(context.Background(), "user1", claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyCustomToken(context.Background(), token, claims, ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCustomTokenError(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -504,6 +573,36 @@ func TestVerifyIDTokenFromTenant(t *testing.T) {
 	}
 }
 
+func TestVerifyIDTokenWithSecondFactor(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+
+	idToken := getIDToken(mockIDTokenPayload{
+		"firebase": map[string]interface{}{
+			"sign_in_provider":         "password",
+			"sign_in_second_factor":    "phone",
+			"second_factor_identifier": "enrollmentID",
+		},
+	})
+	ft, err := client.VerifyIDToken(context.Background(), idToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ft.Firebase.SignInProvider != "password" {
+		t.Errorf("SignInProvider = %q; want = %q", ft.Firebase.SignInProvider, "password")
+	}
+	if ft.Firebase.SignInSecondFactor != "phone" {
+		t.Errorf("SignInSecondFactor = %q; want = %q", ft.Firebase.SignInSecondFactor, "phone")
+	}
+	if ft.Firebase.SecondFactorIdentifier != "enrollmentID" {
+		t.Errorf("SecondFactorIdentifier = %q; want = %q", ft.Firebase.SecondFactorIdentifier, "enrollmentID")
+	}
+}
+
 func TestVerifyIDTokenClockSkew(t *testing.T) {
 	now := testClock.Now().Unix()
 	cases := []struct {
@@ -1285,7 +1384,7 @@ func cookieVerifierForTests(ctx context.Context) (*tokenVerifier, error) {
 
 // mockKeySource provides access to a set of in-memory public keys.
 type mockKeySource struct {
-	keys []*publicKey
+	keys []*PublicKey
 	err  error
 }
 
@@ -1303,7 +1402,7 @@ func newMockKeySource(filePath string) (*mockKeySource, error) {
 	}, nil
 }
 
-func (k *mockKeySource) Keys(ctx context.Context) ([]*publicKey, error) {
+func (k *mockKeySource) Keys(ctx context.Context) ([]*PublicKey, error) {
 	return k.keys, k.err
 }
 
@@ -0,0 +1,92 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authgrpc provides gRPC server interceptors that authenticate calls with Firebase ID
+// tokens, using an auth.Middleware.
+package authgrpc
+
+import (
+	"context"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const appCheckMetadataKey = "x-firebase-appcheck"
+
+// UnaryServerInterceptor returns a gRPC interceptor that authenticates unary calls with the given
+// Middleware.
+//
+// ID tokens are read from the authorization metadata of the call, and App Check tokens from the
+// x-firebase-appcheck metadata. Once verified, the tokens are stored in the context of the call,
+// where they can be accessed with auth.TokenFromContext and auth.AppCheckTokenFromContext.
+//
+// Calls that cannot be authenticated fail with the Unauthenticated status code, or the Internal
+// status code if the credentials could not be verified due to other errors.
+func UnaryServerInterceptor(m *auth.Middleware) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that authenticates streaming calls with the
+// given Middleware, in the same way as UnaryServerInterceptor.
+func StreamServerInterceptor(m *auth.Middleware) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), m)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, m *auth.Middleware) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token, appCheckToken, err := m.VerifyCredentials(
+		ctx, firstValue(md, "authorization"), firstValue(md, appCheckMetadataKey))
+	if err != nil {
+		if auth.IsUnauthenticatedRequest(err) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		// Do not expose the details of backend and configuration errors to the caller.
+		return nil, status.Error(codes.Internal, "failed to verify credentials")
+	}
+	return auth.NewAuthenticatedContext(ctx, token, appCheckToken), nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
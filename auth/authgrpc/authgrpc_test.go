@@ -0,0 +1,223 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authgrpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/appcheck"
+	"firebase.google.com/go/v4/auth"
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testProjectID = "mock-project-id"
+
+// middlewareForTests returns a Middleware backed by a client connected to a fake Auth emulator,
+// which responds to user lookups with the given status code.
+func middlewareForTests(t *testing.T, lookupStatus int, config *auth.MiddlewareConfig) *auth.Middleware {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(lookupStatus)
+		if lookupStatus == http.StatusOK {
+			w.Write([]byte(`{"users": [{"localId": "uid1"}]}`))
+		} else {
+			w.Write([]byte(`{"error": {"message": "INTERNAL_ERROR: database unavailable"}}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := auth.NewClient(context.Background(), &internal.AuthConfig{
+		ProjectID:    testProjectID,
+		EmulatorHost: strings.TrimPrefix(ts.URL, "http://"),
+		Version:      "test-version",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := auth.NewMiddleware(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// emulatorIDToken returns an unsigned ID token, as accepted by the Auth emulator.
+func emulatorIDToken(t *testing.T) string {
+	now := time.Now().Unix()
+	payload, err := json.Marshal(map[string]interface{}{
+		"aud":       testProjectID,
+		"iss":       "https://securetoken.google.com/" + testProjectID,
+		"sub":       "uid1",
+		"iat":       now,
+		"exp":       now + 3600,
+		"auth_time": now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + encode(payload) + "."
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(middlewareForTests(t, http.StatusOK, nil))
+	var token *auth.Token
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		token, _ = auth.TokenFromContext(ctx)
+		return "response", nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+emulatorIDToken(t)))
+	resp, err := interceptor(ctx, "request", &grpc.UnaryServerInfo{}, handler)
+	if resp != "response" || err != nil {
+		t.Fatalf("UnaryServerInterceptor() = (%v, %v); want = (%q, nil)", resp, err, "response")
+	}
+	if token == nil || token.UID != "uid1" {
+		t.Errorf("TokenFromContext() = %v; want = token for %q", token, "uid1")
+	}
+}
+
+func TestUnaryServerInterceptorError(t *testing.T) {
+	idToken := emulatorIDToken(t)
+	cases := []struct {
+		name string
+		m    *auth.Middleware
+		md   metadata.MD
+		want codes.Code
+	}{
+		{"NoCredentials", middlewareForTests(t, http.StatusOK, nil), metadata.MD{}, codes.Unauthenticated},
+		{"NotBearer", middlewareForTests(t, http.StatusOK, nil), metadata.Pairs("authorization", idToken), codes.Unauthenticated},
+		{"InvalidIDToken", middlewareForTests(t, http.StatusOK, nil), metadata.Pairs("authorization", "Bearer not.a.token"), codes.Unauthenticated},
+		{"BackendError", middlewareForTests(t, http.StatusInternalServerError, nil), metadata.Pairs("authorization", "Bearer "+idToken), codes.Internal},
+	}
+	for _, tc := range cases {
+		called := false
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+		_, err := UnaryServerInterceptor(tc.m)(ctx, "request", &grpc.UnaryServerInfo{}, handler)
+		if status.Code(err) != tc.want || called {
+			t.Errorf("UnaryServerInterceptor(%s) = %v; want = %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorInternalErrorMessage(t *testing.T) {
+	m := middlewareForTests(t, http.StatusInternalServerError, nil)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+emulatorIDToken(t)))
+	_, err := UnaryServerInterceptor(m)(ctx, "request", &grpc.UnaryServerInfo{}, handler)
+	if got := status.Convert(err).Message(); got != "failed to verify credentials" {
+		t.Errorf("UnaryServerInterceptor() message = %q; want = %q", got, "failed to verify credentials")
+	}
+}
+
+func TestUnaryServerInterceptorAllowUnauthenticated(t *testing.T) {
+	m := middlewareForTests(t, http.StatusOK, &auth.MiddlewareConfig{AllowUnauthenticated: true})
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		if token, ok := auth.TokenFromContext(ctx); ok {
+			t.Errorf("TokenFromContext() = %v; want = nil", token)
+		}
+		return nil, nil
+	}
+
+	if _, err := UnaryServerInterceptor(m)(context.Background(), "request", &grpc.UnaryServerInfo{}, handler); err != nil || !called {
+		t.Errorf("UnaryServerInterceptor() = %v; want = nil", err)
+	}
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor(middlewareForTests(t, http.StatusOK, nil))
+	var token *auth.Token
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		token, _ = auth.TokenFromContext(ss.Context())
+		return nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+emulatorIDToken(t)))
+	if err := interceptor(nil, &mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if token == nil || token.UID != "uid1" {
+		t.Errorf("TokenFromContext() = %v; want = token for %q", token, "uid1")
+	}
+
+	err := interceptor(nil, &mockServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("StreamServerInterceptor() = %v; want = %v", err, codes.Unauthenticated)
+	}
+}
+
+type mockAppCheckVerifier struct{}
+
+func (v *mockAppCheckVerifier) VerifyToken(token string) (*appcheck.DecodedAppCheckToken, error) {
+	if token != "valid-app-check-token" {
+		return nil, appcheck.ErrTokenAudience
+	}
+	return &appcheck.DecodedAppCheckToken{AppID: "app-id"}, nil
+}
+
+func TestUnaryServerInterceptorAppCheck(t *testing.T) {
+	m := middlewareForTests(t, http.StatusOK, &auth.MiddlewareConfig{AppCheck: &mockAppCheckVerifier{}})
+	var appCheckToken *appcheck.DecodedAppCheckToken
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		appCheckToken, _ = auth.AppCheckTokenFromContext(ctx)
+		return nil, nil
+	}
+
+	idToken := emulatorIDToken(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"authorization", "Bearer "+idToken,
+		"x-firebase-appcheck", "valid-app-check-token"))
+	if _, err := UnaryServerInterceptor(m)(ctx, "request", &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if appCheckToken == nil || appCheckToken.AppID != "app-id" {
+		t.Errorf("AppCheckTokenFromContext() = %v; want = token for %q", appCheckToken, "app-id")
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+idToken))
+	_, err := UnaryServerInterceptor(m)(ctx, "request", &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("UnaryServerInterceptor() = %v; want = %v", err, codes.Unauthenticated)
+	}
+}
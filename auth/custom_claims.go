@@ -0,0 +1,121 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CustomClaimsError is returned when custom claims fail validation, because they contain
+// reserved claims or exceed the maximum size.
+type CustomClaimsError struct {
+	// The reserved claims present in the custom claims, if any. The error message only names
+	// the first one.
+	ReservedClaims []string
+	// The size of the serialized custom claims, if it exceeds MaxSize. Zero otherwise.
+	Size int
+	// The maximum size of the serialized custom claims, in characters.
+	MaxSize int
+}
+
+func (e *CustomClaimsError) Error() string {
+	if len(e.ReservedClaims) > 0 {
+		return fmt.Sprintf("claim %q is reserved and must not be set", e.ReservedClaims[0])
+	}
+	return fmt.Sprintf("serialized custom claims must not exceed %d characters", e.MaxSize)
+}
+
+// MergeCustomUserClaims merges the given claims into the existing custom claims of a user, and
+// returns the resulting claims.
+//
+// Claims present in the given map overwrite the existing claims with the same name, and claims
+// with a nil value are removed. Other existing claims are preserved. The merged claims are
+// validated as in SetCustomUserClaims.
+//
+// The backend does not support conditional updates, so the read and the write are only atomic
+// with respect to other calls to MergeCustomUserClaims made via the same process. Concurrent
+// updates to the claims of the user made elsewhere may be lost.
+func (c *baseClient) MergeCustomUserClaims(
+	ctx context.Context, uid string, customClaims map[string]interface{}) (map[string]interface{}, error) {
+	if err := validateUID(uid); err != nil {
+		return nil, err
+	}
+
+	unlock := claimsLocks.lock(fmt.Sprintf("%s/%s/%s", c.projectID, c.tenantID, uid))
+	defer unlock()
+
+	user, err := c.getUserByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]interface{}, len(user.CustomClaims)+len(customClaims))
+	for k, v := range user.CustomClaims {
+		merged[k] = v
+	}
+	for k, v := range customClaims {
+		if v == nil {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+
+	if err := c.SetCustomUserClaims(ctx, uid, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// claimsLocks serializes the read-modify-write cycles of MergeCustomUserClaims per user.
+var claimsLocks = &keyedMutex{
+	entries: make(map[string]*keyedMutexEntry),
+}
+
+type keyedMutex struct {
+	mu      sync.Mutex
+	entries map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock locks the mutex of the given key, and returns a function that unlocks it. Entries are
+// removed from the map when no longer referenced.
+func (km *keyedMutex) lock(key string) func() {
+	km.mu.Lock()
+	entry, ok := km.entries[key]
+	if !ok {
+		entry = &keyedMutexEntry{}
+		km.entries[key] = entry
+	}
+	entry.refs++
+	km.mu.Unlock()
+
+	entry.mu.Lock()
+	return func() {
+		entry.mu.Unlock()
+		km.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(km.entries, key)
+		}
+		km.mu.Unlock()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCustomClaimsError(t *testing.T) {
+	client := &Client{baseClient: &baseClient{}}
+	cases := []struct {
+		claims map[string]interface{}
+		want   *CustomClaimsError
+		msg    string
+	}{
+		{
+			map[string]interface{}{"sub": "uid", "admin": true},
+			&CustomClaimsError{ReservedClaims: []string{"sub"}, MaxSize: 1000},
+			`claim "sub" is reserved and must not be set`,
+		},
+		{
+			map[string]interface{}{"iss": "issuer", "aud": "audience"},
+			&CustomClaimsError{ReservedClaims: []string{"aud", "iss"}, MaxSize: 1000},
+			`claim "aud" is reserved and must not be set`,
+		},
+		{
+			map[string]interface{}{"a": strings.Repeat("a", 993)},
+			&CustomClaimsError{Size: 1001, MaxSize: 1000},
+			"serialized custom claims must not exceed 1000 characters",
+		},
+	}
+	for _, tc := range cases {
+		err := client.SetCustomUserClaims(context.Background(), "uid", tc.claims)
+		var cce *CustomClaimsError
+		if !errors.As(err, &cce) {
+			t.Fatalf("SetCustomUserClaims(%v) = %v; want = CustomClaimsError", tc.claims, err)
+		}
+		if !reflect.DeepEqual(cce, tc.want) {
+			t.Errorf("SetCustomUserClaims(%v) = %#v; want = %#v", tc.claims, cce, tc.want)
+		}
+		if err.Error() != tc.msg {
+			t.Errorf("SetCustomUserClaims(%v) = %q; want = %q", tc.claims, err.Error(), tc.msg)
+		}
+	}
+}
+
+func TestMergeCustomUserClaims(t *testing.T) {
+	resp := `{
+		"users": [{
+			"localId": "uid",
+			"customAttributes": "{\"admin\": true, \"tier\": \"silver\", \"beta\": true}"
+		}],
+		"localId": "uid"
+	}`
+	s := echoServer([]byte(resp), t)
+	defer s.Close()
+
+	claims, err := s.Client.MergeCustomUserClaims(context.Background(), "uid", map[string]interface{}{
+		"tier": "gold",
+		"beta": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{"admin": true, "tier": "gold"}
+	if !reflect.DeepEqual(claims, want) {
+		t.Errorf("MergeCustomUserClaims() = %v; want = %v", claims, want)
+	}
+	if len(s.Req) != 2 {
+		t.Fatalf("MergeCustomUserClaims() Requests = %d; want = 2", len(s.Req))
+	}
+	if s.Req[0].URL.Path != "/projects/mock-project-id/accounts:lookup" ||
+		s.Req[1].URL.Path != "/projects/mock-project-id/accounts:update" {
+		t.Errorf("MergeCustomUserClaims() URLs = [%q, %q]; want = [lookup, update]",
+			s.Req[0].URL.Path, s.Req[1].URL.Path)
+	}
+
+	var body struct {
+		CustomAttributes string `json:"customAttributes"`
+	}
+	if err := json.Unmarshal(s.Rbody, &body); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(body.CustomAttributes), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeCustomUserClaims() customAttributes = %v; want = %v", got, want)
+	}
+}
+
+func TestMergeCustomUserClaimsInvalid(t *testing.T) {
+	resp := `{"users": [{"localId": "uid", "customAttributes": "{\"admin\": true}"}]}`
+	s := echoServer([]byte(resp), t)
+	defer s.Close()
+
+	ctx := context.Background()
+	if _, err := s.Client.MergeCustomUserClaims(ctx, "", map[string]interface{}{"a": 1}); err == nil {
+		t.Errorf("MergeCustomUserClaims(empty uid) = nil; want = error")
+	}
+
+	claims, err := s.Client.MergeCustomUserClaims(ctx, "uid", map[string]interface{}{"exp": 1})
+	var cce *CustomClaimsError
+	if claims != nil || !errors.As(err, &cce) {
+		t.Errorf("MergeCustomUserClaims(reserved) = (%v, %v); want = (nil, CustomClaimsError)", claims, err)
+	}
+	// Only the lookup request is made.
+	if len(s.Req) != 1 {
+		t.Errorf("MergeCustomUserClaims() Requests = %d; want = 1", len(s.Req))
+	}
+}
+
+func TestKeyedMutex(t *testing.T) {
+	km := &keyedMutex{entries: make(map[string]*keyedMutexEntry)}
+	var wg sync.WaitGroup
+	counter := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := km.lock("key")
+			defer unlock()
+			counter++
+		}()
+	}
+	wg.Wait()
+
+	if counter != 50 {
+		t.Errorf("counter = %d; want = 50", counter)
+	}
+	if len(km.entries) != 0 {
+		t.Errorf("entries = %d; want = 0", len(km.entries))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import "net/http"
+
+// ErrorCode identifies the cause of an auth Error.
+//
+// ErrorCode implements the error interface, so that errors returned by this package can be
+// matched against a specific code using errors.Is:
+//
+//	if errors.Is(err, auth.UserNotFound) {
+//		// ...
+//	}
+type ErrorCode string
+
+// Error returns the string representation of the error code.
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// These constants represent the possible values for the ErrorCode type.
+const (
+	CertificateFetchFailed   ErrorCode = certificateFetchFailed
+	ConfigurationNotFound    ErrorCode = configurationNotFound
+	EmailAlreadyExists       ErrorCode = emailAlreadyExists
+	EmailNotFound            ErrorCode = emailNotFound
+	GoogleOIDCTokenExpired   ErrorCode = googleOIDCTokenExpired
+	GoogleOIDCTokenInvalid   ErrorCode = googleOIDCTokenInvalid
+	IDTokenExpired           ErrorCode = idTokenExpired
+	IDTokenInvalid           ErrorCode = idTokenInvalid
+	IDTokenRevoked           ErrorCode = idTokenRevoked
+	InvalidDynamicLinkDomain ErrorCode = invalidDynamicLinkDomain
+	PhoneNumberAlreadyExists ErrorCode = phoneNumberAlreadyExists
+	SessionCookieExpired     ErrorCode = sessionCookieExpired
+	SessionCookieInvalid     ErrorCode = sessionCookieInvalid
+	SessionCookieRevoked     ErrorCode = sessionCookieRevoked
+	TenantIDMismatch         ErrorCode = tenantIDMismatch
+	TenantNotFound           ErrorCode = tenantNotFound
+	UIDAlreadyExists         ErrorCode = uidAlreadyExists
+	UnauthorizedContinueURI  ErrorCode = unauthorizedContinueURI
+	UserDisabled             ErrorCode = userDisabled
+	UserNotFound             ErrorCode = userNotFound
+)
+
+// Error is the auth-specific representation of an error returned by this package.
+//
+// Errors returned by this package are still of the type checked by the errorutils package. The
+// corresponding Error can be extracted from them via errors.As:
+//
+//	var authErr *auth.Error
+//	if errors.As(err, &authErr) && authErr.Code == auth.EmailAlreadyExists {
+//		// ...
+//	}
+//
+// The IsUserNotFound style functions of this package remain available, and are equivalent to
+// comparing the Code of the Error.
+type Error struct {
+	// Code is the auth error code.
+	Code ErrorCode
+	// Message is the error message.
+	Message string
+	// Response is the HTTP response returned by the backend, if the error was caused by an
+	// unsuccessful HTTP response.
+	Response *http.Response
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether the Error has the given ErrorCode.
+func (e *Error) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.Code
+}
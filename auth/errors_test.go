@@ -0,0 +1,114 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"firebase.google.com/go/v4/errorutils"
+)
+
+func TestErrorFromHTTPResponse(t *testing.T) {
+	resp := []byte(`{"error":{"message":"EMAIL_EXISTS: extra details"}}`)
+	s := echoServer(resp, t)
+	defer s.Close()
+	s.Client.baseClient.httpClient.RetryConfig = nil
+	s.Status = http.StatusBadRequest
+
+	_, err := s.Client.CreateUser(context.Background(), (&UserToCreate{}).Email("user@example.com"))
+
+	var authErr *Error
+	if !errors.As(err, &authErr) {
+		t.Fatalf("CreateUser() = %v; want = *Error", err)
+	}
+	if authErr.Code != EmailAlreadyExists {
+		t.Errorf("Code = %q; want = %q", authErr.Code, EmailAlreadyExists)
+	}
+	want := "user with the provided email already exists: extra details"
+	if authErr.Message != want || authErr.Error() != want {
+		t.Errorf("Message = %q; want = %q", authErr.Message, want)
+	}
+	if authErr.Response == nil || authErr.Response.StatusCode != http.StatusBadRequest {
+		t.Errorf("Response = %v; want = HTTP %d", authErr.Response, http.StatusBadRequest)
+	}
+	if !errors.Is(err, EmailAlreadyExists) || errors.Is(err, UserNotFound) {
+		t.Errorf("errors.Is() matched the wrong ErrorCode for %v", err)
+	}
+	if !IsEmailAlreadyExists(err) || !errorutils.IsAlreadyExists(err) {
+		t.Errorf("IsEmailAlreadyExists() = false; want = true")
+	}
+}
+
+func TestErrorFromUnknownHTTPResponse(t *testing.T) {
+	resp := []byte(`{"error":{"message":"UNKNOWN_CODE: extra details"}}`)
+	s := echoServer(resp, t)
+	defer s.Close()
+	s.Client.baseClient.httpClient.RetryConfig = nil
+	s.Status = http.StatusInternalServerError
+
+	_, err := s.Client.GetUser(context.Background(), "some uid")
+
+	var authErr *Error
+	if err == nil || errors.As(err, &authErr) {
+		t.Errorf("GetUser() = %v; want = error without an ErrorCode", err)
+	}
+}
+
+func TestErrorFromTokenVerification(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+	now := testClock.Now().Unix()
+	token := getIDToken(mockIDTokenPayload{"iat": now - 10000, "exp": now - 3600})
+
+	_, err := client.VerifyIDToken(context.Background(), token)
+
+	var authErr *Error
+	if !errors.As(err, &authErr) || authErr.Code != IDTokenExpired {
+		t.Fatalf("VerifyIDToken() = %v; want = %q", err, IDTokenExpired)
+	}
+	if authErr.Message != err.Error() || authErr.Response != nil {
+		t.Errorf("Error = %#v; want = message %q and no response", authErr, err.Error())
+	}
+	if !errors.Is(err, IDTokenExpired) || errors.Is(err, IDTokenInvalid) {
+		t.Errorf("errors.Is() matched the wrong ErrorCode for %v", err)
+	}
+}
+
+func TestErrorWrapped(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+	_, err := client.VerifyIDToken(context.Background(), "")
+	wrapped := fmt.Errorf("failed to authorize request: %w", err)
+
+	if !errors.Is(wrapped, IDTokenInvalid) {
+		t.Errorf("errors.Is(%v, IDTokenInvalid) = false; want = true", wrapped)
+	}
+	if !IsIDTokenInvalid(wrapped) {
+		t.Errorf("IsIDTokenInvalid(%v) = false; want = true", wrapped)
+	}
+	if IsIDTokenInvalid(errors.New("ID_TOKEN_INVALID")) {
+		t.Errorf("IsIDTokenInvalid(plain error) = true; want = false")
+	}
+}
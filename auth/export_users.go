@@ -16,6 +16,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -32,16 +33,58 @@ const maxReturnedResults = 1000
 // If nextPageToken is empty, the iterator will start at the beginning.
 // If the nextPageToken is not empty, the iterator starts after the token.
 func (c *baseClient) Users(ctx context.Context, nextPageToken string) *UserIterator {
+	return c.UsersWithOptions(ctx, &ListUsersOptions{PageToken: nextPageToken})
+}
+
+// ListUsersOptions specifies how users are listed by UsersWithOptions.
+type ListUsersOptions struct {
+	// PageToken is the page token from which to start listing users. If empty, the listing starts
+	// at the beginning.
+	PageToken string
+
+	// PageSize is the number of users fetched from the backend per request. Must not exceed 1000.
+	// If zero, 1000 users are fetched per request.
+	PageSize int
+
+	// MaxResults is the maximum number of users returned by the iterator. If zero, all users are
+	// returned.
+	MaxResults int
+}
+
+// UsersWithOptions returns an iterator over Users, configured with the given options.
+//
+// Long-running jobs can checkpoint their progress by persisting the token returned by
+// UserIterator.ResumeToken, and resume listing users later by passing it as the PageToken.
+// Pages that fail with a transient error (network errors, and HTTP 429, 500, 502, 503 and 504
+// responses) are retried with exponential backoff before Next returns the error.
+//
+// If the options are invalid, the first call to Next on the returned iterator returns an error.
+func (c *baseClient) UsersWithOptions(ctx context.Context, opts *ListUsersOptions) *UserIterator {
+	if opts == nil {
+		opts = &ListUsersOptions{}
+	}
+
 	it := &UserIterator{
-		ctx:    ctx,
-		client: c,
+		ctx:        ctx,
+		client:     c,
+		maxResults: opts.MaxResults,
 	}
 	it.pageInfo, it.nextFunc = iterator.NewPageInfo(
 		it.fetch,
 		func() int { return len(it.users) },
 		func() interface{} { b := it.users; it.users = nil; return b })
 	it.pageInfo.MaxSize = maxReturnedResults
-	it.pageInfo.Token = nextPageToken
+	if opts.PageSize != 0 {
+		it.pageInfo.MaxSize = opts.PageSize
+	}
+	it.pageInfo.Token = opts.PageToken
+	it.currentPageToken = opts.PageToken
+
+	if opts.PageSize < 0 || opts.PageSize > maxReturnedResults {
+		it.err = fmt.Errorf("page size must be between 1 and %d", maxReturnedResults)
+	} else if opts.MaxResults < 0 {
+		it.err = errors.New("max results must not be negative")
+	}
 	return it
 }
 
@@ -49,30 +92,60 @@ func (c *baseClient) Users(ctx context.Context, nextPageToken string) *UserItera
 //
 // Also see: https://github.com/GoogleCloudPlatform/google-cloud-go/wiki/Iterator-Guidelines
 type UserIterator struct {
-	client   *baseClient
-	ctx      context.Context
-	nextFunc func() error
-	pageInfo *iterator.PageInfo
-	users    []*ExportedUserRecord
+	client           *baseClient
+	ctx              context.Context
+	nextFunc         func() error
+	pageInfo         *iterator.PageInfo
+	users            []*ExportedUserRecord
+	currentPageToken string
+	maxResults       int
+	returned         int
+	err              error
 }
 
 // PageInfo supports pagination. See the google.golang.org/api/iterator package for details.
 // Page size can be determined by the NewPager(...) function described there.
 func (it *UserIterator) PageInfo() *iterator.PageInfo { return it.pageInfo }
 
+// ResumeToken returns a page token from which listing can be resumed without skipping any users.
+//
+// If the iterator is in the middle of a page, the token refers to the start of that page, and
+// the users of the page already returned by Next are returned again when resuming. Returns an
+// empty string when listing should start over from the beginning, or when all users have been
+// listed (which can be distinguished by Next returning [iterator.Done]).
+func (it *UserIterator) ResumeToken() string {
+	if len(it.users) > 0 {
+		return it.currentPageToken
+	}
+	return it.pageInfo.Token
+}
+
 // Next returns the next result. Its second return value is [iterator.Done] if
 // there are no more results. Once Next returns [iterator.Done], all subsequent
 // calls will return [iterator.Done].
 func (it *UserIterator) Next() (*ExportedUserRecord, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if it.maxResults > 0 && it.returned >= it.maxResults {
+		return nil, iterator.Done
+	}
 	if err := it.nextFunc(); err != nil {
 		return nil, err
 	}
 	user := it.users[0]
 	it.users = it.users[1:]
+	it.returned++
 	return user, nil
 }
 
 func (it *UserIterator) fetch(pageSize int, pageToken string) (string, error) {
+	if it.maxResults > 0 {
+		if remaining := it.maxResults - it.returned - len(it.users); remaining < pageSize {
+			pageSize = remaining
+		}
+	}
+
 	query := make(url.Values)
 	query.Set("maxResults", strconv.Itoa(pageSize))
 	if pageToken != "" {
@@ -92,11 +165,12 @@ func (it *UserIterator) fetch(pageSize int, pageToken string) (string, error) {
 		Users         []userQueryResponse `json:"users"`
 		NextPageToken string              `json:"nextPageToken"`
 	}
-	_, err = it.client.httpClient.DoAndUnmarshal(it.ctx, req, &parsed)
+	_, err = withListPageRetries(it.client.httpClient).DoAndUnmarshal(it.ctx, req, &parsed)
 	if err != nil {
 		return "", err
 	}
 
+	it.currentPageToken = pageToken
 	for _, u := range parsed.Users {
 		eu, err := u.makeExportedUserRecord()
 		if err != nil {
@@ -0,0 +1,194 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
+)
+
+const (
+	googleOIDCCertURL      = "https://www.googleapis.com/oauth2/v1/certs"
+	googleOIDCTokenExpired = "GOOGLE_OIDC_TOKEN_EXPIRED"
+	googleOIDCTokenInvalid = "GOOGLE_OIDC_TOKEN_INVALID"
+)
+
+var googleOIDCIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+// IsGoogleOIDCTokenExpired checks if the given error was due to an expired Google OIDC token.
+//
+// When IsGoogleOIDCTokenExpired returns true, IsGoogleOIDCTokenInvalid is guranteed to return true.
+func IsGoogleOIDCTokenExpired(err error) bool {
+	return hasAuthErrorCode(err, googleOIDCTokenExpired)
+}
+
+// IsGoogleOIDCTokenInvalid checks if the given error was due to an invalid Google OIDC token.
+//
+// A Google OIDC token is considered invalid when it is malformed (i.e. contains incorrect data),
+// expired or issued for a different audience.
+func IsGoogleOIDCTokenInvalid(err error) bool {
+	return hasAuthErrorCode(err, googleOIDCTokenInvalid) || IsGoogleOIDCTokenExpired(err)
+}
+
+// GoogleOIDCToken represents a decoded OIDC token signed by Google, such as the tokens attached
+// by Cloud Scheduler, Cloud Tasks and Pub/Sub push subscriptions to the requests they make on
+// behalf of a service account.
+type GoogleOIDCToken struct {
+	Issuer          string `json:"iss"`
+	Audience        string `json:"aud"`
+	Subject         string `json:"sub"`
+	AuthorizedParty string `json:"azp"`
+	IssuedAt        int64  `json:"iat"`
+	Expires         int64  `json:"exp"`
+	Email           string `json:"email"`
+	EmailVerified   bool   `json:"email_verified"`
+
+	// Claims contains the remaining claims of the token.
+	Claims map[string]interface{} `json:"-"`
+}
+
+func newGoogleOIDCTokenVerifier(ctx context.Context) (*tokenVerifier, error) {
+	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, option.WithoutAuthentication())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenVerifier{
+		shortName:         "Google OIDC token",
+		articledShortName: "a Google OIDC token",
+		docURL:            "https://developers.google.com/identity/openid-connect/openid-connect#validatinganidtoken",
+		invalidTokenCode:  googleOIDCTokenInvalid,
+		expiredTokenCode:  googleOIDCTokenExpired,
+		keySource:         newHTTPKeySource(googleOIDCCertURL, noAuthHTTPClient),
+		clock:             internal.SystemClock,
+	}, nil
+}
+
+// VerifyGoogleOIDCToken verifies the signature and payload of an OIDC token signed by Google.
+//
+// This allows backends that receive requests from both Firebase users and Google services (such
+// as Cloud Scheduler or Cloud Tasks configured to send OIDC tokens) to verify both kinds of
+// callers with the same client. The Google public keys are fetched and cached in the same way as
+// the keys used by VerifyIDToken.
+//
+// VerifyGoogleOIDCToken checks that the token is a valid RS256 JWT signed by Google, issued by
+// accounts.google.com for the given audience, and not expired. The audience is typically the URL
+// of the endpoint that receives the request, or the custom audience configured on the caller.
+// Callers should additionally check the Email of the returned token to ensure that the request
+// was made on behalf of an expected service account.
+//
+// Tokens minted by Identity-Aware Proxy are signed with different keys, and are not supported.
+func (c *baseClient) VerifyGoogleOIDCToken(ctx context.Context, token, audience string) (*GoogleOIDCToken, error) {
+	if audience == "" {
+		return nil, errors.New("audience must be a non-empty string")
+	}
+	tv := c.googleOIDCVerifier
+	if tv == nil {
+		return nil, errors.New("google OIDC token verifier is not initialized")
+	}
+
+	payload, err := tv.verifyGoogleOIDCContent(token, audience)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := &Token{IssuedAt: payload.IssuedAt, Expires: payload.Expires}
+	if err := tv.verifyTimestamps(timestamps); err != nil {
+		return nil, err
+	}
+
+	if err := tv.verifySignature(ctx, token); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (tv *tokenVerifier) verifyGoogleOIDCContent(token, audience string) (*GoogleOIDCToken, error) {
+	if token == "" {
+		return nil, withAuthErrorCode(&internal.FirebaseError{
+			ErrorCode: internal.InvalidArgument,
+			String:    fmt.Sprintf("%s must be a non-empty string", tv.shortName),
+		}, tv.invalidTokenCode)
+	}
+
+	payload, err := tv.verifyGoogleOIDCHeaderAndBody(token, audience)
+	if err != nil {
+		return nil, withAuthErrorCode(&internal.FirebaseError{
+			ErrorCode: internal.InvalidArgument,
+			String:    fmt.Sprintf("%s; see %s for details", err.Error(), tv.docURL),
+		}, tv.invalidTokenCode)
+	}
+	return payload, nil
+}
+
+func (tv *tokenVerifier) verifyGoogleOIDCHeaderAndBody(token, audience string) (*GoogleOIDCToken, error) {
+	var (
+		header  jwtHeader
+		payload GoogleOIDCToken
+	)
+
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, errors.New("incorrect number of segments")
+	}
+	if err := tv.decode(segments[0], &header); err != nil {
+		return nil, err
+	}
+	if err := tv.decode(segments[1], &payload); err != nil {
+		return nil, err
+	}
+
+	if header.KeyID == "" {
+		return nil, fmt.Errorf("%s has no 'kid' header", tv.shortName)
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%s has invalid algorithm; expected 'RS256' but got %q",
+			tv.shortName, header.Algorithm)
+	}
+	if payload.Audience != audience {
+		return nil, fmt.Errorf("%s has invalid 'aud' (audience) claim; expected %q but got %q",
+			tv.shortName, audience, payload.Audience)
+	}
+	validIssuer := false
+	for _, issuer := range googleOIDCIssuers {
+		if payload.Issuer == issuer {
+			validIssuer = true
+			break
+		}
+	}
+	if !validIssuer {
+		return nil, fmt.Errorf("%s has invalid 'iss' (issuer) claim; expected %q but got %q",
+			tv.shortName, googleOIDCIssuers[0], payload.Issuer)
+	}
+	if payload.Subject == "" {
+		return nil, fmt.Errorf("%s has empty 'sub' (subject) claim", tv.shortName)
+	}
+
+	var claims map[string]interface{}
+	if err := tv.decode(segments[1], &claims); err != nil {
+		return nil, err
+	}
+	for _, standardClaim := range []string{"iss", "aud", "sub", "azp", "iat", "exp", "email", "email_verified"} {
+		delete(claims, standardClaim)
+	}
+	payload.Claims = claims
+	return &payload, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const testOIDCAudience = "https://example.com/callbacks/scheduler"
+
+func TestVerifyGoogleOIDCToken(t *testing.T) {
+	client := googleOIDCClientForTests(t)
+	for _, issuer := range googleOIDCIssuers {
+		token := getGoogleOIDCToken(mockIDTokenPayload{"iss": issuer})
+
+		decoded, err := client.VerifyGoogleOIDCToken(context.Background(), token, testOIDCAudience)
+		if err != nil {
+			t.Fatalf("VerifyGoogleOIDCToken(iss: %q) = %v", issuer, err)
+		}
+		if decoded.Issuer != issuer {
+			t.Errorf("Issuer = %q; want = %q", decoded.Issuer, issuer)
+		}
+		if decoded.Audience != testOIDCAudience {
+			t.Errorf("Audience = %q; want = %q", decoded.Audience, testOIDCAudience)
+		}
+		if decoded.Subject != "1234567890" {
+			t.Errorf("Subject = %q; want = %q", decoded.Subject, "1234567890")
+		}
+		if decoded.Email != "scheduler@mock-project-id.iam.gserviceaccount.com" || !decoded.EmailVerified {
+			t.Errorf("Email = (%q, %v); want = (%q, true)",
+				decoded.Email, decoded.EmailVerified, "scheduler@mock-project-id.iam.gserviceaccount.com")
+		}
+		if _, ok := decoded.Claims["email"]; ok {
+			t.Errorf("Claims contains standard claim 'email'")
+		}
+		if decoded.Claims["admin"] != true {
+			t.Errorf("Claims['admin'] = %v; want = true", decoded.Claims["admin"])
+		}
+	}
+}
+
+func TestVerifyGoogleOIDCTokenError(t *testing.T) {
+	client := googleOIDCClientForTests(t)
+	now := testClock.Now().Unix()
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"EmptyToken", ""},
+		{"TooFewSegments", "foo"},
+		{"NoKid", getIDTokenWithKid("", googleOIDCPayload(nil))},
+		{"WrongAudience", getGoogleOIDCToken(mockIDTokenPayload{"aud": testProjectID})},
+		{"FirebaseIssuer", getGoogleOIDCToken(mockIDTokenPayload{"iss": "https://securetoken.google.com/" + testProjectID})},
+		{"EmptySubject", getGoogleOIDCToken(mockIDTokenPayload{"sub": ""})},
+		{"FutureToken", getGoogleOIDCToken(mockIDTokenPayload{"iat": now + 1000})},
+		{"ExpiredToken", getGoogleOIDCToken(mockIDTokenPayload{"iat": now - 10000, "exp": now - 3600})},
+		{"FirebaseIDToken", getIDToken(nil)},
+		{"BadSignature", badSignature(getGoogleOIDCToken(nil))},
+	}
+
+	for _, tc := range cases {
+		_, err := client.VerifyGoogleOIDCToken(context.Background(), tc.token, testOIDCAudience)
+		if !IsGoogleOIDCTokenInvalid(err) {
+			t.Errorf("VerifyGoogleOIDCToken(%s) = %v; want = invalid token error", tc.name, err)
+		}
+		if IsIDTokenInvalid(err) {
+			t.Errorf("VerifyGoogleOIDCToken(%s) = %v; want = not an ID token error", tc.name, err)
+		}
+		wantExpired := tc.name == "ExpiredToken"
+		if IsGoogleOIDCTokenExpired(err) != wantExpired {
+			t.Errorf("IsGoogleOIDCTokenExpired(%s) = %v; want = %v", tc.name, !wantExpired, wantExpired)
+		}
+	}
+}
+
+func TestVerifyGoogleOIDCTokenEmptyAudience(t *testing.T) {
+	client := googleOIDCClientForTests(t)
+	_, err := client.VerifyGoogleOIDCToken(context.Background(), getGoogleOIDCToken(nil), "")
+	if err == nil || err.Error() != "audience must be a non-empty string" {
+		t.Errorf("VerifyGoogleOIDCToken() = %v; want = audience error", err)
+	}
+}
+
+func TestVerifyGoogleOIDCTokenCertificateFetchError(t *testing.T) {
+	client := googleOIDCClientForTests(t)
+	client.googleOIDCVerifier.keySource = &mockKeySource{err: errors.New("mock error")}
+
+	_, err := client.VerifyGoogleOIDCToken(context.Background(), getGoogleOIDCToken(nil), testOIDCAudience)
+	if !IsCertificateFetchFailed(err) {
+		t.Errorf("VerifyGoogleOIDCToken() = %v; want = certificate fetch error", err)
+	}
+}
+
+func TestNewClientGoogleOIDCVerifier(t *testing.T) {
+	conf := &internal.AuthConfig{
+		ProjectID: testProjectID,
+		Opts:      optsWithTokenSource,
+	}
+	client, err := NewClient(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tv := client.googleOIDCVerifier
+	if tv == nil {
+		t.Fatal("googleOIDCVerifier not initialized")
+	}
+	if tv.invalidTokenCode != googleOIDCTokenInvalid || tv.expiredTokenCode != googleOIDCTokenExpired {
+		t.Errorf("token codes = (%q, %q); want = (%q, %q)", tv.invalidTokenCode, tv.expiredTokenCode,
+			googleOIDCTokenInvalid, googleOIDCTokenExpired)
+	}
+	ks, ok := tv.keySource.(*httpKeySource)
+	if !ok || ks.KeyURI != googleOIDCCertURL {
+		t.Errorf("keySource = %#v; want = httpKeySource(%q)", tv.keySource, googleOIDCCertURL)
+	}
+
+	tenantClient, err := client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenantClient.googleOIDCVerifier != tv {
+		t.Errorf("TenantClient does not share the Google OIDC token verifier")
+	}
+}
+
+func googleOIDCClientForTests(t *testing.T) *Client {
+	tv, err := newGoogleOIDCTokenVerifier(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tv.keySource = testIDTokenVerifier.keySource
+	tv.clock = testClock
+	return &Client{
+		baseClient: &baseClient{
+			googleOIDCVerifier: tv,
+		},
+	}
+}
+
+func googleOIDCPayload(p mockIDTokenPayload) mockIDTokenPayload {
+	payload := mockIDTokenPayload{
+		"aud":            testOIDCAudience,
+		"iss":            "https://accounts.google.com",
+		"azp":            "1234567890",
+		"email":          "scheduler@mock-project-id.iam.gserviceaccount.com",
+		"email_verified": true,
+	}
+	for k, v := range p {
+		payload[k] = v
+	}
+	return payload
+}
+
+func getGoogleOIDCToken(p mockIDTokenPayload) string {
+	return getIDToken(googleOIDCPayload(p))
+}
+
+func badSignature(token string) string {
+	segments := strings.Split(token, ".")
+	segments[2] = strings.Split(getIDToken(nil), ".")[2]
+	return strings.Join(segments, ".")
+}
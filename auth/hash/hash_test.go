@@ -496,3 +496,53 @@ func TestHashOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestScryptVerify(t *testing.T) {
+	decode := func(s string) []byte {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	// Test vector from https://github.com/firebase/scrypt.
+	s := Scrypt{
+		Key:           decode("jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA=="),
+		SaltSeparator: decode("Bw=="),
+		Rounds:        8,
+		MemoryCost:    14,
+	}
+	passwordHash := decode("lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==")
+	salt := decode("42xEC+ixf3L2lw==")
+
+	if ok, err := s.Verify("user1password", passwordHash, salt); !ok || err != nil {
+		t.Errorf("Verify() = (%v, %v); want = (true, nil)", ok, err)
+	}
+	if ok, err := s.Verify("wrongpassword", passwordHash, salt); ok || err != nil {
+		t.Errorf("Verify(wrong password) = (%v, %v); want = (false, nil)", ok, err)
+	}
+	if ok, err := s.Verify("user1password", passwordHash, []byte("salt")); ok || err != nil {
+		t.Errorf("Verify(wrong salt) = (%v, %v); want = (false, nil)", ok, err)
+	}
+
+	s.Rounds = 0
+	if ok, err := s.Verify("user1password", passwordHash, salt); ok || err == nil {
+		t.Errorf("Verify(invalid config) = (%v, %v); want = (false, error)", ok, err)
+	}
+}
+
+func TestBcryptVerify(t *testing.T) {
+	// Hash of "password" with cost 4.
+	passwordHash := []byte("$2a$04$6dzcxmTCscSwCWVrQT4icOBi3RQH6Ycr4idd.Nl6C6DTp0wrAxEBa")
+
+	if ok, err := (Bcrypt{}).Verify("password", passwordHash); !ok || err != nil {
+		t.Errorf("Verify() = (%v, %v); want = (true, nil)", ok, err)
+	}
+	if ok, err := (Bcrypt{}).Verify("wrongpassword", passwordHash); ok || err != nil {
+		t.Errorf("Verify(wrong password) = (%v, %v); want = (false, nil)", ok, err)
+	}
+	if ok, err := (Bcrypt{}).Verify("password", []byte("not a hash")); ok || err == nil {
+		t.Errorf("Verify(invalid hash) = (%v, %v); want = (false, error)", ok, err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hash
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Verify checks whether the given password matches the bcrypt password hash.
+//
+// The salt is embedded in bcrypt hashes, and hence no separate salt is required.
+func (b Bcrypt) Verify(password string, passwordHash []byte) (bool, error) {
+	err := bcrypt.CompareHashAndPassword(passwordHash, []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Verify checks whether the given password matches a password hash exported from Firebase Auth,
+// such as the PasswordHash and PasswordSalt of an auth.ExportedUserRecord (after base64
+// decoding).
+//
+// The Scrypt parameters must be the password hash parameters of the project, as shown in the
+// Firebase console.
+func (s Scrypt) Verify(password string, passwordHash, salt []byte) (bool, error) {
+	if _, err := s.Config(); err != nil {
+		return false, err
+	}
+
+	derivedKey, err := scrypt.Key(
+		[]byte(password), append(append([]byte{}, salt...), s.SaltSeparator...),
+		1<<s.MemoryCost, s.Rounds, 1, 32)
+	if err != nil {
+		return false, err
+	}
+
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return false, err
+	}
+	want := make([]byte, len(s.Key))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(want, s.Key)
+	return subtle.ConstantTimeCompare(want, passwordHash) == 1, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/api/option"
+)
+
+// IDTokenVerifier verifies Firebase ID tokens and session cookies without a Client.
+//
+// Unlike a Client, an IDTokenVerifier does not require a firebase.App or any credentials, as it
+// only fetches the public keys used to sign the tokens. This makes it suitable for services that
+// only need to verify tokens, such as API gateways. Since it cannot look up users, it does not
+// check whether tokens have been revoked, and it does not support the Firebase Auth emulator.
+type IDTokenVerifier struct {
+	idTokenVerifier *tokenVerifier
+	cookieVerifier  *tokenVerifier
+}
+
+// NewIDTokenVerifier creates a new IDTokenVerifier for the tokens issued to the given Firebase
+// project.
+//
+// The options are used to configure the HTTP client that fetches the public keys. This client
+// does not authenticate its requests, so options that provide credentials, such as
+// option.WithCredentialsFile, option.WithTokenSource or option.WithAPIKey, are not supported and
+// result in an error.
+func NewIDTokenVerifier(ctx context.Context, projectID string, opts ...option.ClientOption) (*IDTokenVerifier, error) {
+	if projectID == "" {
+		return nil, errors.New("project id must be a non-empty string")
+	}
+
+	idTokenVerifier, err := newIDTokenVerifier(ctx, projectID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the http client for fetching public keys; options must not provide credentials: %v", err)
+	}
+	cookieVerifier, err := newSessionCookieVerifier(ctx, projectID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the http client for fetching public keys; options must not provide credentials: %v", err)
+	}
+	return &IDTokenVerifier{
+		idTokenVerifier: idTokenVerifier,
+		cookieVerifier:  cookieVerifier,
+	}, nil
+}
+
+// VerifyIDToken verifies the signature and payload of the provided ID token.
+//
+// It performs the same checks as Client.VerifyIDToken. Tokens issued to users of any tenant of
+// the project are accepted. The tenant of the user is available in the Firebase.Tenant field of
+// the returned Token.
+func (v *IDTokenVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	return v.idTokenVerifier.VerifyToken(ctx, idToken, false)
+}
+
+// VerifySessionCookie verifies the signature and payload of the provided Firebase session cookie.
+//
+// It performs the same checks as Client.VerifySessionCookie.
+func (v *IDTokenVerifier) VerifySessionCookie(ctx context.Context, sessionCookie string) (*Token, error) {
+	return v.cookieVerifier.VerifyToken(ctx, sessionCookie, false)
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+)
+
+func TestIDTokenVerifier(t *testing.T) {
+	v := idTokenVerifierWithTestCerts(t)
+
+	token, err := v.VerifyIDToken(context.Background(), testIDToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.UID != "1234567890" || token.Claims["admin"] != true {
+		t.Errorf("VerifyIDToken() = %#v; want = token for uid %q", token, "1234567890")
+	}
+
+	cookie, err := v.VerifySessionCookie(context.Background(), testSessionCookie)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cookie.UID != "1234567890" {
+		t.Errorf("VerifySessionCookie() = %#v; want = cookie for uid %q", cookie, "1234567890")
+	}
+}
+
+func TestIDTokenVerifierTenantToken(t *testing.T) {
+	v := idTokenVerifierWithTestCerts(t)
+	idToken := getIDToken(mockIDTokenPayload{
+		"firebase": map[string]interface{}{
+			"tenant":           "tenantID",
+			"sign_in_provider": "custom",
+		},
+	})
+
+	token, err := v.VerifyIDToken(context.Background(), idToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Firebase.Tenant != "tenantID" {
+		t.Errorf("Tenant = %q; want = %q", token.Firebase.Tenant, "tenantID")
+	}
+}
+
+func TestIDTokenVerifierError(t *testing.T) {
+	v := idTokenVerifierWithTestCerts(t)
+
+	if _, err := v.VerifyIDToken(context.Background(), testSessionCookie); !IsIDTokenInvalid(err) {
+		t.Errorf("VerifyIDToken(sessionCookie) = %v; want = ID token invalid error", err)
+	}
+	if _, err := v.VerifySessionCookie(context.Background(), testIDToken); !IsSessionCookieInvalid(err) {
+		t.Errorf("VerifySessionCookie(idToken) = %v; want = session cookie invalid error", err)
+	}
+
+	other, err := NewIDTokenVerifier(context.Background(), "other-project-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.VerifyIDToken(context.Background(), testIDToken); !IsIDTokenInvalid(err) {
+		t.Errorf("VerifyIDToken(other project) = %v; want = ID token invalid error", err)
+	}
+}
+
+func TestIDTokenVerifierEmulatedToken(t *testing.T) {
+	t.Setenv(emulatorHostEnvVar, "localhost:9099")
+	v := idTokenVerifierWithTestCerts(t)
+
+	if _, err := v.VerifyIDToken(context.Background(), getEmulatedIDToken(nil)); !IsIDTokenInvalid(err) {
+		t.Errorf("VerifyIDToken(emulated) = %v; want = ID token invalid error", err)
+	}
+}
+
+func TestNewIDTokenVerifierNoProjectID(t *testing.T) {
+	v, err := NewIDTokenVerifier(context.Background(), "")
+	if v != nil || err == nil {
+		t.Errorf("NewIDTokenVerifier() = (%v, %v); want = (nil, error)", v, err)
+	}
+}
+
+func TestNewIDTokenVerifierWithCredentials(t *testing.T) {
+	cases := []option.ClientOption{
+		optsWithTokenSource[0],
+		optsWithServiceAcct[0],
+		option.WithAPIKey("test-api-key"),
+	}
+	for _, opt := range cases {
+		v, err := NewIDTokenVerifier(context.Background(), testProjectID, opt)
+		if v != nil || err == nil || !strings.Contains(err.Error(), "options must not provide credentials") {
+			t.Errorf("NewIDTokenVerifier(%T) = (%v, %v); want = (nil, error)", opt, v, err)
+		}
+	}
+}
+
+// idTokenVerifierWithTestCerts creates an IDTokenVerifier that fetches the test certificates via
+// the HTTP client specified as an option.
+func idTokenVerifierWithTestCerts(t *testing.T) *IDTokenVerifier {
+	data, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc, _ := newTestHTTPClient(data)
+	v, err := NewIDTokenVerifier(context.Background(), testProjectID, option.WithHTTPClient(hc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.idTokenVerifier.clock = testClock
+	v.cookieVerifier.clock = testClock
+	return v
+}
@@ -224,9 +224,11 @@ func (u *UserToImport) validatedUserInfo() (map[string]interface{}, error) {
 		}
 	}
 	if phone, ok := info["phoneNumber"]; ok {
-		if err := validatePhone(phone.(string)); err != nil {
+		normalized, err := NormalizePhoneNumber(phone.(string))
+		if err != nil {
 			return nil, err
 		}
+		info["phoneNumber"] = normalized
 	}
 
 	if claims, ok := info["customClaims"]; ok {
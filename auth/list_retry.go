@@ -0,0 +1,51 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// listPageRetryStatusCodes are the HTTP status codes on which a page of users or tenants is
+// fetched again, in addition to low-level network errors.
+var listPageRetryStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// withListPageRetries returns an HTTP client for fetching pages of list results.
+//
+// Listing all the users of a large project takes many requests, and a single transient error
+// would otherwise end the whole listing. Pages are therefore retried on a wider set of errors than
+// other requests, with the backoff and retry limit of the given client. A client without a retry
+// config is returned unchanged.
+func withListPageRetries(hc *internal.HTTPClient) *internal.HTTPClient {
+	if hc.RetryConfig == nil {
+		return hc
+	}
+
+	rc := *hc.RetryConfig
+	rc.CheckForRetry = func(resp *http.Response, networkErr error) bool {
+		return networkErr != nil || listPageRetryStatusCodes[resp.StatusCode]
+	}
+	client := *hc
+	client.RetryConfig = &rc
+	return &client
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"firebase.google.com/go/v4/appcheck"
+	"firebase.google.com/go/v4/internal"
+)
+
+const appCheckHeader = "X-Firebase-AppCheck"
+
+type tokenContextKey struct{}
+
+type appCheckTokenContextKey struct{}
+
+// TokenFromContext returns the verified token stored in the context by a Middleware.
+func TokenFromContext(ctx context.Context) (*Token, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(*Token)
+	return token, ok && token != nil
+}
+
+// NewContextWithToken returns a copy of the context that carries the given token.
+//
+// This is typically used to test handlers that call TokenFromContext, without running them
+// behind a Middleware.
+func NewContextWithToken(ctx context.Context, token *Token) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// NewAuthenticatedContext returns a copy of the context that carries the given tokens, in the
+// same way as the contexts of the requests authenticated by a Middleware. Either token may be nil.
+func NewAuthenticatedContext(
+	ctx context.Context, token *Token, appCheckToken *appcheck.DecodedAppCheckToken) context.Context {
+	if token != nil {
+		ctx = NewContextWithToken(ctx, token)
+	}
+	if appCheckToken != nil {
+		ctx = context.WithValue(ctx, appCheckTokenContextKey{}, appCheckToken)
+	}
+	return ctx
+}
+
+// AppCheckTokenFromContext returns the verified App Check token stored in the context by a
+// Middleware configured to verify App Check tokens.
+func AppCheckTokenFromContext(ctx context.Context) (*appcheck.DecodedAppCheckToken, bool) {
+	token, ok := ctx.Value(appCheckTokenContextKey{}).(*appcheck.DecodedAppCheckToken)
+	return token, ok && token != nil
+}
+
+// AppCheckVerifier verifies App Check tokens. It is implemented by appcheck.Client.
+//
+// Errors for which appcheck.IsTokenInvalid returns false, such as failures to fetch the public
+// keys, are treated as failures to verify the token rather than as invalid tokens.
+type AppCheckVerifier interface {
+	VerifyToken(token string) (*appcheck.DecodedAppCheckToken, error)
+}
+
+// MiddlewareConfig configures how a Middleware authenticates requests.
+type MiddlewareConfig struct {
+	// SessionCookieName is the name of the cookie that holds a session cookie. When set, requests
+	// that do not have an Authorization header are authenticated with the session cookie.
+	SessionCookieName string
+
+	// CheckRevoked specifies whether to check if the ID token or session cookie has been revoked,
+	// or its user has been disabled. This requires a call to the Firebase Auth backend for each
+	// request.
+	CheckRevoked bool
+
+	// AllowUnauthenticated specifies whether requests without any credentials are passed on to the
+	// next handler, without a token in their context. Requests with invalid credentials are still
+	// rejected.
+	AllowUnauthenticated bool
+
+	// AppCheck is used to verify the App Check token of each request, which is read from the
+	// X-Firebase-AppCheck header of HTTP requests, or the x-firebase-appcheck metadata of gRPC
+	// requests. When set, requests without a valid App Check token are rejected, even if
+	// AllowUnauthenticated is set.
+	AppCheck AppCheckVerifier
+
+	// ErrorHandler writes the response for requests that cannot be authenticated. By default, a
+	// plain text 401 Unauthorized response is written for missing, invalid, expired and revoked
+	// credentials, and a 500 Internal Server Error response for other errors, such as failures to
+	// fetch the public keys.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Middleware authenticates incoming HTTP and gRPC requests with Firebase ID tokens or session
+// cookies. gRPC interceptors backed by a Middleware are provided by the authgrpc package.
+//
+// ID tokens are read from the Authorization header of HTTP requests, or the authorization metadata
+// of gRPC requests, using the Bearer scheme. Session cookies are only supported for HTTP requests.
+// Once verified, the token is stored in the context of the request, where it can be accessed with
+// TokenFromContext. Requests can additionally be required to carry a valid App Check token, which
+// is stored in the context as well, and can be accessed with AppCheckTokenFromContext.
+type Middleware struct {
+	client *Client
+	config MiddlewareConfig
+}
+
+// NewMiddleware creates a Middleware that verifies credentials with the given client.
+//
+// The config may be nil, in which case only ID tokens are accepted, without checking for
+// revocation.
+func NewMiddleware(client *Client, config *MiddlewareConfig) (*Middleware, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	m := &Middleware{client: client}
+	if config != nil {
+		m.config = *config
+	}
+	return m, nil
+}
+
+// Handler wraps the given handler, so that it is only called with authenticated requests.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, appCheckToken, err := m.VerifyRequest(r)
+		if err != nil {
+			m.handleError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewAuthenticatedContext(r.Context(), token, appCheckToken)))
+	})
+}
+
+// VerifyRequest verifies the credentials of the given HTTP request in the same way as Handler,
+// and returns the decoded ID token or session cookie, along with the decoded App Check token if
+// the Middleware is configured to verify App Check tokens.
+//
+// The returned Token is nil if the request has no credentials, and AllowUnauthenticated is set.
+func (m *Middleware) VerifyRequest(r *http.Request) (*Token, *appcheck.DecodedAppCheckToken, error) {
+	appCheckToken, err := m.verifyAppCheckToken(r.Header.Get(appCheckHeader))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := m.verifyHTTPCredential(r)
+	if err != nil {
+		if m.config.AllowUnauthenticated && isMissingCredential(err) {
+			return nil, appCheckToken, nil
+		}
+		return nil, nil, err
+	}
+	return token, appCheckToken, nil
+}
+
+// VerifyCredentials verifies the credentials of a request received over a transport other than
+// HTTP, such as gRPC, in the same way as VerifyRequest. The authorization argument is the value of
+// the authorization header or metadata of the request, and appCheckToken is the value of its
+// X-Firebase-AppCheck header or metadata. Session cookies are not supported.
+//
+// The returned Token is nil if the request has no credentials, and AllowUnauthenticated is set.
+func (m *Middleware) VerifyCredentials(
+	ctx context.Context, authorization, appCheckToken string) (*Token, *appcheck.DecodedAppCheckToken, error) {
+	decodedAppCheckToken, err := m.verifyAppCheckToken(appCheckToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if authorization == "" {
+		if m.config.AllowUnauthenticated {
+			return nil, decodedAppCheckToken, nil
+		}
+		return nil, nil, errMissingCredential
+	}
+	idToken, err := bearerToken(authorization)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, err := m.verifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return token, decodedAppCheckToken, nil
+}
+
+func (m *Middleware) verifyHTTPCredential(r *http.Request) (*Token, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		idToken, err := bearerToken(header)
+		if err != nil {
+			return nil, err
+		}
+		return m.verifyIDToken(r.Context(), idToken)
+	}
+
+	if m.config.SessionCookieName != "" {
+		if cookie, err := r.Cookie(m.config.SessionCookieName); err == nil && cookie.Value != "" {
+			if m.config.CheckRevoked {
+				return m.client.VerifySessionCookieAndCheckRevoked(r.Context(), cookie.Value)
+			}
+			return m.client.VerifySessionCookie(r.Context(), cookie.Value)
+		}
+	}
+	return nil, errMissingCredential
+}
+
+// verifyAppCheckToken verifies the given App Check token, if the Middleware is configured to
+// verify App Check tokens. Returns nil otherwise.
+func (m *Middleware) verifyAppCheckToken(token string) (*appcheck.DecodedAppCheckToken, error) {
+	if m.config.AppCheck == nil {
+		return nil, nil
+	}
+	if token == "" {
+		return nil, errMissingAppCheckToken
+	}
+	decoded, err := m.config.AppCheck.VerifyToken(token)
+	if err != nil {
+		if !appcheck.IsTokenInvalid(err) {
+			return nil, &internal.FirebaseError{
+				ErrorCode: internal.Unknown,
+				String:    fmt.Sprintf("failed to verify App Check token: %v", err),
+				Details:   err,
+			}
+		}
+		return nil, &internal.FirebaseError{
+			ErrorCode: internal.Unauthenticated,
+			String:    fmt.Sprintf("invalid App Check token: %v", err),
+			Details:   err,
+		}
+	}
+	return decoded, nil
+}
+
+func (m *Middleware) verifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	if m.config.CheckRevoked {
+		return m.client.VerifyIDTokenAndCheckRevoked(ctx, idToken)
+	}
+	return m.client.VerifyIDToken(ctx, idToken)
+}
+
+func (m *Middleware) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if m.config.ErrorHandler != nil {
+		m.config.ErrorHandler(w, r, err)
+		return
+	}
+	if IsUnauthenticatedRequest(err) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+var (
+	errMissingCredential = &internal.FirebaseError{
+		ErrorCode: internal.Unauthenticated,
+		String:    "request has no ID token or session cookie",
+	}
+	errMalformedAuthorization = &internal.FirebaseError{
+		ErrorCode: internal.Unauthenticated,
+		String:    "authorization header must be of the form \"Bearer <ID token>\"",
+	}
+	errMissingAppCheckToken = &internal.FirebaseError{
+		ErrorCode: internal.Unauthenticated,
+		String:    "request has no App Check token",
+	}
+)
+
+// bearerToken extracts the token from an Authorization header value that uses the Bearer scheme.
+func bearerToken(header string) (string, error) {
+	scheme, token, ok := strings.Cut(header, " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", errMalformedAuthorization
+	}
+	return token, nil
+}
+
+func isMissingCredential(err error) bool {
+	return err == errMissingCredential
+}
+
+// IsUnauthenticatedRequest reports whether an error returned by VerifyRequest or VerifyCredentials
+// was caused by missing or unacceptable credentials, as opposed to a failure to verify them.
+//
+// Unauthenticated errors returned by the backend, for example when checking for revocation,
+// indicate a misconfiguration of the server instead. Credentials issued for another tenant, or for
+// a user that has since been deleted, are unacceptable.
+func IsUnauthenticatedRequest(err error) bool {
+	if fe, ok := err.(*internal.FirebaseError); ok && fe.ErrorCode == internal.Unauthenticated && fe.Response == nil {
+		return true
+	}
+	return IsIDTokenInvalid(err) || IsSessionCookieInvalid(err) || IsTenantIDMismatch(err) ||
+		IsUserNotFound(err)
+}
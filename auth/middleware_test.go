@@ -0,0 +1,457 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"firebase.google.com/go/v4/appcheck"
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+)
+
+func middlewareForTests(t *testing.T, config *MiddlewareConfig) *Middleware {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+			cookieVerifier:  testCookieVerifier,
+		},
+	}
+	m, err := NewMiddleware(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// serveWithMiddleware sends the request through the middleware, and returns the response along
+// with the token seen by the next handler.
+func serveWithMiddleware(m *Middleware, r *http.Request) (*httptest.ResponseRecorder, *Token, bool) {
+	var (
+		token  *Token
+		called bool
+	)
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		token, _ = TokenFromContext(r.Context())
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	return rec, token, called
+}
+
+func TestNewMiddlewareNilClient(t *testing.T) {
+	m, err := NewMiddleware(nil, nil)
+	if m != nil || err == nil {
+		t.Errorf("NewMiddleware(nil) = (%v, %v); want = (nil, error)", m, err)
+	}
+}
+
+func TestMiddlewareIDToken(t *testing.T) {
+	m := middlewareForTests(t, nil)
+	for _, header := range []string{"Bearer " + testIDToken, "bearer  " + testIDToken} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", header)
+
+		rec, token, called := serveWithMiddleware(m, r)
+		if rec.Code != http.StatusOK || !called {
+			t.Fatalf("Handler(%q) = %d; want = %d", header, rec.Code, http.StatusOK)
+		}
+		if token == nil || token.UID != "1234567890" {
+			t.Errorf("TokenFromContext() = %v; want = token for %q", token, "1234567890")
+		}
+	}
+}
+
+func TestMiddlewareSessionCookie(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{SessionCookieName: "session"})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: testSessionCookie})
+
+	rec, token, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusOK || !called {
+		t.Fatalf("Handler() = %d; want = %d", rec.Code, http.StatusOK)
+	}
+	if token == nil || token.UID != "1234567890" {
+		t.Errorf("TokenFromContext() = %v; want = token for %q", token, "1234567890")
+	}
+}
+
+func TestMiddlewareSessionCookieNotConfigured(t *testing.T) {
+	m := middlewareForTests(t, nil)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: testSessionCookie})
+
+	rec, _, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusUnauthorized || called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareUnauthorized(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{SessionCookieName: "session"})
+	cases := []struct {
+		name   string
+		header string
+		cookie string
+	}{
+		{"NoCredentials", "", ""},
+		{"NotBearer", "Basic " + testIDToken, ""},
+		{"NoToken", "Bearer ", ""},
+		{"InvalidIDToken", "Bearer not.a.token", ""},
+		{"ExpiredIDToken", "Bearer " + getIDToken(mockIDTokenPayload{"exp": testClock.Now().Unix() - clockSkewSeconds - 1}), ""},
+		{"SessionCookieAsIDToken", "Bearer " + testSessionCookie, ""},
+		{"InvalidSessionCookie", "", "not.a.cookie"},
+		{"IDTokenAsSessionCookie", "", testIDToken},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.header != "" {
+			r.Header.Set("Authorization", tc.header)
+		}
+		if tc.cookie != "" {
+			r.AddCookie(&http.Cookie{Name: "session", Value: tc.cookie})
+		}
+
+		rec, _, called := serveWithMiddleware(m, r)
+		if rec.Code != http.StatusUnauthorized || called {
+			t.Errorf("Handler(%s) = %d; want = %d", tc.name, rec.Code, http.StatusUnauthorized)
+		}
+		if got := rec.Header().Get("WWW-Authenticate"); got != "Bearer" {
+			t.Errorf("Handler(%s) WWW-Authenticate = %q; want = %q", tc.name, got, "Bearer")
+		}
+	}
+}
+
+func TestMiddlewareCertificateFetchError(t *testing.T) {
+	tv, err := newIDTokenVerifier(context.Background(), testProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tv.keySource = &mockKeySource{nil, errors.New("mock error")}
+	m, err := NewMiddleware(&Client{baseClient: &baseClient{idTokenVerifier: tv}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+testIDToken)
+
+	rec, _, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusInternalServerError || called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestMiddlewareAllowUnauthenticated(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{AllowUnauthenticated: true})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec, token, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusOK || !called {
+		t.Fatalf("Handler() = %d; want = %d", rec.Code, http.StatusOK)
+	}
+	if token != nil {
+		t.Errorf("TokenFromContext() = %v; want = nil", token)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer not.a.token")
+	rec, _, called = serveWithMiddleware(m, r)
+	if rec.Code != http.StatusUnauthorized || called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareCheckRevoked(t *testing.T) {
+	s := echoServer(testGetUserResponse, t)
+	defer s.Close()
+	s.Client.idTokenVerifier = testIDTokenVerifier
+	m, err := NewMiddleware(s.Client, &MiddlewareConfig{CheckRevoked: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+testIDToken)
+	rec, _, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusOK || !called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusOK)
+	}
+
+	revokedToken := getIDToken(mockIDTokenPayload{"uid": "uid", "iat": 1970})
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+revokedToken)
+	rec, _, called = serveWithMiddleware(m, r)
+	if rec.Code != http.StatusUnauthorized || called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareErrorHandler(t *testing.T) {
+	var handlerErr error
+	m := middlewareForTests(t, &MiddlewareConfig{
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			handlerErr = err
+			w.WriteHeader(http.StatusForbidden)
+		},
+	})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer not.a.token")
+
+	rec, _, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusForbidden || called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusForbidden)
+	}
+	if !IsIDTokenInvalid(handlerErr) {
+		t.Errorf("ErrorHandler() err = %v; want = IDTokenInvalid", handlerErr)
+	}
+}
+
+func TestNewContextWithToken(t *testing.T) {
+	if token, ok := TokenFromContext(context.Background()); token != nil || ok {
+		t.Errorf("TokenFromContext() = (%v, %v); want = (nil, false)", token, ok)
+	}
+
+	want := &Token{UID: "uid"}
+	token, ok := TokenFromContext(NewContextWithToken(context.Background(), want))
+	if token != want || !ok {
+		t.Errorf("TokenFromContext() = (%v, %v); want = (%v, true)", token, ok, want)
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	m := middlewareForTests(t, nil)
+	token, appCheckToken, err := m.VerifyCredentials(context.Background(), "Bearer "+testIDToken, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == nil || token.UID != "1234567890" || appCheckToken != nil {
+		t.Errorf("VerifyCredentials() = (%v, %v); want = (token for %q, nil)", token, appCheckToken, "1234567890")
+	}
+}
+
+func TestVerifyCredentialsError(t *testing.T) {
+	tv, err := newIDTokenVerifier(context.Background(), testProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tv.keySource = &mockKeySource{nil, errors.New("mock error")}
+	fetchErrorMiddleware, err := NewMiddleware(&Client{baseClient: &baseClient{idTokenVerifier: tv}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name          string
+		m             *Middleware
+		authorization string
+		want          bool
+	}{
+		{"NoCredentials", middlewareForTests(t, nil), "", true},
+		{"NotBearer", middlewareForTests(t, nil), testIDToken, true},
+		{"InvalidIDToken", middlewareForTests(t, nil), "Bearer not.a.token", true},
+		{"CertificateFetchError", fetchErrorMiddleware, "Bearer " + testIDToken, false},
+	}
+	for _, tc := range cases {
+		token, _, err := tc.m.VerifyCredentials(context.Background(), tc.authorization, "")
+		if token != nil || err == nil || IsUnauthenticatedRequest(err) != tc.want {
+			t.Errorf("VerifyCredentials(%s) = (%v, %v); want IsUnauthenticatedRequest = %v",
+				tc.name, token, err, tc.want)
+		}
+	}
+}
+
+func TestVerifyCredentialsAllowUnauthenticated(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{AllowUnauthenticated: true})
+	token, appCheckToken, err := m.VerifyCredentials(context.Background(), "", "")
+	if token != nil || appCheckToken != nil || err != nil {
+		t.Errorf("VerifyCredentials() = (%v, %v, %v); want = (nil, nil, nil)", token, appCheckToken, err)
+	}
+}
+
+func TestIsUnauthenticatedRequest(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"MissingCredential", errMissingCredential, true},
+		{"TenantIDMismatch", withAuthErrorCode(&internal.FirebaseError{ErrorCode: internal.InvalidArgument}, tenantIDMismatch), true},
+		{"UserNotFound", withAuthErrorCode(&internal.FirebaseError{ErrorCode: internal.NotFound}, userNotFound), true},
+		{"UserDisabled", withAuthErrorCode(&internal.FirebaseError{ErrorCode: internal.InvalidArgument}, userDisabled), true},
+		{"CertificateFetchFailed", withAuthErrorCode(&internal.FirebaseError{ErrorCode: internal.Unknown}, certificateFetchFailed), false},
+		{"Other", errors.New("other error"), false},
+	}
+	for _, tc := range cases {
+		if got := IsUnauthenticatedRequest(tc.err); got != tc.want {
+			t.Errorf("IsUnauthenticatedRequest(%s) = %v; want = %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNewAuthenticatedContext(t *testing.T) {
+	token := &Token{UID: "uid"}
+	appCheckToken := &appcheck.DecodedAppCheckToken{AppID: "app-id"}
+	ctx := NewAuthenticatedContext(context.Background(), token, appCheckToken)
+	if got, ok := TokenFromContext(ctx); got != token || !ok {
+		t.Errorf("TokenFromContext() = (%v, %v); want = (%v, true)", got, ok, token)
+	}
+	if got, ok := AppCheckTokenFromContext(ctx); got != appCheckToken || !ok {
+		t.Errorf("AppCheckTokenFromContext() = (%v, %v); want = (%v, true)", got, ok, appCheckToken)
+	}
+
+	ctx = NewAuthenticatedContext(context.Background(), nil, nil)
+	if got, ok := TokenFromContext(ctx); got != nil || ok {
+		t.Errorf("TokenFromContext() = (%v, %v); want = (nil, false)", got, ok)
+	}
+}
+
+type mockAppCheckVerifier struct{}
+
+var errAppCheckKeyFetch = errors.New("failed to fetch the public keys")
+
+func (v *mockAppCheckVerifier) VerifyToken(token string) (*appcheck.DecodedAppCheckToken, error) {
+	switch token {
+	case "valid-app-check-token":
+		return &appcheck.DecodedAppCheckToken{AppID: "app-id"}, nil
+	case "unverifiable-app-check-token":
+		return nil, errAppCheckKeyFetch
+	default:
+		return nil, appcheck.ErrTokenAudience
+	}
+}
+
+func TestMiddlewareAppCheck(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{AppCheck: &mockAppCheckVerifier{}})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+testIDToken)
+	r.Header.Set("X-Firebase-AppCheck", "valid-app-check-token")
+
+	var appCheckToken *appcheck.DecodedAppCheckToken
+	var token *Token
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _ = TokenFromContext(r.Context())
+		appCheckToken, _ = AppCheckTokenFromContext(r.Context())
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Handler() = %d; want = %d", rec.Code, http.StatusOK)
+	}
+	if token == nil || token.UID != "1234567890" {
+		t.Errorf("TokenFromContext() = %v; want = token for %q", token, "1234567890")
+	}
+	if appCheckToken == nil || appCheckToken.AppID != "app-id" {
+		t.Errorf("AppCheckTokenFromContext() = %v; want = token for %q", appCheckToken, "app-id")
+	}
+}
+
+func TestMiddlewareAppCheckRejected(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{
+		AppCheck:             &mockAppCheckVerifier{},
+		AllowUnauthenticated: true,
+	})
+	cases := []struct {
+		name     string
+		appCheck string
+	}{
+		{"Missing", ""},
+		{"Invalid", "invalid-app-check-token"},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+testIDToken)
+		if tc.appCheck != "" {
+			r.Header.Set("X-Firebase-AppCheck", tc.appCheck)
+		}
+
+		token, appCheckToken, err := m.VerifyRequest(r)
+		if token != nil || appCheckToken != nil || !errorutils.IsUnauthenticated(err) {
+			t.Errorf("VerifyRequest(%s) = (%v, %v, %v); want = (nil, nil, Unauthenticated)",
+				tc.name, token, appCheckToken, err)
+		}
+
+		rec, _, called := serveWithMiddleware(m, r)
+		if rec.Code != http.StatusUnauthorized || called {
+			t.Errorf("Handler(%s) = %d; want = %d", tc.name, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestMiddlewareAppCheckInvalidTokenError(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{AppCheck: &mockAppCheckVerifier{}})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Firebase-AppCheck", "invalid-app-check-token")
+
+	_, _, err := m.VerifyRequest(r)
+	if !errors.Is(err, appcheck.ErrTokenAudience) {
+		t.Errorf("VerifyRequest() = %v; want = %v", err, appcheck.ErrTokenAudience)
+	}
+}
+
+func TestMiddlewareAppCheckVerificationFailure(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{AppCheck: &mockAppCheckVerifier{}})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+testIDToken)
+	r.Header.Set("X-Firebase-AppCheck", "unverifiable-app-check-token")
+
+	_, _, err := m.VerifyRequest(r)
+	if !errors.Is(err, errAppCheckKeyFetch) || IsUnauthenticatedRequest(err) {
+		t.Errorf("VerifyRequest() = %v; want = verification failure", err)
+	}
+
+	rec, _, called := serveWithMiddleware(m, r)
+	if rec.Code != http.StatusInternalServerError || called {
+		t.Errorf("Handler() = %d; want = %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestMiddlewareAppCheckWithoutUser(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{
+		AppCheck:             &mockAppCheckVerifier{},
+		AllowUnauthenticated: true,
+	})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Firebase-AppCheck", "valid-app-check-token")
+
+	token, appCheckToken, err := m.VerifyRequest(r)
+	if token != nil || err != nil {
+		t.Fatalf("VerifyRequest() = (%v, %v); want = (nil, nil)", token, err)
+	}
+	if appCheckToken == nil || appCheckToken.AppID != "app-id" {
+		t.Errorf("VerifyRequest() = %v; want = token for %q", appCheckToken, "app-id")
+	}
+}
+
+func TestVerifyCredentialsAppCheck(t *testing.T) {
+	m := middlewareForTests(t, &MiddlewareConfig{AppCheck: &mockAppCheckVerifier{}})
+	_, appCheckToken, err := m.VerifyCredentials(context.Background(), "Bearer "+testIDToken, "valid-app-check-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if appCheckToken == nil || appCheckToken.AppID != "app-id" {
+		t.Errorf("VerifyCredentials() = %v; want = token for %q", appCheckToken, "app-id")
+	}
+
+	_, _, err = m.VerifyCredentials(context.Background(), "Bearer "+testIDToken, "")
+	if !IsUnauthenticatedRequest(err) {
+		t.Errorf("VerifyCredentials() = %v; want = unauthenticated error", err)
+	}
+}
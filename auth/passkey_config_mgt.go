@@ -0,0 +1,125 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	rpIDKey            = "rpId"
+	expectedOriginsKey = "expectedOrigins"
+)
+
+// PasskeyConfig represents the passkey (WebAuthn) sign-in configuration of a project or tenant.
+type PasskeyConfig struct {
+	// The resource name of the passkey config.
+	Name string `json:"name,omitempty"`
+	// The relying party ID, which is the domain the passkeys are bound to.
+	RpID string `json:"rpId,omitempty"`
+	// The website or app origins from which passkey sign-in requests are accepted.
+	ExpectedOrigins []string `json:"expectedOrigins,omitempty"`
+}
+
+// PasskeyConfigToUpdate represents the options used to update the passkey configuration.
+type PasskeyConfigToUpdate struct {
+	params nestedMap
+}
+
+// RpID sets the relying party ID of the passkey configuration.
+func (pc *PasskeyConfigToUpdate) RpID(rpID string) *PasskeyConfigToUpdate {
+	return pc.set(rpIDKey, rpID)
+}
+
+// ExpectedOrigins sets the origins from which passkey sign-in requests are accepted.
+func (pc *PasskeyConfigToUpdate) ExpectedOrigins(origins []string) *PasskeyConfigToUpdate {
+	return pc.set(expectedOriginsKey, origins)
+}
+
+func (pc *PasskeyConfigToUpdate) set(key string, value interface{}) *PasskeyConfigToUpdate {
+	if pc.params == nil {
+		pc.params = make(nestedMap)
+	}
+	pc.params.Set(key, value)
+	return pc
+}
+
+func (pc *PasskeyConfigToUpdate) validate() error {
+	if val, ok := pc.params[rpIDKey]; ok && val.(string) == "" {
+		return errors.New("rpID must not be empty")
+	}
+	if val, ok := pc.params[expectedOriginsKey]; ok {
+		origins := val.([]string)
+		if len(origins) == 0 {
+			return errors.New("expectedOrigins must not be empty")
+		}
+		for _, origin := range origins {
+			if origin == "" {
+				return errors.New("expectedOrigins must not contain empty strings")
+			}
+		}
+	}
+	return nil
+}
+
+// GetPasskeyConfig returns the passkey configuration of the project, or of the tenant when called
+// on a TenantClient.
+func (c *baseClient) GetPasskeyConfig(ctx context.Context) (*PasskeyConfig, error) {
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    "/passkeyConfig",
+	}
+	var result PasskeyConfig
+	if _, err := c.makeRequest(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdatePasskeyConfig updates the passkey configuration of the project, or of the tenant when
+// called on a TenantClient.
+//
+// Passkey sign-in is enabled once both the relying party ID and the expected origins are set.
+func (c *baseClient) UpdatePasskeyConfig(ctx context.Context, config *PasskeyConfigToUpdate) (*PasskeyConfig, error) {
+	if config == nil {
+		return nil, errors.New("passkey config must not be nil")
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	mask := config.params.UpdateMask()
+	if len(mask) == 0 {
+		return nil, errors.New("no parameters specified in the update request")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodPatch,
+		URL:    "/passkeyConfig",
+		Body:   internal.NewJSONEntity(config.params),
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParam("updateMask", strings.Join(mask, ",")),
+		},
+	}
+	var result PasskeyConfig
+	if _, err := c.makeRequest(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
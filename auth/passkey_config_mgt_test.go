@@ -0,0 +1,134 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const passkeyConfigResponse = `{
+	"name": "projects/mock-project-id/passkeyConfig",
+	"rpId": "example.com",
+	"expectedOrigins": ["https://example.com", "https://app.example.com"]
+}`
+
+var testPasskeyConfig = &PasskeyConfig{
+	Name:            "projects/mock-project-id/passkeyConfig",
+	RpID:            "example.com",
+	ExpectedOrigins: []string{"https://example.com", "https://app.example.com"},
+}
+
+func TestGetPasskeyConfig(t *testing.T) {
+	s := echoServer([]byte(passkeyConfigResponse), t)
+	defer s.Close()
+
+	config, err := s.Client.GetPasskeyConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config, testPasskeyConfig) {
+		t.Errorf("GetPasskeyConfig() = %#v; want = %#v", config, testPasskeyConfig)
+	}
+
+	req := s.Req[0]
+	if req.Method != http.MethodGet || req.URL.Path != "/projects/mock-project-id/passkeyConfig" {
+		t.Errorf("GetPasskeyConfig() Request = %s %s; want = GET /projects/mock-project-id/passkeyConfig",
+			req.Method, req.URL.Path)
+	}
+}
+
+func TestUpdatePasskeyConfig(t *testing.T) {
+	s := echoServer([]byte(passkeyConfigResponse), t)
+	defer s.Close()
+
+	options := (&PasskeyConfigToUpdate{}).
+		RpID("example.com").
+		ExpectedOrigins([]string{"https://example.com", "https://app.example.com"})
+	config, err := s.Client.UpdatePasskeyConfig(context.Background(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config, testPasskeyConfig) {
+		t.Errorf("UpdatePasskeyConfig() = %#v; want = %#v", config, testPasskeyConfig)
+	}
+
+	req := s.Req[0]
+	if req.Method != http.MethodPatch || req.URL.Path != "/projects/mock-project-id/passkeyConfig" {
+		t.Errorf("UpdatePasskeyConfig() Request = %s %s; want = PATCH /projects/mock-project-id/passkeyConfig",
+			req.Method, req.URL.Path)
+	}
+	want := `{"expectedOrigins":["https://example.com","https://app.example.com"],"rpId":"example.com"}`
+	if string(s.Rbody) != want {
+		t.Errorf("UpdatePasskeyConfig() Body = %s; want = %s", string(s.Rbody), want)
+	}
+	mask := strings.Split(req.URL.Query().Get("updateMask"), ",")
+	sort.Strings(mask)
+	if wantMask := []string{"expectedOrigins", "rpId"}; !reflect.DeepEqual(mask, wantMask) {
+		t.Errorf("UpdatePasskeyConfig() updateMask = %v; want = %v", mask, wantMask)
+	}
+}
+
+func TestTenantUpdatePasskeyConfig(t *testing.T) {
+	s := echoServer([]byte(passkeyConfigResponse), t)
+	defer s.Close()
+
+	client, err := s.Client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := (&PasskeyConfigToUpdate{}).ExpectedOrigins([]string{"https://example.com"})
+	if _, err := client.UpdatePasskeyConfig(context.Background(), options); err != nil {
+		t.Fatal(err)
+	}
+
+	wantPath := "/projects/mock-project-id/tenants/tenantID/passkeyConfig"
+	if s.Req[0].URL.Path != wantPath {
+		t.Errorf("UpdatePasskeyConfig() URL = %q; want = %q", s.Req[0].URL.Path, wantPath)
+	}
+	if mask := s.Req[0].URL.Query().Get("updateMask"); mask != "expectedOrigins" {
+		t.Errorf("UpdatePasskeyConfig() updateMask = %q; want = %q", mask, "expectedOrigins")
+	}
+}
+
+func TestInvalidPasskeyConfigToUpdate(t *testing.T) {
+	cases := []struct {
+		config *PasskeyConfigToUpdate
+		want   string
+	}{
+		{nil, "passkey config must not be nil"},
+		{&PasskeyConfigToUpdate{}, "no parameters specified in the update request"},
+		{(&PasskeyConfigToUpdate{}).RpID(""), "rpID must not be empty"},
+		{(&PasskeyConfigToUpdate{}).ExpectedOrigins(nil), "expectedOrigins must not be empty"},
+		{(&PasskeyConfigToUpdate{}).ExpectedOrigins([]string{""}), "expectedOrigins must not contain empty strings"},
+	}
+
+	s := echoServer([]byte(passkeyConfigResponse), t)
+	defer s.Close()
+
+	for _, tc := range cases {
+		config, err := s.Client.UpdatePasskeyConfig(context.Background(), tc.config)
+		if config != nil || err == nil || err.Error() != tc.want {
+			t.Errorf("UpdatePasskeyConfig() = (%v, %v); want = (nil, %q)", config, err, tc.want)
+		}
+	}
+	if len(s.Req) != 0 {
+		t.Errorf("UpdatePasskeyConfig() Requests = %d; want = 0", len(s.Req))
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	minPasswordLength = 6
+	maxPasswordLength = 4096
+)
+
+// PasswordPolicyEnforcementState represents whether a password policy is enforced.
+type PasswordPolicyEnforcementState string
+
+// These constants represent the possible values for the PasswordPolicyEnforcementState type.
+const (
+	// EnforcementStateEnforce rejects sign ups and password updates that do not comply with the
+	// password policy.
+	EnforcementStateEnforce PasswordPolicyEnforcementState = "ENFORCE"
+	// EnforcementStateOff disables the password policy.
+	EnforcementStateOff PasswordPolicyEnforcementState = "OFF"
+)
+
+// PasswordPolicyConfig represents the password policy of a project or tenant.
+type PasswordPolicyConfig struct {
+	// The state of the password policy, whether it's enforced or not.
+	EnforcementState PasswordPolicyEnforcementState
+	// Whether users with non-compliant passwords are required to update them when signing in.
+	ForceUpgradeOnSignin bool
+	// The requirements that passwords must meet.
+	Constraints *CustomStrengthOptionsConfig
+}
+
+// CustomStrengthOptionsConfig represents the requirements of a password policy.
+type CustomStrengthOptionsConfig struct {
+	// Whether the password must contain an uppercase character.
+	RequireUppercase bool `json:"containsUppercaseCharacter,omitempty"`
+	// Whether the password must contain a lowercase character.
+	RequireLowercase bool `json:"containsLowercaseCharacter,omitempty"`
+	// Whether the password must contain a non-alphanumeric character.
+	RequireNonAlphanumeric bool `json:"containsNonAlphanumericCharacter,omitempty"`
+	// Whether the password must contain a number.
+	RequireNumeric bool `json:"containsNumericCharacter,omitempty"`
+	// The minimum length of the password, between 6 and 30 (inclusive). Defaults to 6.
+	MinLength int `json:"minPasswordLength,omitempty"`
+	// The maximum length of the password, up to 4096. Defaults to 4096.
+	MaxLength int `json:"maxPasswordLength,omitempty"`
+}
+
+type passwordPolicyConfigDAO struct {
+	EnforcementState       PasswordPolicyEnforcementState `json:"passwordPolicyEnforcementState,omitempty"`
+	ForceUpgradeOnSignin   bool                           `json:"forceUpgradeOnSignin,omitempty"`
+	PasswordPolicyVersions []*passwordPolicyVersion       `json:"passwordPolicyVersions,omitempty"`
+}
+
+type passwordPolicyVersion struct {
+	CustomStrengthOptions *CustomStrengthOptionsConfig `json:"customStrengthOptions,omitempty"`
+}
+
+// MarshalJSON converts the password policy into the format expected by the backend.
+func (ppc PasswordPolicyConfig) MarshalJSON() ([]byte, error) {
+	dao := passwordPolicyConfigDAO{
+		EnforcementState:     ppc.EnforcementState,
+		ForceUpgradeOnSignin: ppc.ForceUpgradeOnSignin,
+	}
+	if ppc.Constraints != nil {
+		dao.PasswordPolicyVersions = []*passwordPolicyVersion{
+			{CustomStrengthOptions: ppc.Constraints},
+		}
+	}
+	return json.Marshal(dao)
+}
+
+// UnmarshalJSON parses the password policy returned by the backend.
+func (ppc *PasswordPolicyConfig) UnmarshalJSON(b []byte) error {
+	var dao passwordPolicyConfigDAO
+	if err := json.Unmarshal(b, &dao); err != nil {
+		return err
+	}
+	*ppc = PasswordPolicyConfig{
+		EnforcementState:     dao.EnforcementState,
+		ForceUpgradeOnSignin: dao.ForceUpgradeOnSignin,
+	}
+	if len(dao.PasswordPolicyVersions) > 0 {
+		ppc.Constraints = dao.PasswordPolicyVersions[0].CustomStrengthOptions
+	}
+	return nil
+}
+
+func validatePasswordPolicyConfig(req map[string]interface{}) error {
+	val, ok := req[passwordPolicyConfigKey]
+	if !ok {
+		return nil
+	}
+	passwordPolicyConfig, ok := val.(PasswordPolicyConfig)
+	if !ok {
+		return fmt.Errorf("invalid type for PasswordPolicyConfig: %v", val)
+	}
+	return passwordPolicyConfig.validate()
+}
+
+func (ppc *PasswordPolicyConfig) validate() error {
+	if ppc == nil {
+		return nil
+	}
+	state := ppc.EnforcementState
+	if state != EnforcementStateEnforce && state != EnforcementStateOff {
+		return fmt.Errorf("\"PasswordPolicyConfig.EnforcementState\" must be 'ENFORCE' or 'OFF'")
+	}
+	if state == EnforcementStateEnforce && ppc.Constraints == nil {
+		return fmt.Errorf("\"PasswordPolicyConfig.Constraints\" must be defined when the policy is enforced")
+	}
+	return ppc.Constraints.validate()
+}
+
+func (cso *CustomStrengthOptionsConfig) validate() error {
+	if cso == nil {
+		return nil
+	}
+	if cso.MinLength != 0 && (cso.MinLength < minPasswordLength || cso.MinLength > 30) {
+		return fmt.Errorf("\"MinLength\" must be an integer between 6 and 30 (inclusive)")
+	}
+	if cso.MaxLength != 0 && (cso.MaxLength < cso.minLength() || cso.MaxLength > maxPasswordLength) {
+		return fmt.Errorf("\"MaxLength\" must be greater than or equal to \"MinLength\" and at most 4096")
+	}
+	return nil
+}
+
+func (cso *CustomStrengthOptionsConfig) minLength() int {
+	if cso.MinLength == 0 {
+		return minPasswordLength
+	}
+	return cso.MinLength
+}
+
+func (cso *CustomStrengthOptionsConfig) maxLength() int {
+	if cso.MaxLength == 0 {
+		return maxPasswordLength
+	}
+	return cso.MaxLength
+}
+
+// PasswordRequirement identifies a requirement of a password policy.
+type PasswordRequirement string
+
+// These constants represent the possible values for the PasswordRequirement type.
+const (
+	MinLengthRequirement       PasswordRequirement = "MIN_LENGTH"
+	MaxLengthRequirement       PasswordRequirement = "MAX_LENGTH"
+	UppercaseRequirement       PasswordRequirement = "UPPERCASE"
+	LowercaseRequirement       PasswordRequirement = "LOWERCASE"
+	NumericRequirement         PasswordRequirement = "NUMERIC"
+	NonAlphanumericRequirement PasswordRequirement = "NON_ALPHANUMERIC"
+)
+
+// PasswordValidationResult is the result of validating a password against a password policy.
+type PasswordValidationResult struct {
+	// Whether the password meets all the requirements of the policy.
+	Valid bool
+	// Whether the policy is enforced. Passwords that are not valid are rejected by the backend
+	// only when the policy is enforced.
+	Enforced bool
+	// The requirements of the policy that the password does not meet.
+	UnmetRequirements []PasswordRequirement
+	// The requirements the password was validated against.
+	Constraints CustomStrengthOptionsConfig
+}
+
+// ValidatePasswordLocally checks the given password against the password policy of the project,
+// or of the tenant when called on a TenantClient.
+//
+// The password policy is fetched from the backend on each call, and the password is checked
+// client-side: it is not sent to the backend, and no user is created. The result is advisory, as
+// the backend remains the authority on which passwords are accepted. Uppercase and lowercase
+// letters and digits are recognized in all scripts, and any other character counts as
+// non-alphanumeric. If no password policy is configured, the password is only checked against
+// the default length requirements.
+func (c *baseClient) ValidatePasswordLocally(ctx context.Context, password string) (*PasswordValidationResult, error) {
+	// Tenant resources are located at the tenant's root, while the project resource is the config.
+	url := "/config"
+	if c.tenantID != "" {
+		url = ""
+	}
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    url,
+	}
+	var result struct {
+		PasswordPolicyConfig *PasswordPolicyConfig `json:"passwordPolicyConfig"`
+	}
+	if _, err := c.makeRequest(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	var constraints CustomStrengthOptionsConfig
+	enforced := false
+	if ppc := result.PasswordPolicyConfig; ppc != nil {
+		enforced = ppc.EnforcementState == EnforcementStateEnforce
+		if ppc.Constraints != nil {
+			constraints = *ppc.Constraints
+		}
+	}
+	constraints.MinLength = constraints.minLength()
+	constraints.MaxLength = constraints.maxLength()
+
+	unmet := constraints.unmetRequirements(password)
+	return &PasswordValidationResult{
+		Valid:             len(unmet) == 0,
+		Enforced:          enforced,
+		UnmetRequirements: unmet,
+		Constraints:       constraints,
+	}, nil
+}
+
+func (cso *CustomStrengthOptionsConfig) unmetRequirements(password string) []PasswordRequirement {
+	var unmet []PasswordRequirement
+	length := utf8.RuneCountInString(password)
+	if length < cso.minLength() {
+		unmet = append(unmet, MinLengthRequirement)
+	}
+	if length > cso.maxLength() {
+		unmet = append(unmet, MaxLengthRequirement)
+	}
+
+	var upper, lower, numeric, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			numeric = true
+		case unicode.IsLetter(r):
+			// Letters of scripts without case are alphanumeric, but neither uppercase nor
+			// lowercase.
+		default:
+			other = true
+		}
+	}
+	if cso.RequireUppercase && !upper {
+		unmet = append(unmet, UppercaseRequirement)
+	}
+	if cso.RequireLowercase && !lower {
+		unmet = append(unmet, LowercaseRequirement)
+	}
+	if cso.RequireNumeric && !numeric {
+		unmet = append(unmet, NumericRequirement)
+	}
+	if cso.RequireNonAlphanumeric && !other {
+		unmet = append(unmet, NonAlphanumericRequirement)
+	}
+	return unmet
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const passwordPolicyConfigResponse = `{
+	"passwordPolicyConfig": {
+		"passwordPolicyEnforcementState": "ENFORCE",
+		"forceUpgradeOnSignin": true,
+		"passwordPolicyVersions": [
+			{
+				"customStrengthOptions": {
+					"containsUppercaseCharacter": true,
+					"containsNumericCharacter": true,
+					"minPasswordLength": 8,
+					"maxPasswordLength": 16
+				}
+			}
+		]
+	}
+}`
+
+var testPasswordPolicyConfig = &PasswordPolicyConfig{
+	EnforcementState:     EnforcementStateEnforce,
+	ForceUpgradeOnSignin: true,
+	Constraints: &CustomStrengthOptionsConfig{
+		RequireUppercase: true,
+		RequireNumeric:   true,
+		MinLength:        8,
+		MaxLength:        16,
+	},
+}
+
+func TestGetProjectConfigPasswordPolicy(t *testing.T) {
+	s := echoServer([]byte(passwordPolicyConfigResponse), t)
+	defer s.Close()
+
+	projectConfig, err := s.Client.GetProjectConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(projectConfig.PasswordPolicyConfig, testPasswordPolicyConfig) {
+		t.Errorf("GetProjectConfig().PasswordPolicyConfig = %#v; want = %#v",
+			projectConfig.PasswordPolicyConfig, testPasswordPolicyConfig)
+	}
+}
+
+func TestUpdateProjectConfigPasswordPolicy(t *testing.T) {
+	s := echoServer([]byte(passwordPolicyConfigResponse), t)
+	defer s.Close()
+
+	options := (&ProjectConfigToUpdate{}).PasswordPolicyConfig(*testPasswordPolicyConfig)
+	if _, err := s.Client.UpdateProjectConfig(context.Background(), options); err != nil {
+		t.Fatal(err)
+	}
+
+	wantBody := map[string]interface{}{
+		"passwordPolicyConfig": map[string]interface{}{
+			"passwordPolicyEnforcementState": "ENFORCE",
+			"forceUpgradeOnSignin":           true,
+			"passwordPolicyVersions": []interface{}{
+				map[string]interface{}{
+					"customStrengthOptions": map[string]interface{}{
+						"containsUppercaseCharacter": true,
+						"containsNumericCharacter":   true,
+						"minPasswordLength":          float64(8),
+						"maxPasswordLength":          float64(16),
+					},
+				},
+			},
+		},
+	}
+	wantMask := []string{"passwordPolicyConfig"}
+	if err := checkUpdateProjectConfigRequest(s, wantBody, wantMask); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateTenantPasswordPolicy(t *testing.T) {
+	s := echoServer([]byte(`{"name": "projects/mock-project-id/tenants/tenantID"}`), t)
+	defer s.Close()
+
+	options := (&TenantToUpdate{}).PasswordPolicyConfig(PasswordPolicyConfig{
+		EnforcementState: EnforcementStateOff,
+	})
+	if _, err := s.Client.TenantManager.UpdateTenant(context.Background(), "tenantID", options); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"passwordPolicyConfig":{"passwordPolicyEnforcementState":"OFF"}}`
+	if string(s.Rbody) != want {
+		t.Errorf("UpdateTenant() Body = %s; want = %s", string(s.Rbody), want)
+	}
+	if mask := s.Req[0].URL.Query().Get("updateMask"); mask != "passwordPolicyConfig" {
+		t.Errorf("UpdateTenant() updateMask = %q; want = %q", mask, "passwordPolicyConfig")
+	}
+}
+
+func TestInvalidPasswordPolicyConfig(t *testing.T) {
+	cases := []struct {
+		config PasswordPolicyConfig
+		want   string
+	}{
+		{
+			PasswordPolicyConfig{},
+			`"PasswordPolicyConfig.EnforcementState" must be 'ENFORCE' or 'OFF'`,
+		},
+		{
+			PasswordPolicyConfig{EnforcementState: EnforcementStateEnforce},
+			`"PasswordPolicyConfig.Constraints" must be defined when the policy is enforced`,
+		},
+		{
+			PasswordPolicyConfig{
+				EnforcementState: EnforcementStateEnforce,
+				Constraints:      &CustomStrengthOptionsConfig{MinLength: 5},
+			},
+			`"MinLength" must be an integer between 6 and 30 (inclusive)`,
+		},
+		{
+			PasswordPolicyConfig{
+				EnforcementState: EnforcementStateEnforce,
+				Constraints:      &CustomStrengthOptionsConfig{MinLength: 10, MaxLength: 8},
+			},
+			`"MaxLength" must be greater than or equal to "MinLength" and at most 4096`,
+		},
+		{
+			PasswordPolicyConfig{
+				EnforcementState: EnforcementStateOff,
+				Constraints:      &CustomStrengthOptionsConfig{MaxLength: 4097},
+			},
+			`"MaxLength" must be greater than or equal to "MinLength" and at most 4096`,
+		},
+	}
+
+	client := &baseClient{}
+	for _, tc := range cases {
+		options := (&ProjectConfigToUpdate{}).PasswordPolicyConfig(tc.config)
+		if _, err := client.UpdateProjectConfig(context.Background(), options); err == nil || err.Error() != tc.want {
+			t.Errorf("UpdateProjectConfig(%#v) = %v; want = %q", tc.config, err, tc.want)
+		}
+
+		tenant := (&TenantToCreate{}).PasswordPolicyConfig(tc.config)
+		if err := tenant.validate(); err == nil || err.Error() != tc.want {
+			t.Errorf("TenantToCreate.validate(%#v) = %v; want = %q", tc.config, err, tc.want)
+		}
+	}
+}
+
+func TestValidatePasswordLocally(t *testing.T) {
+	s := echoServer([]byte(passwordPolicyConfigResponse), t)
+	defer s.Close()
+
+	cases := []struct {
+		password string
+		want     []PasswordRequirement
+	}{
+		{"Passw0rd", nil},
+		{"Pass0", []PasswordRequirement{MinLengthRequirement}},
+		{"password", []PasswordRequirement{UppercaseRequirement, NumericRequirement}},
+		{"PASSWORD1" + strings.Repeat("x", 8), []PasswordRequirement{MaxLengthRequirement}},
+		{"Contraseña1", nil},
+		{"ÄÖÜäöüß٣", nil},
+		{"密码密码密码密码1", []PasswordRequirement{UppercaseRequirement}},
+	}
+	for _, tc := range cases {
+		result, err := s.Client.ValidatePasswordLocally(context.Background(), tc.password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Valid != (len(tc.want) == 0) || !result.Enforced {
+			t.Errorf("ValidatePasswordLocally(%q) = %#v; want Valid = %v, Enforced = true", tc.password, result, len(tc.want) == 0)
+		}
+		if !reflect.DeepEqual(result.UnmetRequirements, tc.want) {
+			t.Errorf("ValidatePasswordLocally(%q).UnmetRequirements = %v; want = %v", tc.password, result.UnmetRequirements, tc.want)
+		}
+	}
+
+	req := s.Req[0]
+	if req.Method != http.MethodGet || req.URL.Path != "/projects/mock-project-id/config" {
+		t.Errorf("ValidatePasswordLocally() Request = %s %s; want = GET /projects/mock-project-id/config", req.Method, req.URL.Path)
+	}
+}
+
+func TestValidatePasswordLocallyWithoutPolicy(t *testing.T) {
+	s := echoServer([]byte(`{}`), t)
+	defer s.Close()
+
+	result, err := s.Client.ValidatePasswordLocally(context.Background(), "12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &PasswordValidationResult{
+		UnmetRequirements: []PasswordRequirement{MinLengthRequirement},
+		Constraints: CustomStrengthOptionsConfig{
+			MinLength: 6,
+			MaxLength: 4096,
+		},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("ValidatePasswordLocally() = %#v; want = %#v", result, want)
+	}
+}
+
+func TestTenantValidatePasswordLocally(t *testing.T) {
+	s := echoServer([]byte(passwordPolicyConfigResponse), t)
+	defer s.Close()
+
+	client, err := s.Client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.ValidatePasswordLocally(context.Background(), "Passw0rd")
+	if err != nil || !result.Valid {
+		t.Errorf("ValidatePasswordLocally() = (%#v, %v); want = (valid, nil)", result, err)
+	}
+
+	wantPath := "/projects/mock-project-id/tenants/tenantID"
+	if s.Req[0].URL.Path != wantPath {
+		t.Errorf("ValidatePasswordLocally() URL = %q; want = %q", s.Req[0].URL.Path, wantPath)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxPhoneNumberDigits = 15
+	invalidPhoneNumber   = "phone number must be a valid, E.164 compliant identifier"
+)
+
+// phoneNumberSeparators are the formatting characters removed by NormalizePhoneNumber.
+var phoneNumberSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// ValidatePhoneNumber checks that the given string is a phone number in the E.164 format, which
+// is the format expected by Firebase Auth: a "+" sign followed by the country code and the
+// subscriber number, with up to 15 digits in total and no other characters (e.g. "+15555550100").
+//
+// The returned error describes what is wrong with the phone number. Use NormalizePhoneNumber to
+// accept phone numbers that contain formatting characters.
+func ValidatePhoneNumber(phone string) error {
+	return validateE164(phone, phone)
+}
+
+// NormalizePhoneNumber returns the E.164 form of the given phone number.
+//
+// Spaces, hyphens, dots and parentheses commonly used to format phone numbers are removed, so
+// that "+1 (555) 555-0100" is normalized to "+15555550100". The phone number must include the
+// "+" sign and the country code, as the country cannot be inferred otherwise. An error is
+// returned if the result is not a valid E.164 phone number.
+//
+// UserToCreate, UserToUpdate, UserToImport and the user lookup functions normalize phone numbers
+// automatically.
+func NormalizePhoneNumber(phone string) (string, error) {
+	normalized := phoneNumberSeparators.Replace(phone)
+	if err := validateE164(phone, normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
+// validateE164 checks that the normalized phone number is in the E.164 format. Errors refer to
+// the phone number as it was specified by the developer.
+func validateE164(phone, normalized string) error {
+	if phone == "" {
+		return errors.New("phone number must be a non-empty string")
+	}
+	if !strings.HasPrefix(normalized, "+") {
+		return fmt.Errorf("%s: %q must start with a \"+\" followed by the country code", invalidPhoneNumber, phone)
+	}
+
+	digits := normalized[1:]
+	if digits == "" {
+		return fmt.Errorf("%s: %q contains no digits", invalidPhoneNumber, phone)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("%s: %q contains the invalid character %q", invalidPhoneNumber, phone, r)
+		}
+	}
+	if digits[0] == '0' {
+		return fmt.Errorf("%s: the country code of %q must not start with 0", invalidPhoneNumber, phone)
+	}
+	if len(digits) > maxPhoneNumberDigits {
+		return fmt.Errorf("%s: %q has %d digits, but at most %d are allowed",
+			invalidPhoneNumber, phone, len(digits), maxPhoneNumberDigits)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidatePhoneNumber(t *testing.T) {
+	for _, phone := range []string{"+1", "+15555550100", "+442071838750", "+123456789012345"} {
+		if err := ValidatePhoneNumber(phone); err != nil {
+			t.Errorf("ValidatePhoneNumber(%q) = %v; want = nil", phone, err)
+		}
+	}
+}
+
+func TestValidatePhoneNumberError(t *testing.T) {
+	cases := []struct {
+		phone string
+		want  string
+	}{
+		{"", "phone number must be a non-empty string"},
+		{"15555550100", `phone number must be a valid, E.164 compliant identifier: "15555550100" must start with a "+" followed by the country code`},
+		{"+", `phone number must be a valid, E.164 compliant identifier: "+" contains no digits`},
+		{"+1 555 555 0100", `phone number must be a valid, E.164 compliant identifier: "+1 555 555 0100" contains the invalid character ' '`},
+		{"+1555CALLNOW", `phone number must be a valid, E.164 compliant identifier: "+1555CALLNOW" contains the invalid character 'C'`},
+		{"+0445555550100", `phone number must be a valid, E.164 compliant identifier: the country code of "+0445555550100" must not start with 0`},
+		{"+1234567890123456", `phone number must be a valid, E.164 compliant identifier: "+1234567890123456" has 16 digits, but at most 15 are allowed`},
+	}
+	for _, tc := range cases {
+		err := ValidatePhoneNumber(tc.phone)
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("ValidatePhoneNumber(%q) = %v; want = %q", tc.phone, err, tc.want)
+		}
+	}
+}
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	cases := []struct {
+		phone string
+		want  string
+	}{
+		{"+15555550100", "+15555550100"},
+		{"+1 555 555 0100", "+15555550100"},
+		{"+1 (555) 555-0100", "+15555550100"},
+		{"+44 20.7183.8750", "+442071838750"},
+		{" +15555550100 ", "+15555550100"},
+	}
+	for _, tc := range cases {
+		got, err := NormalizePhoneNumber(tc.phone)
+		if err != nil || got != tc.want {
+			t.Errorf("NormalizePhoneNumber(%q) = (%q, %v); want = (%q, nil)", tc.phone, got, err, tc.want)
+		}
+	}
+}
+
+func TestNormalizePhoneNumberError(t *testing.T) {
+	cases := []struct {
+		phone string
+		want  string
+	}{
+		{"", "phone number must be a non-empty string"},
+		{"   ", `phone number must be a valid, E.164 compliant identifier: "   " must start with a "+" followed by the country code`},
+		{"(555) 555-0100", `phone number must be a valid, E.164 compliant identifier: "(555) 555-0100" must start with a "+" followed by the country code`},
+		{"+1 555/555/0100", `phone number must be a valid, E.164 compliant identifier: "+1 555/555/0100" contains the invalid character '/'`},
+	}
+	for _, tc := range cases {
+		got, err := NormalizePhoneNumber(tc.phone)
+		if got != "" || err == nil || err.Error() != tc.want {
+			t.Errorf("NormalizePhoneNumber(%q) = (%q, %v); want = (\"\", %q)", tc.phone, got, err, tc.want)
+		}
+	}
+}
+
+func TestGetUserByPhoneNumberNormalized(t *testing.T) {
+	s := echoServer(testGetUserResponse, t)
+	defer s.Close()
+
+	if _, err := s.Client.GetUserByPhoneNumber(context.Background(), "+1 (234) 567-890"); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"phoneNumber":["+1234567890"]}`
+	if got := string(s.Rbody); got != want {
+		t.Errorf("GetUserByPhoneNumber() request = %s; want = %s", got, want)
+	}
+}
@@ -26,7 +26,12 @@ import (
 
 // ProjectConfig represents the properties to update on the provided project config.
 type ProjectConfig struct {
-	MultiFactorConfig *MultiFactorConfig `json:"mfa,omitEmpty"`
+	MultiFactorConfig    *MultiFactorConfig    `json:"mfa,omitEmpty"`
+	PasswordPolicyConfig *PasswordPolicyConfig `json:"passwordPolicyConfig,omitempty"`
+	SMSRegionConfig      *SMSRegionConfig      `json:"smsRegionConfig,omitempty"`
+	RecaptchaConfig      *RecaptchaConfig      `json:"recaptchaConfig,omitempty"`
+	EmailPrivacyConfig   *EmailPrivacyConfig   `json:"emailPrivacyConfig,omitempty"`
+	SignInConfig         *SignInConfig         `json:"signIn,omitempty"`
 }
 
 func (base *baseClient) GetProjectConfig(ctx context.Context) (*ProjectConfig, error) {
@@ -74,6 +79,13 @@ type ProjectConfigToUpdate struct {
 
 const (
 	multiFactorConfigProjectKey = "mfa"
+	passwordPolicyConfigKey     = "passwordPolicyConfig"
+	smsRegionConfigKey          = "smsRegionConfig"
+	recaptchaConfigKey          = "recaptchaConfig"
+	emailPrivacyConfigKey       = "emailPrivacyConfig"
+	emailSignInEnabledKey       = "signIn.email.enabled"
+	emailPasswordRequiredKey    = "signIn.email.passwordRequired"
+	anonymousSignInEnabledKey   = "signIn.anonymous.enabled"
 )
 
 // MultiFactorConfig configures the project's multi-factor settings
@@ -81,6 +93,45 @@ func (pc *ProjectConfigToUpdate) MultiFactorConfig(multiFactorConfig MultiFactor
 	return pc.set(multiFactorConfigProjectKey, multiFactorConfig)
 }
 
+// PasswordPolicyConfig configures the project's password policy.
+func (pc *ProjectConfigToUpdate) PasswordPolicyConfig(passwordPolicyConfig PasswordPolicyConfig) *ProjectConfigToUpdate {
+	return pc.set(passwordPolicyConfigKey, passwordPolicyConfig)
+}
+
+// SMSRegionConfig configures the regions where users are allowed to send verification SMS.
+func (pc *ProjectConfigToUpdate) SMSRegionConfig(smsRegionConfig SMSRegionConfig) *ProjectConfigToUpdate {
+	return pc.set(smsRegionConfigKey, smsRegionConfig)
+}
+
+// RecaptchaConfig configures the project's reCAPTCHA Enterprise protection.
+func (pc *ProjectConfigToUpdate) RecaptchaConfig(recaptchaConfig RecaptchaConfig) *ProjectConfigToUpdate {
+	// Keys are provisioned by the backend, and cannot be set.
+	recaptchaConfig.RecaptchaKeys = nil
+	return pc.set(recaptchaConfigKey, recaptchaConfig)
+}
+
+// EmailPrivacyConfig configures the project's email privacy settings.
+func (pc *ProjectConfigToUpdate) EmailPrivacyConfig(emailPrivacyConfig EmailPrivacyConfig) *ProjectConfigToUpdate {
+	return pc.set(emailPrivacyConfigKey, emailPrivacyConfig)
+}
+
+// AllowPasswordSignUp enables or disables the email sign-in provider of the project.
+func (pc *ProjectConfigToUpdate) AllowPasswordSignUp(allow bool) *ProjectConfigToUpdate {
+	return pc.set(emailSignInEnabledKey, allow)
+}
+
+// EnableEmailLinkSignIn enables or disables email link sign-in for the project.
+//
+// Disabling this makes the password required for email sign-in.
+func (pc *ProjectConfigToUpdate) EnableEmailLinkSignIn(enable bool) *ProjectConfigToUpdate {
+	return pc.set(emailPasswordRequiredKey, !enable)
+}
+
+// EnableAnonymousUsers enables or disables anonymous authentication for the project.
+func (pc *ProjectConfigToUpdate) EnableAnonymousUsers(enable bool) *ProjectConfigToUpdate {
+	return pc.set(anonymousSignInEnabledKey, enable)
+}
+
 func (pc *ProjectConfigToUpdate) set(key string, value interface{}) *ProjectConfigToUpdate {
 	pc.ensureParams().Set(key, value)
 	return pc
@@ -108,5 +159,17 @@ func (pc *ProjectConfigToUpdate) validate() error {
 			return err
 		}
 	}
-	return nil
+	if err := validateSMSRegionConfig(req); err != nil {
+		return err
+	}
+	if val, ok := req[recaptchaConfigKey]; ok {
+		recaptchaConfig, ok := val.(RecaptchaConfig)
+		if !ok {
+			return fmt.Errorf("invalid type for RecaptchaConfig: %v", val)
+		}
+		if err := recaptchaConfig.validate(); err != nil {
+			return err
+		}
+	}
+	return validatePasswordPolicyConfig(req)
 }
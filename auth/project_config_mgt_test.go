@@ -141,3 +141,196 @@ func checkUpdateProjectConfigRequest(s *mockAuthServer, wantBody interface{}, wa
 
 	return nil
 }
+
+func TestGetProjectConfigAuthSettings(t *testing.T) {
+	resp := `{
+		"smsRegionConfig": {
+			"allowlistOnly": {"allowedRegions": ["US", "CA"]}
+		},
+		"recaptchaConfig": {
+			"emailPasswordEnforcementState": "AUDIT",
+			"managedRules": [{"endScore": 0.3, "action": "BLOCK"}],
+			"recaptchaKeys": [{"type": "WEB", "key": "site-key"}],
+			"useAccountDefender": true
+		},
+		"emailPrivacyConfig": {
+			"enableImprovedEmailPrivacy": true
+		}
+	}`
+	s := echoServer([]byte(resp), t)
+	defer s.Close()
+
+	projectConfig, err := s.Client.GetProjectConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ProjectConfig{
+		SMSRegionConfig: &SMSRegionConfig{
+			AllowlistOnly: &AllowlistOnly{AllowedRegions: []string{"US", "CA"}},
+		},
+		RecaptchaConfig: &RecaptchaConfig{
+			EmailPasswordEnforcementState: RecaptchaAudit,
+			ManagedRules: []*RecaptchaManagedRule{
+				{EndScore: 0.3, Action: RecaptchaActionBlock},
+			},
+			RecaptchaKeys: []*RecaptchaKey{
+				{Type: RecaptchaKeyWeb, Key: "site-key"},
+			},
+			UseAccountDefender: true,
+		},
+		EmailPrivacyConfig: &EmailPrivacyConfig{
+			EnableImprovedEmailPrivacy: true,
+		},
+	}
+	if !reflect.DeepEqual(projectConfig, want) {
+		t.Errorf("GetProjectConfig() = %#v; want = %#v", projectConfig, want)
+	}
+}
+
+func TestUpdateProjectConfigAuthSettings(t *testing.T) {
+	s := echoServer([]byte(`{}`), t)
+	defer s.Close()
+
+	options := (&ProjectConfigToUpdate{}).
+		SMSRegionConfig(SMSRegionConfig{
+			AllowByDefault: &AllowByDefault{DisallowedRegions: []string{"AQ"}},
+		}).
+		RecaptchaConfig(RecaptchaConfig{
+			EmailPasswordEnforcementState: RecaptchaEnforce,
+			ManagedRules: []*RecaptchaManagedRule{
+				{EndScore: 0.5, Action: RecaptchaActionBlock},
+			},
+			RecaptchaKeys: []*RecaptchaKey{
+				{Type: RecaptchaKeyWeb, Key: "ignored"},
+			},
+		}).
+		EmailPrivacyConfig(EmailPrivacyConfig{})
+	if _, err := s.Client.UpdateProjectConfig(context.Background(), options); err != nil {
+		t.Fatal(err)
+	}
+
+	wantBody := map[string]interface{}{
+		"smsRegionConfig": map[string]interface{}{
+			"allowByDefault": map[string]interface{}{
+				"disallowedRegions": []interface{}{"AQ"},
+			},
+		},
+		"recaptchaConfig": map[string]interface{}{
+			"emailPasswordEnforcementState": "ENFORCE",
+			"managedRules": []interface{}{
+				map[string]interface{}{"endScore": 0.5, "action": "BLOCK"},
+			},
+		},
+		"emailPrivacyConfig": map[string]interface{}{
+			"enableImprovedEmailPrivacy": false,
+		},
+	}
+	wantMask := []string{"emailPrivacyConfig", "recaptchaConfig", "smsRegionConfig"}
+	if err := checkUpdateProjectConfigRequest(s, wantBody, wantMask); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetProjectConfigSignIn(t *testing.T) {
+	resp := `{
+		"signIn": {
+			"email": {"enabled": true, "passwordRequired": false},
+			"anonymous": {"enabled": true}
+		}
+	}`
+	s := echoServer([]byte(resp), t)
+	defer s.Close()
+
+	projectConfig, err := s.Client.GetProjectConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &SignInConfig{
+		Email:     &EmailSignInConfig{Enabled: true},
+		Anonymous: &AnonymousSignInConfig{Enabled: true},
+	}
+	if !reflect.DeepEqual(projectConfig.SignInConfig, want) {
+		t.Errorf("GetProjectConfig().SignInConfig = %#v; want = %#v", projectConfig.SignInConfig, want)
+	}
+}
+
+func TestUpdateProjectConfigSignIn(t *testing.T) {
+	s := echoServer([]byte(`{}`), t)
+	defer s.Close()
+
+	options := (&ProjectConfigToUpdate{}).
+		AllowPasswordSignUp(true).
+		EnableEmailLinkSignIn(false).
+		EnableAnonymousUsers(true)
+	if _, err := s.Client.UpdateProjectConfig(context.Background(), options); err != nil {
+		t.Fatal(err)
+	}
+
+	wantBody := map[string]interface{}{
+		"signIn": map[string]interface{}{
+			"email": map[string]interface{}{
+				"enabled":          true,
+				"passwordRequired": true,
+			},
+			"anonymous": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+	}
+	wantMask := []string{"signIn.anonymous.enabled", "signIn.email.enabled", "signIn.email.passwordRequired"}
+	if err := checkUpdateProjectConfigRequest(s, wantBody, wantMask); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateProjectConfigInvalidAuthSettings(t *testing.T) {
+	cases := []struct {
+		options *ProjectConfigToUpdate
+		want    string
+	}{
+		{
+			(&ProjectConfigToUpdate{}).SMSRegionConfig(SMSRegionConfig{}),
+			`exactly one of "AllowByDefault" or "AllowlistOnly" must be specified`,
+		},
+		{
+			(&ProjectConfigToUpdate{}).SMSRegionConfig(SMSRegionConfig{
+				AllowByDefault: &AllowByDefault{},
+				AllowlistOnly:  &AllowlistOnly{},
+			}),
+			`exactly one of "AllowByDefault" or "AllowlistOnly" must be specified`,
+		},
+		{
+			(&ProjectConfigToUpdate{}).SMSRegionConfig(SMSRegionConfig{
+				AllowlistOnly: &AllowlistOnly{AllowedRegions: []string{"USA"}},
+			}),
+			`invalid region code: "USA"`,
+		},
+		{
+			(&ProjectConfigToUpdate{}).RecaptchaConfig(RecaptchaConfig{
+				EmailPasswordEnforcementState: "ON",
+			}),
+			"reCAPTCHA enforcement state must be 'OFF', 'AUDIT' or 'ENFORCE'",
+		},
+		{
+			(&ProjectConfigToUpdate{}).RecaptchaConfig(RecaptchaConfig{
+				ManagedRules: []*RecaptchaManagedRule{{EndScore: 1.5, Action: RecaptchaActionBlock}},
+			}),
+			`"EndScore" must be a number between 0.0 and 1.0 (inclusive)`,
+		},
+		{
+			(&ProjectConfigToUpdate{}).RecaptchaConfig(RecaptchaConfig{
+				ManagedRules: []*RecaptchaManagedRule{{EndScore: 0.5}},
+			}),
+			`"Action" must be 'BLOCK'`,
+		},
+	}
+
+	client := &baseClient{}
+	for _, tc := range cases {
+		if _, err := client.UpdateProjectConfig(context.Background(), tc.options); err == nil || err.Error() != tc.want {
+			t.Errorf("UpdateProjectConfig() = %v; want = %q", err, tc.want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"firebase.google.com/go/v4/internal"
+	"firebase.google.com/go/v4/resourcename"
 	"google.golang.org/api/iterator"
 )
 
@@ -896,6 +897,9 @@ func (c *baseClient) makeRequest(
 	} else {
 		req.URL = fmt.Sprintf("%s/projects/%s%s", c.providerConfigEndpoint, c.projectID, req.URL)
 	}
+	if c.requestAnnotation != "" {
+		req.Opts = append(req.Opts, withRequestAnnotation(c.requestAnnotation))
+	}
 
 	return c.httpClient.DoAndUnmarshal(ctx, req, v)
 }
@@ -985,6 +989,10 @@ func validateSAMLConfigID(id string) error {
 
 func extractResourceID(name string) string {
 	// name format: "projects/project-id/resource/resource-id"
-	segments := strings.Split(name, "/")
-	return segments[len(segments)-1]
+	id, err := resourcename.LastID(name)
+	if err != nil {
+		segments := strings.Split(name, "/")
+		return segments[len(segments)-1]
+	}
+	return id
 }
@@ -0,0 +1,116 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ProviderConfigClient reads and creates the OIDC and SAML provider configurations of a project
+// or tenant. It is implemented by Client and TenantClient.
+type ProviderConfigClient interface {
+	OIDCProviderConfig(ctx context.Context, id string) (*OIDCProviderConfig, error)
+	CreateOIDCProviderConfig(ctx context.Context, config *OIDCProviderConfigToCreate) (*OIDCProviderConfig, error)
+	SAMLProviderConfig(ctx context.Context, id string) (*SAMLProviderConfig, error)
+	CreateSAMLProviderConfig(ctx context.Context, config *SAMLProviderConfigToCreate) (*SAMLProviderConfig, error)
+}
+
+// ToCreate returns the options to create a copy of the OIDC provider configuration.
+//
+// The returned options can be adjusted before creating the copy, for instance to change its ID.
+func (config *OIDCProviderConfig) ToCreate() *OIDCProviderConfigToCreate {
+	toCreate := (&OIDCProviderConfigToCreate{}).
+		ID(config.ID).
+		DisplayName(config.DisplayName).
+		Enabled(config.Enabled).
+		ClientID(config.ClientID).
+		Issuer(config.Issuer)
+	if config.ClientSecret != "" {
+		toCreate.ClientSecret(config.ClientSecret)
+	}
+	if config.CodeResponseType || config.IDTokenResponseType {
+		toCreate.CodeResponseType(config.CodeResponseType).
+			IDTokenResponseType(config.IDTokenResponseType)
+	}
+	return toCreate
+}
+
+// ToCreate returns the options to create a copy of the SAML provider configuration.
+//
+// The returned options can be adjusted before creating the copy, for instance to change its ID
+// or callback URL.
+func (config *SAMLProviderConfig) ToCreate() *SAMLProviderConfigToCreate {
+	return (&SAMLProviderConfigToCreate{}).
+		ID(config.ID).
+		DisplayName(config.DisplayName).
+		Enabled(config.Enabled).
+		IDPEntityID(config.IDPEntityID).
+		SSOURL(config.SSOURL).
+		RequestSigningEnabled(config.RequestSigningEnabled).
+		X509Certificates(config.X509Certificates).
+		RPEntityID(config.RPEntityID).
+		CallbackURL(config.CallbackURL)
+}
+
+// CloneOIDCProviderConfig copies the OIDC provider configuration with the given ID from one
+// project or tenant to another, such as from a staging project to a production project.
+//
+// The copy is created with targetID as its ID, or with the ID of the source configuration if
+// targetID is empty. An error is returned if the target already has a configuration with that ID.
+func CloneOIDCProviderConfig(
+	ctx context.Context, src, dst ProviderConfigClient, id, targetID string) (*OIDCProviderConfig, error) {
+	if src == nil || dst == nil {
+		return nil, errors.New("source and destination clients must not be nil")
+	}
+	if targetID == "" {
+		targetID = id
+	}
+	if err := validateOIDCConfigID(targetID); err != nil {
+		return nil, err
+	}
+
+	config, err := src.OIDCProviderConfig(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dst.CreateOIDCProviderConfig(ctx, config.ToCreate().ID(targetID))
+}
+
+// CloneSAMLProviderConfig copies the SAML provider configuration with the given ID from one
+// project or tenant to another, such as from a staging project to a production project.
+//
+// The copy is created with targetID as its ID, or with the ID of the source configuration if
+// targetID is empty. An error is returned if the target already has a configuration with that ID.
+// The RP entity ID and callback URL are copied as is. Use SAMLProviderConfig.ToCreate to adjust
+// them when they differ between the source and the target.
+func CloneSAMLProviderConfig(
+	ctx context.Context, src, dst ProviderConfigClient, id, targetID string) (*SAMLProviderConfig, error) {
+	if src == nil || dst == nil {
+		return nil, errors.New("source and destination clients must not be nil")
+	}
+	if targetID == "" {
+		targetID = id
+	}
+	if err := validateSAMLConfigID(targetID); err != nil {
+		return nil, err
+	}
+
+	config, err := src.SAMLProviderConfig(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dst.CreateSAMLProviderConfig(ctx, config.ToCreate().ID(targetID))
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const oidcCodeFlowConfigResponse = `{
+	"name": "projects/mock-project-id/oauthIdpConfigs/oidc.provider",
+	"clientId": "CLIENT_ID",
+	"issuer": "https://oidc.com/issuer",
+	"displayName": "oidcProviderName",
+	"enabled": true,
+	"clientSecret": "CLIENT_SECRET",
+	"responseType": {"code": true}
+}`
+
+func TestCloneOIDCProviderConfig(t *testing.T) {
+	src := echoServer([]byte(oidcCodeFlowConfigResponse), t)
+	defer src.Close()
+	dst := echoServer([]byte(oidcCodeFlowConfigResponse), t)
+	defer dst.Close()
+
+	tenantClient, err := dst.Client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := CloneOIDCProviderConfig(context.Background(), src.Client, tenantClient, "oidc.provider", "oidc.copy")
+	if err != nil || config == nil {
+		t.Fatalf("CloneOIDCProviderConfig() = (%v, %v); want = (config, nil)", config, err)
+	}
+
+	if req := src.Req[0]; req.Method != http.MethodGet ||
+		req.URL.Path != "/projects/mock-project-id/oauthIdpConfigs/oidc.provider" {
+		t.Errorf("Source request = %s %s; want = GET /projects/mock-project-id/oauthIdpConfigs/oidc.provider",
+			req.Method, req.URL.Path)
+	}
+	req := dst.Req[0]
+	if req.Method != http.MethodPost || req.URL.Path != "/projects/mock-project-id/tenants/tenantID/oauthIdpConfigs" {
+		t.Errorf("Target request = %s %s; want = POST /projects/mock-project-id/tenants/tenantID/oauthIdpConfigs",
+			req.Method, req.URL.Path)
+	}
+	if id := req.URL.Query().Get("oauthIdpConfigId"); id != "oidc.copy" {
+		t.Errorf("oauthIdpConfigId = %q; want = %q", id, "oidc.copy")
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(dst.Rbody, &body); err != nil {
+		t.Fatal(err)
+	}
+	wantBody := map[string]interface{}{
+		"displayName":  "oidcProviderName",
+		"enabled":      true,
+		"clientId":     "CLIENT_ID",
+		"issuer":       "https://oidc.com/issuer",
+		"clientSecret": "CLIENT_SECRET",
+		"responseType": map[string]interface{}{
+			"code":    true,
+			"idToken": false,
+		},
+	}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("Target body = %#v; want = %#v", body, wantBody)
+	}
+}
+
+func TestCloneSAMLProviderConfig(t *testing.T) {
+	src := echoServer([]byte(samlConfigResponse), t)
+	defer src.Close()
+	dst := echoServer([]byte(samlConfigResponse), t)
+	defer dst.Close()
+
+	config, err := CloneSAMLProviderConfig(context.Background(), src.Client, dst.Client, "saml.provider", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config, samlProviderConfig) {
+		t.Errorf("CloneSAMLProviderConfig() = %#v; want = %#v", config, samlProviderConfig)
+	}
+
+	req := dst.Req[0]
+	if req.Method != http.MethodPost || req.URL.Path != "/projects/mock-project-id/inboundSamlConfigs" {
+		t.Errorf("Target request = %s %s; want = POST /projects/mock-project-id/inboundSamlConfigs",
+			req.Method, req.URL.Path)
+	}
+	if id := req.URL.Query().Get("inboundSamlConfigId"); id != "saml.provider" {
+		t.Errorf("inboundSamlConfigId = %q; want = %q", id, "saml.provider")
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(dst.Rbody, &body); err != nil {
+		t.Fatal(err)
+	}
+	wantBody := map[string]interface{}{
+		"displayName": "samlProviderName",
+		"enabled":     true,
+		"idpConfig": map[string]interface{}{
+			"idpEntityId":     "IDP_ENTITY_ID",
+			"ssoUrl":          "https://example.com/login",
+			"signRequest":     true,
+			"idpCertificates": idpCertsMap,
+		},
+		"spConfig": map[string]interface{}{
+			"spEntityId":  "RP_ENTITY_ID",
+			"callbackUri": "https://projectId.firebaseapp.com/__/auth/handler",
+		},
+	}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("Target body = %#v; want = %#v", body, wantBody)
+	}
+}
+
+func TestCloneProviderConfigInvalidTarget(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	ctx := context.Background()
+	if _, err := CloneOIDCProviderConfig(ctx, s.Client, s.Client, "oidc.provider", "saml.provider"); err == nil {
+		t.Errorf("CloneOIDCProviderConfig(saml.provider) = nil; want = error")
+	}
+	if _, err := CloneSAMLProviderConfig(ctx, s.Client, s.Client, "saml.provider", "oidc.provider"); err == nil {
+		t.Errorf("CloneSAMLProviderConfig(oidc.provider) = nil; want = error")
+	}
+	if _, err := CloneOIDCProviderConfig(ctx, nil, s.Client, "oidc.provider", ""); err == nil {
+		t.Errorf("CloneOIDCProviderConfig(nil) = nil; want = error")
+	}
+	if len(s.Req) != 0 {
+		t.Errorf("Requests = %d; want = 0", len(s.Req))
+	}
+
+	// Configs with both response types enabled cannot be created via the SDK.
+	if _, err := CloneOIDCProviderConfig(ctx, s.Client, s.Client, "oidc.provider", ""); err == nil {
+		t.Errorf("CloneOIDCProviderConfig(code+idToken) = nil; want = error")
+	}
+	if len(s.Req) != 1 {
+		t.Errorf("Requests = %d; want = 1", len(s.Req))
+	}
+}
@@ -1455,3 +1455,18 @@ func checkUpdateSAMLConfigRequestWithURL(s *mockAuthServer, wantBody interface{}
 
 	return nil
 }
+
+func TestExtractResourceID(t *testing.T) {
+	cases := map[string]string{
+		"projects/mock-project-id/oauthIdpConfigs/oidc.provider": "oidc.provider",
+		"projects/mock-project-id/tenants/tenant-1":              "tenant-1",
+		"oauthIdpConfigs/oidc.provider":                          "oidc.provider",
+		"oidc.provider":                                          "oidc.provider",
+		"projects//oauthIdpConfigs/oidc.provider":                "oidc.provider",
+	}
+	for name, want := range cases {
+		if got := extractResourceID(name); got != want {
+			t.Errorf("extractResourceID(%q) = %q; want = %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// PrefetchPublicKeys loads the public keys used to verify ID tokens and session cookies, so that
+// the first verification does not incur the latency of fetching them.
+//
+// Keys that are already cached and have not expired are not fetched again.
+func (c *baseClient) PrefetchPublicKeys(ctx context.Context) error {
+	for _, tv := range c.tokenVerifiers() {
+		if _, err := tv.keySource.Keys(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InvalidatePublicKeys discards the cached public keys used to verify ID tokens and session
+// cookies. The keys are fetched again on the next verification, or the next call to
+// PrefetchPublicKeys.
+//
+// A KeySource set via SetIDTokenKeySource or SetSessionCookieKeySource is invalidated only if it
+// implements an Invalidate() method.
+func (c *baseClient) InvalidatePublicKeys() {
+	for _, tv := range c.tokenVerifiers() {
+		if ks, ok := tv.keySource.(interface{ Invalidate() }); ok {
+			ks.Invalidate()
+		}
+	}
+}
+
+// SetPublicKeyCacheTTL overrides the duration for which the public keys fetched from Google
+// servers are cached. By default, the keys are cached for the duration advertised in the
+// cache-control header of the response. A zero or negative TTL restores the default behavior.
+//
+// The new TTL takes effect the next time the keys are fetched.
+func (c *baseClient) SetPublicKeyCacheTTL(ttl time.Duration) {
+	for _, tv := range c.tokenVerifiers() {
+		if ks, ok := tv.keySource.(interface{ SetTTL(time.Duration) }); ok {
+			ks.SetTTL(ttl)
+		}
+	}
+}
+
+// SetIDTokenKeySource replaces the KeySource used to obtain the public keys for verifying ID
+// tokens.
+//
+// The KeySource is shared with all the tenant-aware clients created from the same Client. It
+// should be set before the client is used to verify any ID tokens.
+func (c *baseClient) SetIDTokenKeySource(ks KeySource) error {
+	return setKeySource(c.idTokenVerifier, ks)
+}
+
+// SetSessionCookieKeySource replaces the KeySource used to obtain the public keys for verifying
+// session cookies.
+//
+// The KeySource is shared with all the tenant-aware clients created from the same Client. It
+// should be set before the client is used to verify any session cookies.
+func (c *baseClient) SetSessionCookieKeySource(ks KeySource) error {
+	return setKeySource(c.cookieVerifier, ks)
+}
+
+func setKeySource(tv *tokenVerifier, ks KeySource) error {
+	if ks == nil {
+		return errors.New("key source must not be nil")
+	}
+	if tv == nil {
+		return errors.New("token verifier is not initialized")
+	}
+	tv.keySource = ks
+	return nil
+}
+
+func (c *baseClient) tokenVerifiers() []*tokenVerifier {
+	var verifiers []*tokenVerifier
+	for _, tv := range []*tokenVerifier{c.idTokenVerifier, c.cookieVerifier} {
+		if tv != nil {
+			verifiers = append(verifiers, tv)
+		}
+	}
+	return verifiers
+}
+
+type staticKeySource struct {
+	keys []*PublicKey
+}
+
+// NewStaticKeySource creates a KeySource that always returns the given set of public keys,
+// without making any network calls.
+//
+// Together with SetIDTokenKeySource and SetSessionCookieKeySource, it allows verifying tokens in
+// environments without network access to Google servers, or in unit tests.
+func NewStaticKeySource(keys []*PublicKey) (KeySource, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys must not be empty")
+	}
+	for _, k := range keys {
+		if k == nil || k.Key == nil {
+			return nil, errors.New("keys must not contain nil keys")
+		}
+	}
+	return &staticKeySource{
+		keys: append([]*PublicKey(nil), keys...),
+	}, nil
+}
+
+func (k *staticKeySource) Keys(ctx context.Context) ([]*PublicKey, error) {
+	return k.keys, nil
+}
+
+// ParsePEMPublicKeys parses a map of key IDs to PEM-encoded RSA keys.
+//
+// Each value may contain an X.509 certificate (as served by Google for verifying ID tokens), a
+// PKIX public key, or a PKCS #1 public key.
+func ParsePEMPublicKeys(keys map[string]string) ([]*PublicKey, error) {
+	var result []*PublicKey
+	for kid, key := range keys {
+		block, _ := pem.Decode([]byte(key))
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode the key %q as PEM", kid)
+		}
+
+		var pk interface{}
+		var err error
+		switch block.Type {
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				pk = cert.PublicKey
+			}
+		case "PUBLIC KEY":
+			pk, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			pk, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		default:
+			err = fmt.Errorf("unsupported PEM block type: %q", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the key %q: %v", kid, err)
+		}
+
+		rsaKey, ok := pk.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("key %q is not an RSA key", kid)
+		}
+		result = append(result, &PublicKey{Kid: kid, Key: rsaKey})
+	}
+	return result, nil
+}
+
+// ParseJWKS parses a JSON Web Key Set containing RSA public keys.
+func ParseJWKS(data []byte) ([]*PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	var result []*PublicKey
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" {
+			return nil, fmt.Errorf("key %q has unsupported key type: %q", jwk.Kid, jwk.Kty)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q has invalid modulus: %v", jwk.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q has invalid exponent: %v", jwk.Kid, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if len(n) == 0 || exp.BitLen() > 31 || exp.Int64() < 2 {
+			return nil, fmt.Errorf("key %q is not a valid RSA key", jwk.Kid)
+		}
+		result = append(result, &PublicKey{
+			Kid: jwk.Kid,
+			Key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(exp.Int64()),
+			},
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const maxQueryUsersLimit = 500
+
+// UserSortField is a field by which the users returned by QueryUsers can be sorted.
+type UserSortField string
+
+// These constants represent the possible values for the UserSortField type.
+const (
+	SortByEmail       UserSortField = "USER_EMAIL"
+	SortByUID         UserSortField = "USER_ID"
+	SortByDisplayName UserSortField = "NAME"
+	SortByCreatedAt   UserSortField = "CREATED_AT"
+	SortByLastLoginAt UserSortField = "LAST_LOGIN_AT"
+)
+
+// SortOrder is the order in which the users returned by QueryUsers are sorted.
+type SortOrder string
+
+// These constants represent the possible values for the SortOrder type.
+const (
+	Ascending  SortOrder = "ASC"
+	Descending SortOrder = "DESC"
+)
+
+// UserQueryExpression matches the users that have all the specified fields.
+type UserQueryExpression struct {
+	UID         string `json:"userId,omitempty"`
+	Email       string `json:"email,omitempty"`
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+}
+
+// QueryUsersRequest specifies the users returned by QueryUsers.
+//
+// The backend sorts and pages through the users of the project, and matches them against the
+// Expressions. The remaining filters (CreatedAfter, CreatedBefore, Disabled, HasEmail and
+// HasPhoneNumber) are not supported by the backend, and are applied by the SDK to the users of
+// the requested page. Therefore, when those filters are set, a page may contain fewer users than
+// the Limit even when more users are available.
+type QueryUsersRequest struct {
+	// The users matching any of the expressions are returned. If empty, all users are returned.
+	Expressions []*UserQueryExpression
+
+	// The field by which the users are sorted. Defaults to SortByUID.
+	SortBy UserSortField
+	// The order in which the users are sorted. Defaults to Ascending.
+	Order SortOrder
+	// The number of users to skip.
+	Offset int
+	// The maximum number of users in the page, up to 500. Defaults to 500.
+	Limit int
+
+	// Only users created after this time are returned.
+	CreatedAfter time.Time
+	// Only users created before this time are returned.
+	CreatedBefore time.Time
+	// Only users with the specified disabled status are returned.
+	Disabled *bool
+	// Only users with, or without, an email address are returned.
+	HasEmail *bool
+	// Only users with, or without, a phone number are returned.
+	HasPhoneNumber *bool
+}
+
+// QueryUsersResult is a page of users returned by QueryUsers.
+type QueryUsersResult struct {
+	// The users of the page that match the filters.
+	Users []*UserRecord
+	// The total number of users matching the Expressions.
+	Count int64
+	// The offset from which to query the next page, or -1 if this is the last page.
+	NextOffset int
+}
+
+type queryUsersRequest struct {
+	ReturnUserInfo bool                   `json:"returnUserInfo"`
+	Limit          int                    `json:"limit,string,omitempty"`
+	Offset         int                    `json:"offset,string,omitempty"`
+	SortBy         UserSortField          `json:"sortBy,omitempty"`
+	Order          SortOrder              `json:"order,omitempty"`
+	Expression     []*UserQueryExpression `json:"expression,omitempty"`
+}
+
+type queryUsersResponse struct {
+	RecordsCount int64                `json:"recordsCount,string"`
+	UserInfo     []*userQueryResponse `json:"userInfo"`
+}
+
+// QueryUsers returns a page of the users of the project, or of the tenant when called on a
+// TenantClient, sorted and filtered as specified by the request.
+//
+// Unlike Users, which lists all the users in a fixed order, QueryUsers supports sorting the
+// users and jumping to an arbitrary page via the Offset.
+//
+// The CreatedAfter, CreatedBefore, Disabled, HasEmail and HasPhoneNumber filters are applied by
+// the SDK to the page returned by the backend. When any of them is set, the page may therefore
+// contain fewer users than the Limit, or none at all, even though more matching users exist. Use
+// NextOffset, rather than the number of users in the page, to decide whether to fetch the next
+// page. Count is the number of users matching the Expressions, before these filters are applied.
+func (c *baseClient) QueryUsers(ctx context.Context, query *QueryUsersRequest) (*QueryUsersResult, error) {
+	if query == nil {
+		query = &QueryUsersRequest{}
+	}
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = maxQueryUsersLimit
+	}
+	req := &queryUsersRequest{
+		ReturnUserInfo: true,
+		Limit:          limit,
+		Offset:         query.Offset,
+		SortBy:         query.SortBy,
+		Order:          query.Order,
+		Expression:     query.Expressions,
+	}
+	var parsed queryUsersResponse
+	if _, err := c.post(ctx, "/accounts:query", req, &parsed); err != nil {
+		return nil, err
+	}
+
+	result := &QueryUsersResult{
+		Count:      parsed.RecordsCount,
+		NextOffset: -1,
+	}
+	for _, user := range parsed.UserInfo {
+		userRecord, err := user.makeUserRecord()
+		if err != nil {
+			return nil, err
+		}
+		if query.matches(userRecord) {
+			result.Users = append(result.Users, userRecord)
+		}
+	}
+	if next := query.Offset + len(parsed.UserInfo); len(parsed.UserInfo) == limit && int64(next) < parsed.RecordsCount {
+		result.NextOffset = next
+	}
+	return result, nil
+}
+
+func (q *QueryUsersRequest) validate() error {
+	if q.Limit < 0 || q.Limit > maxQueryUsersLimit {
+		return fmt.Errorf("limit must be between 0 and %d, where 0 selects the default", maxQueryUsersLimit)
+	}
+	if q.Offset < 0 {
+		return errors.New("offset must not be negative")
+	}
+	switch q.SortBy {
+	case "", SortByEmail, SortByUID, SortByDisplayName, SortByCreatedAt, SortByLastLoginAt:
+	default:
+		return fmt.Errorf("invalid sort field: %q", q.SortBy)
+	}
+	if q.Order != "" && q.Order != Ascending && q.Order != Descending {
+		return fmt.Errorf("invalid sort order: %q", q.Order)
+	}
+	for _, exp := range q.Expressions {
+		if exp == nil || (exp.UID == "" && exp.Email == "" && exp.PhoneNumber == "") {
+			return errors.New("expressions must specify at least one field")
+		}
+	}
+	if !q.CreatedAfter.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedAfter.Before(q.CreatedBefore) {
+		return errors.New("CreatedAfter must be before CreatedBefore")
+	}
+	return nil
+}
+
+func (q *QueryUsersRequest) matches(user *UserRecord) bool {
+	var createdAt time.Time
+	if user.UserMetadata != nil {
+		createdAt = time.UnixMilli(user.UserMetadata.CreationTimestamp)
+	}
+	if !q.CreatedAfter.IsZero() && !createdAt.After(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !createdAt.Before(q.CreatedBefore) {
+		return false
+	}
+	if q.Disabled != nil && user.Disabled != *q.Disabled {
+		return false
+	}
+	if q.HasEmail != nil && (user.Email != "") != *q.HasEmail {
+		return false
+	}
+	if q.HasPhoneNumber != nil && (user.PhoneNumber != "") != *q.HasPhoneNumber {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const queryUsersResponseBody = `{
+	"recordsCount": "5",
+	"userInfo": [
+		{"localId": "user1", "email": "user1@example.com", "createdAt": "1000"},
+		{"localId": "user2", "phoneNumber": "+11234567890", "createdAt": "2000", "disabled": true},
+		{"localId": "user3", "email": "user3@example.com", "createdAt": "3000"}
+	]
+}`
+
+func TestQueryUsers(t *testing.T) {
+	s := echoServer([]byte(queryUsersResponseBody), t)
+	defer s.Close()
+
+	result, err := s.Client.QueryUsers(context.Background(), &QueryUsersRequest{
+		Expressions: []*UserQueryExpression{{Email: "user1@example.com"}},
+		SortBy:      SortByCreatedAt,
+		Order:       Descending,
+		Offset:      1,
+		Limit:       3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var uids []string
+	for _, user := range result.Users {
+		uids = append(uids, user.UID)
+	}
+	if want := []string{"user1", "user2", "user3"}; !reflect.DeepEqual(uids, want) {
+		t.Errorf("QueryUsers().Users = %v; want = %v", uids, want)
+	}
+	if result.Count != 5 || result.NextOffset != 4 {
+		t.Errorf("QueryUsers() = {Count: %d, NextOffset: %d}; want = {Count: 5, NextOffset: 4}",
+			result.Count, result.NextOffset)
+	}
+
+	wantPath := "/projects/mock-project-id/accounts:query"
+	if s.Req[0].URL.Path != wantPath {
+		t.Errorf("QueryUsers() URL = %q; want = %q", s.Req[0].URL.Path, wantPath)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(s.Rbody, &body); err != nil {
+		t.Fatal(err)
+	}
+	wantBody := map[string]interface{}{
+		"returnUserInfo": true,
+		"limit":          "3",
+		"offset":         "1",
+		"sortBy":         "CREATED_AT",
+		"order":          "DESC",
+		"expression": []interface{}{
+			map[string]interface{}{"email": "user1@example.com"},
+		},
+	}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("QueryUsers() Body = %#v; want = %#v", body, wantBody)
+	}
+}
+
+func TestQueryUsersFilters(t *testing.T) {
+	s := echoServer([]byte(queryUsersResponseBody), t)
+	defer s.Close()
+
+	enabled, hasEmail := false, true
+	cases := []struct {
+		name  string
+		query *QueryUsersRequest
+		want  []string
+	}{
+		{"CreatedAfter", &QueryUsersRequest{CreatedAfter: time.UnixMilli(1000)}, []string{"user2", "user3"}},
+		{"CreatedBefore", &QueryUsersRequest{CreatedBefore: time.UnixMilli(3000)}, []string{"user1", "user2"}},
+		{"Disabled", &QueryUsersRequest{Disabled: &enabled}, []string{"user1", "user3"}},
+		{"HasEmail", &QueryUsersRequest{HasEmail: &hasEmail, CreatedAfter: time.UnixMilli(1500)}, []string{"user3"}},
+		{"HasPhoneNumber", &QueryUsersRequest{HasPhoneNumber: &hasEmail}, []string{"user2"}},
+	}
+	for _, tc := range cases {
+		result, err := s.Client.QueryUsers(context.Background(), tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var uids []string
+		for _, user := range result.Users {
+			uids = append(uids, user.UID)
+		}
+		if !reflect.DeepEqual(uids, tc.want) {
+			t.Errorf("QueryUsers(%s) = %v; want = %v", tc.name, uids, tc.want)
+		}
+		// A page smaller than the default limit is the last page.
+		if result.NextOffset != -1 {
+			t.Errorf("QueryUsers(%s).NextOffset = %d; want = -1", tc.name, result.NextOffset)
+		}
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(s.Rbody, &body); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"returnUserInfo": true, "limit": "500"}; !reflect.DeepEqual(body, want) {
+		t.Errorf("QueryUsers() Body = %#v; want = %#v", body, want)
+	}
+}
+
+func TestQueryUsersFiltersShortPage(t *testing.T) {
+	s := echoServer([]byte(queryUsersResponseBody), t)
+	defer s.Close()
+
+	enabled := false
+	result, err := s.Client.QueryUsers(context.Background(), &QueryUsersRequest{
+		Limit:    3,
+		Disabled: &enabled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The filter is applied to the page of 3 users, so that the page is short even though more
+	// users are available.
+	if len(result.Users) != 2 || result.Count != 5 || result.NextOffset != 3 {
+		t.Errorf("QueryUsers() = {Users: %d, Count: %d, NextOffset: %d}; want = {Users: 2, Count: 5, NextOffset: 3}",
+			len(result.Users), result.Count, result.NextOffset)
+	}
+}
+
+func TestTenantQueryUsers(t *testing.T) {
+	s := echoServer([]byte(queryUsersResponseBody), t)
+	defer s.Close()
+
+	client, err := s.Client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.QueryUsers(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	wantPath := "/projects/mock-project-id/tenants/tenantID/accounts:query"
+	if s.Req[0].URL.Path != wantPath {
+		t.Errorf("QueryUsers() URL = %q; want = %q", s.Req[0].URL.Path, wantPath)
+	}
+}
+
+func TestQueryUsersInvalidRequest(t *testing.T) {
+	s := echoServer([]byte(queryUsersResponseBody), t)
+	defer s.Close()
+
+	cases := []*QueryUsersRequest{
+		{Limit: -1},
+		{Limit: 501},
+		{Offset: -1},
+		{SortBy: "EMAIL"},
+		{Order: "ascending"},
+		{Expressions: []*UserQueryExpression{{}}},
+		{Expressions: []*UserQueryExpression{nil}},
+		{CreatedAfter: time.UnixMilli(2000), CreatedBefore: time.UnixMilli(1000)},
+	}
+	for _, tc := range cases {
+		if result, err := s.Client.QueryUsers(context.Background(), tc); result != nil || err == nil {
+			t.Errorf("QueryUsers(%#v) = (%v, %v); want = (nil, error)", tc, result, err)
+		}
+	}
+	want := "limit must be between 0 and 500, where 0 selects the default"
+	if _, err := s.Client.QueryUsers(context.Background(), &QueryUsersRequest{Limit: 501}); err == nil || err.Error() != want {
+		t.Errorf("QueryUsers(501) = %v; want = %q", err, want)
+	}
+	if len(s.Req) != 0 {
+		t.Errorf("QueryUsers() Requests = %d; want = 0", len(s.Req))
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const maxRequestAnnotationLen = 128
+
+// SetRequestAnnotation attaches an annotation, such as the name of the team or service using the
+// client, to the requests made by the client to manage provider, project, passkey and password
+// policy configurations.
+//
+// The annotation is sent in the X-Firebase-Request-Annotation header, and reported in the
+// Annotation field of the RequestMetrics passed to the firebase.Config.MetricsHook. If the request
+// already has a User-Agent header when the annotation is applied, the annotation is also appended
+// to it. This makes it possible to attribute configuration changes to the services that made them
+// when several services share the same credentials.
+//
+// The annotation must consist of up to 128 printable ASCII characters. Passing an empty string
+// removes the annotation. Tenant-aware clients obtained from the TenantManager after this call
+// inherit the annotation, and can override it.
+func (c *baseClient) SetRequestAnnotation(annotation string) error {
+	if len(annotation) > maxRequestAnnotationLen {
+		return fmt.Errorf("annotation must not be longer than %d characters", maxRequestAnnotationLen)
+	}
+	for _, r := range annotation {
+		if r < 0x20 || r > 0x7e {
+			return errors.New("annotation must only contain printable ASCII characters")
+		}
+	}
+	c.requestAnnotation = annotation
+	return nil
+}
+
+// withRequestAnnotation returns an HTTPOption that sends the given annotation in the request
+// annotation header, and appends it to the User-Agent of the request, if any.
+func withRequestAnnotation(annotation string) internal.HTTPOption {
+	return func(r *http.Request) {
+		r.Header.Set(internal.RequestAnnotationHeader, annotation)
+		if ua := r.Header.Get("User-Agent"); ua != "" {
+			r.Header.Set("User-Agent", fmt.Sprintf("%s %s", ua, annotation))
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+func TestRequestAnnotation(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	if err := s.Client.SetRequestAnnotation("billing-service"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := s.Req[0]
+	if got := req.Header.Get("X-Firebase-Request-Annotation"); got != "billing-service" {
+		t.Errorf("X-Firebase-Request-Annotation = %q; want = %q", got, "billing-service")
+	}
+	if got := req.Header.Get("User-Agent"); strings.Contains(got, "billing-service") {
+		t.Errorf("User-Agent = %q; want without annotation", got)
+	}
+}
+
+func TestRequestAnnotationMetrics(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	var metrics []*internal.RequestMetrics
+	s.Client.httpClient.MetricsFn = func(ctx context.Context, m *internal.RequestMetrics) {
+		metrics = append(metrics, m)
+	}
+	if err := s.Client.SetRequestAnnotation("billing-service"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metrics) != 1 || metrics[0].Annotation != "billing-service" {
+		t.Errorf("MetricsFn() = %v; want = 1 call with annotation %q", metrics, "billing-service")
+	}
+}
+
+func TestRequestAnnotationAppendsToUserAgent(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	s.Client.httpClient.Opts = append(s.Client.httpClient.Opts,
+		internal.WithHeader("User-Agent", "custom-agent/1.0"))
+	if err := s.Client.SetRequestAnnotation("billing-service"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+
+	wantUA := "custom-agent/1.0 billing-service"
+	if got := s.Req[0].Header.Get("User-Agent"); got != wantUA {
+		t.Errorf("User-Agent = %q; want = %q", got, wantUA)
+	}
+}
+
+func TestRequestAnnotationNotSet(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	if _, err := s.Client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := s.Req[0]
+	if got := req.Header.Get("X-Firebase-Request-Annotation"); got != "" {
+		t.Errorf("X-Firebase-Request-Annotation = %q; want = %q", got, "")
+	}
+}
+
+func TestRequestAnnotationCleared(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	if err := s.Client.SetRequestAnnotation("billing-service"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Client.SetRequestAnnotation(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+
+	req := s.Req[0]
+	if got := req.Header.Get("X-Firebase-Request-Annotation"); got != "" {
+		t.Errorf("X-Firebase-Request-Annotation = %q; want = %q", got, "")
+	}
+}
+
+func TestTenantRequestAnnotation(t *testing.T) {
+	s := echoServer([]byte(oidcConfigResponse), t)
+	defer s.Close()
+
+	if err := s.Client.SetRequestAnnotation("billing-service"); err != nil {
+		t.Fatal(err)
+	}
+	client, err := s.Client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatalf("AuthForTenant() = %v", err)
+	}
+	if _, err := client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetRequestAnnotation("tenant-service"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.OIDCProviderConfig(context.Background(), "oidc.provider"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"billing-service", "tenant-service"} {
+		if got := s.Req[i].Header.Get("X-Firebase-Request-Annotation"); got != want {
+			t.Errorf("X-Firebase-Request-Annotation[%d] = %q; want = %q", i, got, want)
+		}
+	}
+	if s.Client.requestAnnotation != "billing-service" {
+		t.Errorf("Client annotation = %q; want = %q", s.Client.requestAnnotation, "billing-service")
+	}
+}
+
+func TestInvalidRequestAnnotation(t *testing.T) {
+	client := &Client{baseClient: &baseClient{requestAnnotation: "original"}}
+	cases := []struct {
+		annotation string
+		want       string
+	}{
+		{"billing\r\nX-Injected: true", "annotation must only contain printable ASCII characters"},
+		{"billing\tservice", "annotation must only contain printable ASCII characters"},
+		{"facturación", "annotation must only contain printable ASCII characters"},
+		{strings.Repeat("a", 129), "annotation must not be longer than 128 characters"},
+	}
+	for _, tc := range cases {
+		err := client.SetRequestAnnotation(tc.annotation)
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("SetRequestAnnotation(%q) = %v; want = %q", tc.annotation, err, tc.want)
+		}
+	}
+	if client.requestAnnotation != "original" {
+		t.Errorf("requestAnnotation = %q; want = %q", client.requestAnnotation, "original")
+	}
+}
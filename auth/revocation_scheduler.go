@@ -0,0 +1,245 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRevocationBatchSize     = 100
+	defaultRevocationBatchInterval = time.Second
+	maxRevocationBatchSize         = 1000
+)
+
+// RevocationSchedulerConfig configures how a RevocationScheduler revokes refresh tokens.
+type RevocationSchedulerConfig struct {
+	// BatchSize is the maximum number of users whose tokens are revoked in each batch. Must not
+	// exceed 1000. If zero, 100 users are revoked per batch.
+	BatchSize int
+
+	// BatchInterval is the minimum delay between the start of two batches. If zero, batches are
+	// started at most once per second.
+	BatchInterval time.Duration
+
+	// OnBatch is called after each batch has been processed. Tools that need to track their
+	// progress durably can persist the result of each batch, and resume from there if the
+	// process is interrupted.
+	OnBatch func(result *RevocationBatchResult)
+
+	// OnComplete is called once, after the scheduler has been closed and all the pending users
+	// have been processed.
+	OnComplete func(result *RevocationResult)
+}
+
+// RevocationError describes a failure to revoke the refresh tokens of a user.
+type RevocationError struct {
+	UID string
+	Err error
+}
+
+// RevocationBatchResult is the result of revoking the refresh tokens of a batch of users.
+type RevocationBatchResult struct {
+	// UIDs contains the users processed in the batch, in the order in which they were added.
+	UIDs []string
+
+	// SuccessCount is the number of users whose tokens were revoked.
+	SuccessCount int
+
+	// FailureCount is the number of users whose tokens could not be revoked.
+	FailureCount int
+
+	// Errors describes the failures of the batch. Its length is equal to FailureCount.
+	Errors []*RevocationError
+}
+
+// RevocationResult is the result of all the revocations performed by a RevocationScheduler.
+type RevocationResult struct {
+	SuccessCount int
+	FailureCount int
+	Errors       []*RevocationError
+}
+
+// RevocationScheduler revokes the refresh tokens of large numbers of users in the background.
+//
+// Users added to the scheduler are processed in batches, which are started at most once per
+// BatchInterval in order to stay within the quota of the Firebase Auth backend. Within a batch,
+// the tokens of each user are revoked in the same way as RevokeRefreshTokens.
+//
+// Close must be called once all the users have been added, to wait for the pending revocations
+// and release the resources of the scheduler. If the context of the scheduler is cancelled, the
+// remaining users are reported as failures with the context error.
+type RevocationScheduler struct {
+	client *baseClient
+	ctx    context.Context
+	config RevocationSchedulerConfig
+
+	mu      sync.Mutex
+	pending []string
+	closed  bool
+	notify  chan struct{}
+	done    chan struct{}
+	result  RevocationResult
+}
+
+// NewRevocationScheduler creates a RevocationScheduler, and starts processing users in the
+// background. The config may be nil, in which case the default batch size and interval are used.
+func (c *baseClient) NewRevocationScheduler(ctx context.Context, config *RevocationSchedulerConfig) (*RevocationScheduler, error) {
+	s := &RevocationScheduler{
+		client: c,
+		ctx:    ctx,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	if config != nil {
+		s.config = *config
+	}
+
+	if s.config.BatchSize < 0 || s.config.BatchSize > maxRevocationBatchSize {
+		return nil, fmt.Errorf("batch size must be between 0 and %d", maxRevocationBatchSize)
+	} else if s.config.BatchSize == 0 {
+		s.config.BatchSize = defaultRevocationBatchSize
+	}
+	if s.config.BatchInterval < 0 {
+		return nil, errors.New("batch interval must not be negative")
+	} else if s.config.BatchInterval == 0 {
+		s.config.BatchInterval = defaultRevocationBatchInterval
+	}
+
+	go s.run()
+	return s, nil
+}
+
+// Add schedules the refresh tokens of the given users to be revoked.
+//
+// An error is returned, and none of the users are added, if any of the UIDs is invalid or the
+// scheduler has already been closed.
+func (s *RevocationScheduler) Add(uids ...string) error {
+	for _, uid := range uids {
+		if err := validateUID(uid); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("revocation scheduler is closed")
+	}
+	s.pending = append(s.pending, uids...)
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops accepting new users, and waits until all the pending users have been processed.
+//
+// Close returns the result of all the revocations performed by the scheduler. It is safe to call
+// Close more than once.
+func (s *RevocationScheduler) Close() *RevocationResult {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.notify)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+	return &s.result
+}
+
+func (s *RevocationScheduler) run() {
+	defer close(s.done)
+	for {
+		batch := s.nextBatch()
+		if batch == nil {
+			break
+		}
+
+		start := time.Now()
+		result := s.revoke(batch)
+		s.result.SuccessCount += result.SuccessCount
+		s.result.FailureCount += result.FailureCount
+		s.result.Errors = append(s.result.Errors, result.Errors...)
+		if s.config.OnBatch != nil {
+			s.config.OnBatch(result)
+		}
+
+		if s.hasMoreWork() {
+			select {
+			case <-s.ctx.Done():
+			case <-time.After(s.config.BatchInterval - time.Since(start)):
+			}
+		}
+	}
+
+	if s.config.OnComplete != nil {
+		s.config.OnComplete(&s.result)
+	}
+}
+
+// nextBatch blocks until there are pending users, and returns up to BatchSize of them. Returns
+// nil when the scheduler is closed and there are no pending users.
+func (s *RevocationScheduler) nextBatch() []string {
+	for {
+		s.mu.Lock()
+		if n := len(s.pending); n > 0 {
+			if n > s.config.BatchSize {
+				n = s.config.BatchSize
+			}
+			batch := s.pending[:n:n]
+			s.pending = s.pending[n:]
+			s.mu.Unlock()
+			return batch
+		}
+		closed := s.closed
+		s.mu.Unlock()
+
+		if closed {
+			return nil
+		}
+		<-s.notify
+	}
+}
+
+func (s *RevocationScheduler) hasMoreWork() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending) > 0 || !s.closed
+}
+
+func (s *RevocationScheduler) revoke(uids []string) *RevocationBatchResult {
+	result := &RevocationBatchResult{UIDs: uids}
+	for _, uid := range uids {
+		err := s.ctx.Err()
+		if err == nil {
+			err = s.client.RevokeRefreshTokens(s.ctx, uid)
+		}
+
+		if err != nil {
+			result.FailureCount++
+			result.Errors = append(result.Errors, &RevocationError{UID: uid, Err: err})
+		} else {
+			result.SuccessCount++
+		}
+	}
+	return result
+}
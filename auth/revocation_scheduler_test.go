@@ -0,0 +1,216 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// revocationServer responds to accounts:update requests, failing the requests for the given
+// UIDs with USER_NOT_FOUND. It records the UIDs of all the requests it receives.
+type revocationServer struct {
+	srv     *httptest.Server
+	mu      sync.Mutex
+	uids    []string
+	failing map[string]bool
+}
+
+func newRevocationScheduler(t *testing.T, config *RevocationSchedulerConfig, failing ...string) (*RevocationScheduler, *revocationServer) {
+	rs := &revocationServer{failing: make(map[string]bool)}
+	for _, uid := range failing {
+		rs.failing[uid] = true
+	}
+	rs.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			LocalID string `json:"localId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		rs.mu.Lock()
+		rs.uids = append(rs.uids, req.LocalID)
+		rs.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if rs.failing[req.LocalID] {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"message": "USER_NOT_FOUND"}}`))
+			return
+		}
+		w.Write([]byte(`{"localId": "` + req.LocalID + `"}`))
+	}))
+	t.Cleanup(rs.srv.Close)
+
+	client, err := NewClient(context.Background(), &internal.AuthConfig{
+		ProjectID: testProjectID,
+		Opts:      optsWithTokenSource,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.userManagementEndpoint = rs.srv.URL
+	s, err := client.NewRevocationScheduler(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, rs
+}
+
+func TestRevocationScheduler(t *testing.T) {
+	var batches []*RevocationBatchResult
+	var complete *RevocationResult
+	s, rs := newRevocationScheduler(t, &RevocationSchedulerConfig{
+		BatchSize:     2,
+		BatchInterval: 20 * time.Millisecond,
+		OnBatch: func(result *RevocationBatchResult) {
+			batches = append(batches, result)
+		},
+		OnComplete: func(result *RevocationResult) {
+			complete = result
+		},
+	}, "uid3")
+
+	if err := s.Add("uid1", "uid2", "uid3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add("uid4", "uid5"); err != nil {
+		t.Fatal(err)
+	}
+	result := s.Close()
+
+	if result != complete {
+		t.Errorf("OnComplete() = %v; want = %v", complete, result)
+	}
+	if result.SuccessCount != 4 || result.FailureCount != 1 {
+		t.Errorf("Close() = {SuccessCount: %d, FailureCount: %d}; want = {4, 1}",
+			result.SuccessCount, result.FailureCount)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].UID != "uid3" || !IsUserNotFound(result.Errors[0].Err) {
+		t.Errorf("Close().Errors = %v; want = [uid3: UserNotFound]", result.Errors)
+	}
+
+	wantUIDs := []string{"uid1", "uid2", "uid3", "uid4", "uid5"}
+	if !reflect.DeepEqual(rs.uids, wantUIDs) {
+		t.Errorf("Revoked = %v; want = %v", rs.uids, wantUIDs)
+	}
+	var batchUIDs []string
+	for _, b := range batches {
+		if len(b.UIDs) > 2 {
+			t.Errorf("Batch = %v; want at most 2 UIDs", b.UIDs)
+		}
+		if b.SuccessCount+b.FailureCount != len(b.UIDs) || len(b.Errors) != b.FailureCount {
+			t.Errorf("Batch = %#v; counts do not add up", b)
+		}
+		batchUIDs = append(batchUIDs, b.UIDs...)
+	}
+	if !reflect.DeepEqual(batchUIDs, wantUIDs) {
+		t.Errorf("OnBatch() UIDs = %v; want = %v", batchUIDs, wantUIDs)
+	}
+}
+
+func TestRevocationSchedulerBatchInterval(t *testing.T) {
+	interval := 50 * time.Millisecond
+	s, rs := newRevocationScheduler(t, &RevocationSchedulerConfig{
+		BatchSize:     1,
+		BatchInterval: interval,
+	})
+
+	start := time.Now()
+	if err := s.Add("uid1", "uid2", "uid3"); err != nil {
+		t.Fatal(err)
+	}
+	if result := s.Close(); result.SuccessCount != 3 {
+		t.Fatalf("Close().SuccessCount = %d; want = 3", result.SuccessCount)
+	}
+
+	// The third batch cannot start before two intervals have elapsed since the first one.
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("Close() returned after %v; want >= %v", elapsed, 2*interval)
+	}
+	if len(rs.uids) != 3 {
+		t.Errorf("Revoked = %v; want = 3 users", rs.uids)
+	}
+}
+
+func TestRevocationSchedulerContextCancelled(t *testing.T) {
+	client := &baseClient{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s, err := client.NewRevocationScheduler(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Add("uid1", "uid2"); err != nil {
+		t.Fatal(err)
+	}
+	result := s.Close()
+	if result.FailureCount != 2 || len(result.Errors) != 2 {
+		t.Fatalf("Close() = %#v; want = 2 failures", result)
+	}
+	for _, e := range result.Errors {
+		if e.Err != context.Canceled {
+			t.Errorf("Error(%s) = %v; want = %v", e.UID, e.Err, context.Canceled)
+		}
+	}
+}
+
+func TestRevocationSchedulerAddInvalidUID(t *testing.T) {
+	s, rs := newRevocationScheduler(t, nil)
+	if err := s.Add("uid1", ""); err == nil {
+		t.Errorf("Add(invalid) = nil; want = error")
+	}
+	if result := s.Close(); result.SuccessCount != 0 || result.FailureCount != 0 {
+		t.Errorf("Close() = %#v; want = empty result", result)
+	}
+	if len(rs.uids) != 0 {
+		t.Errorf("Revoked = %v; want = none", rs.uids)
+	}
+}
+
+func TestRevocationSchedulerClosed(t *testing.T) {
+	s, _ := newRevocationScheduler(t, nil)
+	first := s.Close()
+	if second := s.Close(); second != first {
+		t.Errorf("Close() = %v; want = %v", second, first)
+	}
+	if err := s.Add("uid1"); err == nil {
+		t.Errorf("Add() after Close() = nil; want = error")
+	}
+}
+
+func TestRevocationSchedulerInvalidConfig(t *testing.T) {
+	client := &baseClient{}
+	configs := []*RevocationSchedulerConfig{
+		{BatchSize: -1},
+		{BatchSize: 1001},
+		{BatchInterval: -time.Second},
+	}
+	for _, config := range configs {
+		s, err := client.NewRevocationScheduler(context.Background(), config)
+		if s != nil || err == nil {
+			t.Errorf("NewRevocationScheduler(%#v) = (%v, %v); want = (nil, error)", config, s, err)
+		}
+	}
+}
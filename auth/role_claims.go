@@ -0,0 +1,160 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// rolesKey is the key under which role names are stored in the namespaced claim.
+const rolesKey = "roles"
+
+// Role is a named set of permissions.
+type Role struct {
+	Name        string
+	Permissions []string
+}
+
+// RoleModel defines the roles of an application, and stores the roles of users in a single
+// namespaced custom claim.
+//
+// Only role names are stored in the claim, as {"<namespace>": {"roles": ["admin", "editor"]}}.
+// Permissions are resolved from the model when a token is checked, which keeps the claims small,
+// and lets permissions change without updating the claims of every user.
+type RoleModel struct {
+	namespace string
+	roles     map[string]*Role
+}
+
+// NewRoleModel creates a RoleModel that stores roles under the given claim name.
+//
+// The namespace must not be a reserved claim name, and role names must be non-empty and unique.
+func NewRoleModel(namespace string, roles ...*Role) (*RoleModel, error) {
+	if namespace == "" {
+		return nil, errors.New("namespace must not be empty")
+	}
+	for _, claim := range reservedClaims {
+		if namespace == claim {
+			return nil, fmt.Errorf("namespace %q is a reserved claim", namespace)
+		}
+	}
+
+	m := &RoleModel{
+		namespace: namespace,
+		roles:     make(map[string]*Role, len(roles)),
+	}
+	for _, r := range roles {
+		if r == nil || r.Name == "" {
+			return nil, errors.New("role name must not be empty")
+		}
+		if _, ok := m.roles[r.Name]; ok {
+			return nil, fmt.Errorf("duplicate role: %q", r.Name)
+		}
+		m.roles[r.Name] = r
+	}
+	return m, nil
+}
+
+// Claims returns the custom claims that assign the given roles to a user. The result can be
+// passed to MergeCustomUserClaims, to preserve the other custom claims of the user, or to
+// SetCustomUserClaims. Passing no roles returns claims that assign an empty set of roles.
+func (m *RoleModel) Claims(roles ...string) (map[string]interface{}, error) {
+	names := make([]string, 0, len(roles))
+	seen := make(map[string]bool, len(roles))
+	for _, name := range roles {
+		if _, ok := m.roles[name]; !ok {
+			return nil, fmt.Errorf("unknown role: %q", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return map[string]interface{}{
+		m.namespace: map[string]interface{}{
+			rolesKey: names,
+		},
+	}, nil
+}
+
+// Roles returns the roles assigned to the user of the given token. Role names in the token that
+// are not defined in the model are ignored.
+func (m *RoleModel) Roles(token *Token) []string {
+	if token == nil {
+		return nil
+	}
+	claim, ok := token.Claims[m.namespace].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	values, ok := claim[rolesKey].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var roles []string
+	for _, v := range values {
+		if name, ok := v.(string); ok {
+			if _, defined := m.roles[name]; defined {
+				roles = append(roles, name)
+			}
+		}
+	}
+	return roles
+}
+
+// HasRole checks if the user of the given token has been assigned the given role.
+func (m *RoleModel) HasRole(token *Token, role string) bool {
+	for _, r := range m.Roles(token) {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission checks if any of the roles assigned to the user of the given token grants the
+// given permission.
+func (m *RoleModel) HasPermission(token *Token, permission string) bool {
+	for _, r := range m.Roles(token) {
+		for _, p := range m.roles[r].Permissions {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Permissions returns the permissions granted by the roles assigned to the user of the given
+// token, sorted and without duplicates.
+func (m *RoleModel) Permissions(token *Token) []string {
+	seen := make(map[string]bool)
+	var permissions []string
+	for _, r := range m.Roles(token) {
+		for _, p := range m.roles[r].Permissions {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func newTestRoleModel(t *testing.T) *RoleModel {
+	m, err := NewRoleModel("acme",
+		&Role{Name: "admin", Permissions: []string{"users.read", "users.write"}},
+		&Role{Name: "viewer", Permissions: []string{"users.read", "reports.read"}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// tokenWithClaims returns a Token carrying the given claims, decoded from JSON as in a verified
+// ID token.
+func tokenWithClaims(t *testing.T, claims map[string]interface{}) *Token {
+	b, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &Token{}
+	if err := json.Unmarshal(b, &token.Claims); err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestNewRoleModelErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		namespace string
+		roles     []*Role
+	}{
+		{"EmptyNamespace", "", nil},
+		{"ReservedNamespace", "firebase", nil},
+		{"NilRole", "acme", []*Role{nil}},
+		{"EmptyRoleName", "acme", []*Role{{Name: ""}}},
+		{"DuplicateRole", "acme", []*Role{{Name: "admin"}, {Name: "admin"}}},
+	}
+	for _, tc := range cases {
+		m, err := NewRoleModel(tc.namespace, tc.roles...)
+		if m != nil || err == nil {
+			t.Errorf("NewRoleModel(%s) = (%v, %v); want = (nil, error)", tc.name, m, err)
+		}
+	}
+}
+
+func TestRoleModelClaims(t *testing.T) {
+	m := newTestRoleModel(t)
+
+	claims, err := m.Claims("viewer", "admin", "viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"acme": map[string]interface{}{
+			"roles": []string{"admin", "viewer"},
+		},
+	}
+	if !reflect.DeepEqual(claims, want) {
+		t.Errorf("Claims() = %v; want = %v", claims, want)
+	}
+
+	if claims, err := m.Claims("owner"); claims != nil || err == nil {
+		t.Errorf("Claims(owner) = (%v, %v); want = (nil, error)", claims, err)
+	}
+}
+
+func TestRoleModelToken(t *testing.T) {
+	m := newTestRoleModel(t)
+	claims, err := m.Claims("viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := tokenWithClaims(t, claims)
+
+	if roles := m.Roles(token); !reflect.DeepEqual(roles, []string{"viewer"}) {
+		t.Errorf("Roles() = %v; want = [viewer]", roles)
+	}
+	if !m.HasRole(token, "viewer") || m.HasRole(token, "admin") {
+		t.Errorf("HasRole() = (%v, %v); want = (true, false)", m.HasRole(token, "viewer"), m.HasRole(token, "admin"))
+	}
+	if !m.HasPermission(token, "reports.read") || m.HasPermission(token, "users.write") {
+		t.Errorf("HasPermission() = (%v, %v); want = (true, false)",
+			m.HasPermission(token, "reports.read"), m.HasPermission(token, "users.write"))
+	}
+
+	token = tokenWithClaims(t, map[string]interface{}{
+		"acme": map[string]interface{}{
+			"roles": []string{"viewer", "admin", "owner"},
+		},
+	})
+	if roles := m.Roles(token); !reflect.DeepEqual(roles, []string{"viewer", "admin"}) {
+		t.Errorf("Roles() = %v; want = [viewer admin]", roles)
+	}
+	want := []string{"reports.read", "users.read", "users.write"}
+	if perms := m.Permissions(token); !reflect.DeepEqual(perms, want) {
+		t.Errorf("Permissions() = %v; want = %v", perms, want)
+	}
+}
+
+func TestRoleModelTokenWithoutRoles(t *testing.T) {
+	m := newTestRoleModel(t)
+	tokens := []*Token{
+		nil,
+		{},
+		tokenWithClaims(t, map[string]interface{}{"acme": "admin"}),
+		tokenWithClaims(t, map[string]interface{}{"acme": map[string]interface{}{"roles": "admin"}}),
+	}
+	for _, token := range tokens {
+		if roles := m.Roles(token); len(roles) != 0 {
+			t.Errorf("Roles() = %v; want = []", roles)
+		}
+		if m.HasPermission(token, "users.read") {
+			t.Errorf("HasPermission() = true; want = false")
+		}
+	}
+}
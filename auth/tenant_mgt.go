@@ -43,12 +43,14 @@ import (
 // All other settings of a tenant will also be inherited. These will need to be managed from the
 // Cloud Console UI.
 type Tenant struct {
-	ID                    string             `json:"name"`
-	DisplayName           string             `json:"displayName"`
-	AllowPasswordSignUp   bool               `json:"allowPasswordSignup"`
-	EnableEmailLinkSignIn bool               `json:"enableEmailLinkSignin"`
-	EnableAnonymousUsers  bool               `json:"enableAnonymousUser"`
-	MultiFactorConfig     *MultiFactorConfig `json:"mfaConfig"`
+	ID                    string                `json:"name"`
+	DisplayName           string                `json:"displayName"`
+	AllowPasswordSignUp   bool                  `json:"allowPasswordSignup"`
+	EnableEmailLinkSignIn bool                  `json:"enableEmailLinkSignin"`
+	EnableAnonymousUsers  bool                  `json:"enableAnonymousUser"`
+	MultiFactorConfig     *MultiFactorConfig    `json:"mfaConfig"`
+	PasswordPolicyConfig  *PasswordPolicyConfig `json:"passwordPolicyConfig"`
+	SMSRegionConfig       *SMSRegionConfig      `json:"smsRegionConfig"`
 }
 
 // TenantClient is used for managing users, configuring SAML/OIDC providers, and generating email
@@ -194,11 +196,14 @@ func (tm *TenantManager) DeleteTenant(ctx context.Context, tenantID string) erro
 // Tenants returns an iterator over tenants in the project.
 //
 // If nextPageToken is empty, the iterator will start at the beginning. Otherwise,
-// iterator starts after the token.
+// iterator starts after the token. Pages that fail with a transient error are retried with
+// exponential backoff before Next returns the error, and TenantIterator.ResumeToken returns a
+// token from which an interrupted listing can be resumed.
 func (tm *TenantManager) Tenants(ctx context.Context, nextPageToken string) *TenantIterator {
 	it := &TenantIterator{
-		ctx: ctx,
-		tm:  tm,
+		ctx:              ctx,
+		tm:               tm,
+		currentPageToken: nextPageToken,
 	}
 	it.pageInfo, it.nextFunc = iterator.NewPageInfo(
 		it.fetch,
@@ -210,12 +215,17 @@ func (tm *TenantManager) Tenants(ctx context.Context, nextPageToken string) *Ten
 }
 
 func (tm *TenantManager) makeRequest(ctx context.Context, req *internal.Request, v interface{}) (*internal.Response, error) {
+	return tm.makeRequestWithClient(ctx, tm.httpClient, req, v)
+}
+
+func (tm *TenantManager) makeRequestWithClient(
+	ctx context.Context, hc *internal.HTTPClient, req *internal.Request, v interface{}) (*internal.Response, error) {
 	if tm.projectID == "" {
 		return nil, errors.New("project id not available")
 	}
 
 	req.URL = fmt.Sprintf("%s/projects/%s%s", tm.endpoint, tm.projectID, req.URL)
-	return tm.httpClient.DoAndUnmarshal(ctx, req, v)
+	return hc.DoAndUnmarshal(ctx, req, v)
 }
 
 const (
@@ -258,6 +268,17 @@ func (t *TenantToCreate) MultiFactorConfig(multiFactorConfig MultiFactorConfig)
 	return t.set(multiFactorConfigTenantKey, multiFactorConfig)
 }
 
+// PasswordPolicyConfig configures the tenant's password policy.
+func (t *TenantToCreate) PasswordPolicyConfig(passwordPolicyConfig PasswordPolicyConfig) *TenantToCreate {
+	return t.set(passwordPolicyConfigKey, passwordPolicyConfig)
+}
+
+// SMSRegionConfig configures the regions where users of the tenant are allowed to send
+// verification SMS.
+func (t *TenantToCreate) SMSRegionConfig(smsRegionConfig SMSRegionConfig) *TenantToCreate {
+	return t.set(smsRegionConfigKey, smsRegionConfig)
+}
+
 func (t *TenantToCreate) set(key string, value interface{}) *TenantToCreate {
 	t.ensureParams().Set(key, value)
 	return t
@@ -285,7 +306,10 @@ func (t *TenantToCreate) validate() error {
 			return err
 		}
 	}
-	return nil
+	if err := validateSMSRegionConfig(req); err != nil {
+		return err
+	}
+	return validatePasswordPolicyConfig(req)
 }
 
 // TenantToUpdate represents the options used to update an existing tenant.
@@ -320,6 +344,17 @@ func (t *TenantToUpdate) MultiFactorConfig(multiFactorConfig MultiFactorConfig)
 	return t.set(multiFactorConfigTenantKey, multiFactorConfig)
 }
 
+// PasswordPolicyConfig configures the tenant's password policy.
+func (t *TenantToUpdate) PasswordPolicyConfig(passwordPolicyConfig PasswordPolicyConfig) *TenantToUpdate {
+	return t.set(passwordPolicyConfigKey, passwordPolicyConfig)
+}
+
+// SMSRegionConfig configures the regions where users of the tenant are allowed to send
+// verification SMS.
+func (t *TenantToUpdate) SMSRegionConfig(smsRegionConfig SMSRegionConfig) *TenantToUpdate {
+	return t.set(smsRegionConfigKey, smsRegionConfig)
+}
+
 func (t *TenantToUpdate) set(key string, value interface{}) *TenantToUpdate {
 	if t.params == nil {
 		t.params = make(nestedMap)
@@ -344,16 +379,20 @@ func (t *TenantToUpdate) validate() error {
 			return err
 		}
 	}
-	return nil
+	if err := validateSMSRegionConfig(req); err != nil {
+		return err
+	}
+	return validatePasswordPolicyConfig(req)
 }
 
 // TenantIterator is an iterator over tenants.
 type TenantIterator struct {
-	tm       *TenantManager
-	ctx      context.Context
-	nextFunc func() error
-	pageInfo *iterator.PageInfo
-	tenants  []*Tenant
+	tm               *TenantManager
+	ctx              context.Context
+	nextFunc         func() error
+	pageInfo         *iterator.PageInfo
+	tenants          []*Tenant
+	currentPageToken string
 }
 
 // PageInfo supports pagination.
@@ -361,6 +400,19 @@ func (it *TenantIterator) PageInfo() *iterator.PageInfo {
 	return it.pageInfo
 }
 
+// ResumeToken returns a page token from which listing can be resumed without skipping any tenants.
+//
+// If the iterator is in the middle of a page, the token refers to the start of that page, and
+// the tenants of the page already returned by Next are returned again when resuming. Returns an
+// empty string when listing should start over from the beginning, or when all tenants have been
+// listed (which can be distinguished by Next returning [iterator.Done]).
+func (it *TenantIterator) ResumeToken() string {
+	if len(it.tenants) > 0 {
+		return it.currentPageToken
+	}
+	return it.pageInfo.Token
+}
+
 // Next returns the next Tenant. The error value of [iterator.Done] is
 // returned if there are no more results. Once Next returns [iterator.Done], all
 // subsequent calls will return [iterator.Done].
@@ -394,10 +446,12 @@ func (it *TenantIterator) fetch(pageSize int, pageToken string) (string, error)
 		Tenants       []Tenant `json:"tenants"`
 		NextPageToken string   `json:"nextPageToken"`
 	}
-	if _, err := it.tm.makeRequest(it.ctx, req, &result); err != nil {
+	hc := withListPageRetries(it.tm.httpClient)
+	if _, err := it.tm.makeRequestWithClient(it.ctx, hc, req, &result); err != nil {
 		return "", err
 	}
 
+	it.currentPageToken = pageToken
 	for i := range result.Tenants {
 		result.Tenants[i].ID = extractResourceID(result.Tenants[i].ID)
 		it.tenants = append(it.tenants, &result.Tenants[i])
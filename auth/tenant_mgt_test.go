@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sort"
 	"strconv"
@@ -1095,6 +1096,11 @@ const tenantResponse = `{
 				}
 			}
 		]
+	},
+	"smsRegionConfig": {
+		"allowlistOnly": {
+			"allowedRegions": ["US", "CA"]
+		}
 	}
 }`
 
@@ -1138,6 +1144,11 @@ var testTenant = &Tenant{
 			},
 		},
 	},
+	SMSRegionConfig: &SMSRegionConfig{
+		AllowlistOnly: &AllowlistOnly{
+			AllowedRegions: []string{"US", "CA"},
+		},
+	},
 }
 
 var testTenant2 = &Tenant{
@@ -1223,7 +1234,8 @@ func TestCreateTenant(t *testing.T) {
 		AllowPasswordSignUp(testTenant.AllowPasswordSignUp).
 		EnableEmailLinkSignIn(testTenant.EnableEmailLinkSignIn).
 		EnableAnonymousUsers(testTenant.EnableAnonymousUsers).
-		MultiFactorConfig(*testTenant.MultiFactorConfig)
+		MultiFactorConfig(*testTenant.MultiFactorConfig).
+		SMSRegionConfig(*testTenant.SMSRegionConfig)
 	tenant, err := client.TenantManager.CreateTenant(context.Background(), options)
 	if err != nil {
 		t.Fatal(err)
@@ -1248,6 +1260,11 @@ func TestCreateTenant(t *testing.T) {
 				},
 			},
 		},
+		"smsRegionConfig": map[string]interface{}{
+			"allowlistOnly": map[string]interface{}{
+				"allowedRegions": []interface{}{"US", "CA"},
+			},
+		},
 	}
 	if err := checkCreateTenantRequest(s, wantBody); err != nil {
 		t.Fatal(err)
@@ -1335,7 +1352,8 @@ func TestUpdateTenant(t *testing.T) {
 		AllowPasswordSignUp(testTenant.AllowPasswordSignUp).
 		EnableEmailLinkSignIn(testTenant.EnableEmailLinkSignIn).
 		EnableAnonymousUsers(testTenant.EnableAnonymousUsers).
-		MultiFactorConfig(*testTenant.MultiFactorConfig)
+		MultiFactorConfig(*testTenant.MultiFactorConfig).
+		SMSRegionConfig(*testTenant.SMSRegionConfig)
 	tenant, err := client.TenantManager.UpdateTenant(context.Background(), "tenantID", options)
 	if err != nil {
 		t.Fatal(err)
@@ -1360,8 +1378,13 @@ func TestUpdateTenant(t *testing.T) {
 				},
 			},
 		},
+		"smsRegionConfig": map[string]interface{}{
+			"allowlistOnly": map[string]interface{}{
+				"allowedRegions": []interface{}{"US", "CA"},
+			},
+		},
 	}
-	wantMask := []string{"allowPasswordSignup", "displayName", "enableAnonymousUser", "enableEmailLinkSignin", "mfaConfig"}
+	wantMask := []string{"allowPasswordSignup", "displayName", "enableAnonymousUser", "enableEmailLinkSignin", "mfaConfig", "smsRegionConfig"}
 	if err := checkUpdateTenantRequest(s, wantBody, wantMask); err != nil {
 		t.Fatal(err)
 	}
@@ -1445,6 +1468,21 @@ func TestUpdateTenantEmptyID(t *testing.T) {
 	}
 }
 
+func TestTenantInvalidSMSRegionConfig(t *testing.T) {
+	tm := &TenantManager{}
+	want := `exactly one of "AllowByDefault" or "AllowlistOnly" must be specified`
+	config := SMSRegionConfig{
+		AllowByDefault: &AllowByDefault{DisallowedRegions: []string{"AQ"}},
+		AllowlistOnly:  &AllowlistOnly{AllowedRegions: []string{"US"}},
+	}
+	if _, err := tm.CreateTenant(context.Background(), (&TenantToCreate{}).SMSRegionConfig(config)); err == nil || err.Error() != want {
+		t.Errorf("CreateTenant() = %v, want = %q", err, want)
+	}
+	if _, err := tm.UpdateTenant(context.Background(), "tenantID", (&TenantToUpdate{}).SMSRegionConfig(config)); err == nil || err.Error() != want {
+		t.Errorf("UpdateTenant() = %v, want = %q", err, want)
+	}
+}
+
 func TestUpdateTenantNilOptions(t *testing.T) {
 	tm := &TenantManager{}
 	want := "tenant must not be nil"
@@ -1582,6 +1620,50 @@ func TestTenantsError(t *testing.T) {
 	}
 }
 
+func TestTenantsRetriesFailedPage(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"tenants": [%s, %s], "nextPageToken": "nextToken"}`, tenantResponse, tenantResponse2)
+	}))
+	defer ts.Close()
+	s := echoServer(nil, t)
+	defer s.Close()
+	s.Client.TenantManager.endpoint = ts.URL
+
+	it := s.Client.TenantManager.Tenants(context.Background(), "pageToken")
+	if token := it.ResumeToken(); token != "pageToken" {
+		t.Errorf("ResumeToken() = %q; want = %q", token, "pageToken")
+	}
+
+	tenant, err := it.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tenant, testTenant) {
+		t.Errorf("Next() = %#v; want = %#v", tenant, testTenant)
+	}
+	if requests != 2 {
+		t.Errorf("Tenants() Requests = %d; want = 2", requests)
+	}
+	// The current page has not been fully consumed yet.
+	if token := it.ResumeToken(); token != "pageToken" {
+		t.Errorf("ResumeToken() = %q; want = %q", token, "pageToken")
+	}
+
+	if _, err := it.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if token := it.ResumeToken(); token != "nextToken" {
+		t.Errorf("ResumeToken() = %q; want = %q", token, "nextToken")
+	}
+}
+
 func checkCreateTenantRequest(s *mockAuthServer, wantBody interface{}) error {
 	req := s.Req[0]
 	if req.Method != http.MethodPost {
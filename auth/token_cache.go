@@ -0,0 +1,221 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TokenCache is a bounded, in-memory cache of verified ID tokens.
+//
+// When a TokenCache is configured on a client, VerifyIDToken returns the cached result for tokens
+// that have already been verified and have not expired, without decoding the token or verifying
+// its signature again. This significantly reduces the CPU cost of verifying the same tokens
+// repeatedly, as is typical in middleware that verifies every request of a user session.
+//
+// Tokens are cached under the SHA-256 hash of the token and the project that verified it, so that
+// a token is only served from the cache to verifiers that expect the same issuer and audience.
+// When the cache is full, the least recently used entry is evicted. Revocation and disabled-user
+// checks are never cached, and are always performed by VerifyIDTokenAndCheckRevoked.
+//
+// A TokenCache is safe for concurrent use.
+type TokenCache struct {
+	maxEntries int
+
+	mu        sync.Mutex
+	ll        *list.List
+	entries   map[[sha256.Size]byte]*list.Element
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// TokenCacheStats contains the usage metrics of a TokenCache.
+type TokenCacheStats struct {
+	// Hits is the number of verifications served from the cache.
+	Hits int64
+	// Misses is the number of verifications not found in the cache.
+	Misses int64
+	// Evictions is the number of entries evicted to make room for new entries.
+	Evictions int64
+	// Size is the number of entries currently in the cache, including expired entries that have
+	// not been evicted yet.
+	Size int
+}
+
+type tokenCacheEntry struct {
+	key       [sha256.Size]byte
+	tokenHash [sha256.Size]byte
+	token     *Token
+	expires   time.Time
+}
+
+// NewTokenCache creates a TokenCache that holds up to maxEntries verified tokens.
+func NewTokenCache(maxEntries int) (*TokenCache, error) {
+	if maxEntries <= 0 {
+		return nil, errors.New("maxEntries must be greater than 0")
+	}
+	return &TokenCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		entries:    make(map[[sha256.Size]byte]*list.Element),
+	}, nil
+}
+
+// Invalidate removes the given token from the cache, so that it is fully verified again the next
+// time it is presented.
+func (tc *TokenCache) Invalidate(token string) {
+	tokenHash := sha256.Sum256([]byte(token))
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	// The token may have been cached by verifiers of different projects.
+	for elem := tc.ll.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*tokenCacheEntry).tokenHash == tokenHash {
+			tc.removeElement(elem)
+		}
+		elem = next
+	}
+}
+
+// Purge removes all the entries from the cache.
+func (tc *TokenCache) Purge() {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.ll.Init()
+	tc.entries = make(map[[sha256.Size]byte]*list.Element)
+}
+
+// Stats returns the usage metrics of the cache.
+func (tc *TokenCache) Stats() TokenCacheStats {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return TokenCacheStats{
+		Hits:      tc.hits,
+		Misses:    tc.misses,
+		Evictions: tc.evictions,
+		Size:      tc.ll.Len(),
+	}
+}
+
+// get returns a copy of the cached token, if the token was cached for the given scope and has not
+// expired at the given time.
+func (tc *TokenCache) get(scope, token string, now time.Time) (*Token, bool) {
+	key := cacheKey(scope, token)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	elem, ok := tc.entries[key]
+	if !ok {
+		tc.misses++
+		return nil, false
+	}
+	entry := elem.Value.(*tokenCacheEntry)
+	if !now.Before(entry.expires) {
+		tc.removeElement(elem)
+		tc.misses++
+		return nil, false
+	}
+
+	tc.ll.MoveToFront(elem)
+	tc.hits++
+	return copyToken(entry.token), true
+}
+
+// put caches a copy of the verified token for the given scope until it expires.
+func (tc *TokenCache) put(scope, token string, verified *Token) {
+	key := cacheKey(scope, token)
+	entry := &tokenCacheEntry{
+		key:       key,
+		tokenHash: sha256.Sum256([]byte(token)),
+		token:     copyToken(verified),
+		expires:   time.Unix(verified.Expires, 0),
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if elem, ok := tc.entries[key]; ok {
+		elem.Value = entry
+		tc.ll.MoveToFront(elem)
+		return
+	}
+
+	tc.entries[key] = tc.ll.PushFront(entry)
+	for tc.ll.Len() > tc.maxEntries {
+		tc.removeElement(tc.ll.Back())
+		tc.evictions++
+	}
+}
+
+func (tc *TokenCache) removeElement(elem *list.Element) {
+	tc.ll.Remove(elem)
+	delete(tc.entries, elem.Value.(*tokenCacheEntry).key)
+}
+
+// cacheKey returns the key of a token verified within the given scope. The scope identifies the
+// issuer and audience expected by the verifier.
+func cacheKey(scope, token string) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(scope))
+	h.Write([]byte{0})
+	h.Write([]byte(token))
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// copyToken returns a copy of the token, so that callers cannot modify the cached token.
+func copyToken(t *Token) *Token {
+	dup := *t
+	dup.Claims = copyMap(t.Claims)
+	dup.Firebase.Identities = copyMap(t.Firebase.Identities)
+	return &dup
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	dup := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		dup[k] = v
+	}
+	return dup
+}
+
+// SetIDTokenCache configures a cache of verified ID tokens, which is consulted by VerifyIDToken
+// and VerifyIDTokenAndCheckRevoked before verifying a token.
+//
+// Passing a nil cache disables caching. The cache is shared with all the tenant-aware clients
+// created from the same Client. It should be set before the client is used to verify any ID
+// tokens. Caching has no effect when the client is connected to the Auth emulator.
+func (c *baseClient) SetIDTokenCache(cache *TokenCache) {
+	if c.idTokenVerifier != nil {
+		c.idTokenVerifier.cache = cache
+	}
+}
+
+// SetIDTokenCache configures a cache of verified ID tokens, which is consulted by VerifyIDToken
+// before verifying a token.
+//
+// Passing a nil cache disables caching. The cache should be set before the IDTokenVerifier is
+// used to verify any ID tokens.
+func (v *IDTokenVerifier) SetIDTokenCache(cache *TokenCache) {
+	v.idTokenVerifier.cache = cache
+}
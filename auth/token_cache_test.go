@@ -0,0 +1,265 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+type countingKeySource struct {
+	KeySource
+	mu    sync.Mutex
+	count int
+}
+
+func (k *countingKeySource) Keys(ctx context.Context) ([]*PublicKey, error) {
+	k.mu.Lock()
+	k.count++
+	k.mu.Unlock()
+	return k.KeySource.Keys(ctx)
+}
+
+func TestTokenCache(t *testing.T) {
+	client, ks, cache := tokenCacheClientForTests(t, 10)
+
+	for i := 0; i < 3; i++ {
+		token, err := client.VerifyIDToken(context.Background(), testIDToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.UID != "1234567890" || token.Claims["admin"] != true {
+			t.Errorf("VerifyIDToken() = %#v; want = token for uid %q", token, "1234567890")
+		}
+		token.Claims["admin"] = false
+	}
+
+	if ks.count != 1 {
+		t.Errorf("Keys() called %d times; want = 1", ks.count)
+	}
+	want := TokenCacheStats{Hits: 2, Misses: 1, Size: 1}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %#v; want = %#v", got, want)
+	}
+}
+
+func TestTokenCacheInvalidToken(t *testing.T) {
+	client, _, cache := tokenCacheClientForTests(t, 10)
+	token := getIDToken(mockIDTokenPayload{"aud": "other-project"})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.VerifyIDToken(context.Background(), token); !IsIDTokenInvalid(err) {
+			t.Errorf("VerifyIDToken() = %v; want = ID token invalid error", err)
+		}
+	}
+	want := TokenCacheStats{Misses: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %#v; want = %#v", got, want)
+	}
+}
+
+func TestTokenCacheExpiry(t *testing.T) {
+	client, _, cache := tokenCacheClientForTests(t, 10)
+	clock := &internal.MockClock{Timestamp: testClock.Now()}
+	client.idTokenVerifier.clock = clock
+
+	if _, err := client.VerifyIDToken(context.Background(), testIDToken); err != nil {
+		t.Fatal(err)
+	}
+
+	// The cached entry expires with the token, which the verifier accepts within the clock skew.
+	clock.Timestamp = clock.Timestamp.Add(time.Hour)
+	if _, err := client.VerifyIDToken(context.Background(), testIDToken); err != nil {
+		t.Fatal(err)
+	}
+	clock.Timestamp = clock.Timestamp.Add(time.Hour)
+	if _, err := client.VerifyIDToken(context.Background(), testIDToken); !IsIDTokenExpired(err) {
+		t.Errorf("VerifyIDToken() = %v; want = ID token expired error", err)
+	}
+
+	want := TokenCacheStats{Misses: 3, Size: 0}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %#v; want = %#v", got, want)
+	}
+}
+
+func TestTokenCacheEviction(t *testing.T) {
+	client, ks, cache := tokenCacheClientForTests(t, 2)
+	tokens := []string{
+		getIDToken(mockIDTokenPayload{"sub": "uid1"}),
+		getIDToken(mockIDTokenPayload{"sub": "uid2"}),
+		getIDToken(mockIDTokenPayload{"sub": "uid3"}),
+	}
+	verify := func(token string) {
+		if _, err := client.VerifyIDToken(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verify(tokens[0])
+	verify(tokens[1])
+	verify(tokens[0]) // uid1 becomes the most recently used entry.
+	verify(tokens[2]) // Evicts uid2.
+	verify(tokens[0])
+	verify(tokens[1])
+
+	want := TokenCacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %#v; want = %#v", got, want)
+	}
+	if ks.count != 4 {
+		t.Errorf("Keys() called %d times; want = 4", ks.count)
+	}
+}
+
+func TestTokenCacheInvalidate(t *testing.T) {
+	client, ks, cache := tokenCacheClientForTests(t, 10)
+	other := getIDToken(mockIDTokenPayload{"sub": "uid1"})
+	for _, token := range []string{testIDToken, other} {
+		if _, err := client.VerifyIDToken(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache.Invalidate(testIDToken)
+	if got := cache.Stats().Size; got != 1 {
+		t.Errorf("Size after Invalidate() = %d; want = 1", got)
+	}
+	if _, err := client.VerifyIDToken(context.Background(), testIDToken); err != nil {
+		t.Fatal(err)
+	}
+	if ks.count != 3 {
+		t.Errorf("Keys() called %d times; want = 3", ks.count)
+	}
+
+	cache.Purge()
+	if got := cache.Stats().Size; got != 0 {
+		t.Errorf("Size after Purge() = %d; want = 0", got)
+	}
+}
+
+func TestTokenCacheSharedWithTenantClient(t *testing.T) {
+	conf := &internal.AuthConfig{
+		ProjectID: testProjectID,
+		Opts:      optsWithTokenSource,
+	}
+	client, err := NewClient(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := NewTokenCache(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.SetIDTokenCache(cache)
+	tenantClient, err := client.TenantManager.AuthForTenant("tenantID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenantClient.idTokenVerifier.cache != cache {
+		t.Errorf("TenantClient does not share the ID token cache")
+	}
+
+	client.SetIDTokenCache(nil)
+	if tenantClient.idTokenVerifier.cache != nil {
+		t.Errorf("SetIDTokenCache(nil) did not disable the cache")
+	}
+}
+
+func TestTokenCacheScopedToProject(t *testing.T) {
+	client, _, cache := tokenCacheClientForTests(t, 10)
+	if _, err := client.VerifyIDToken(context.Background(), testIDToken); err != nil {
+		t.Fatal(err)
+	}
+
+	tv, err := idTokenVerifierForTests(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tv.projectID = "other-project"
+	other := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: tv,
+		},
+	}
+	other.SetIDTokenCache(cache)
+
+	if token, err := other.VerifyIDToken(context.Background(), testIDToken); token != nil || err == nil {
+		t.Errorf("VerifyIDToken() = (%v, %v); want = (nil, error)", token, err)
+	}
+	want := TokenCacheStats{Hits: 0, Misses: 2, Size: 1}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %#v; want = %#v", got, want)
+	}
+}
+
+func TestTokenCacheCopiesIdentities(t *testing.T) {
+	client, _, _ := tokenCacheClientForTests(t, 10)
+	token := getIDToken(mockIDTokenPayload{
+		"firebase": map[string]interface{}{
+			"sign_in_provider": "custom",
+			"identities": map[string]interface{}{
+				"email": []interface{}{"test@example.com"},
+			},
+		},
+	})
+	first, err := client.VerifyIDToken(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(first.Firebase.Identities, "email")
+
+	second, err := client.VerifyIDToken(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := second.Firebase.Identities["email"]; !ok {
+		t.Errorf("VerifyIDToken() returned a token sharing Identities with the cache")
+	}
+}
+
+func TestNewTokenCacheError(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if cache, err := NewTokenCache(n); cache != nil || err == nil {
+			t.Errorf("NewTokenCache(%d) = (%v, %v); want = (nil, error)", n, cache, err)
+		}
+	}
+}
+
+func tokenCacheClientForTests(t *testing.T, maxEntries int) (*Client, *countingKeySource, *TokenCache) {
+	tv, err := idTokenVerifierForTests(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := &countingKeySource{KeySource: tv.keySource}
+	tv.keySource = ks
+
+	cache, err := NewTokenCache(maxEntries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: tv,
+		},
+	}
+	client.SetIDTokenCache(cache)
+	return client, ks, cache
+}
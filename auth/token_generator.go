@@ -175,6 +175,7 @@ func newIAMSigner(ctx context.Context, config *internal.AuthConfig) (*iamSigner,
 	if err != nil {
 		return nil, err
 	}
+	hc.MetricsFn = config.MetricsFn
 
 	return &iamSigner{
 		mutex:        &sync.Mutex{},
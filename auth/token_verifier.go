@@ -98,12 +98,15 @@ type tokenVerifier struct {
 	issuerPrefix      string
 	invalidTokenCode  string
 	expiredTokenCode  string
-	keySource         keySource
+	keySource         KeySource
 	clock             internal.Clock
+	codec             internal.JSONCodec
+	cache             *TokenCache
 }
 
-func newIDTokenVerifier(ctx context.Context, projectID string) (*tokenVerifier, error) {
-	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, option.WithoutAuthentication())
+func newIDTokenVerifier(ctx context.Context, projectID string, opts ...option.ClientOption) (*tokenVerifier, error) {
+	opts = append(opts, option.WithoutAuthentication())
+	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,8 +124,9 @@ func newIDTokenVerifier(ctx context.Context, projectID string) (*tokenVerifier,
 	}, nil
 }
 
-func newSessionCookieVerifier(ctx context.Context, projectID string) (*tokenVerifier, error) {
-	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, option.WithoutAuthentication())
+func newSessionCookieVerifier(ctx context.Context, projectID string, opts ...option.ClientOption) (*tokenVerifier, error) {
+	opts = append(opts, option.WithoutAuthentication())
+	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +153,7 @@ func newSessionCookieVerifier(ctx context.Context, projectID string) (*tokenVeri
 //     and projectID of the tokenVerifier.
 //   - The JWT contains a valid subject (sub) claim.
 //   - The JWT is not expired, and it has been issued some time in the past.
-//   - The JWT is signed by a Firebase Auth backend server as determined by the keySource.
+//   - The JWT is signed by a Firebase Auth backend server as determined by the KeySource.
 //
 // If any of the above conditions are not met, an error is returned. Otherwise a pointer to a
 // decoded Token is returned.
@@ -159,6 +163,13 @@ func (tv *tokenVerifier) VerifyToken(ctx context.Context, token string, isEmulat
 		return nil, errors.New("project id not available")
 	}
 
+	cache := tv.cache
+	if cache != nil && !isEmulator {
+		if cached, ok := cache.get(tv.cacheScope(), token, tv.clock.Now()); ok {
+			return cached, nil
+		}
+	}
+
 	// Validate the token content first. This is fast and cheap.
 	payload, err := tv.verifyContent(token, isEmulator)
 	if err != nil {
@@ -180,27 +191,33 @@ func (tv *tokenVerifier) VerifyToken(ctx context.Context, token string, isEmulat
 		return nil, err
 	}
 
+	if cache != nil {
+		cache.put(tv.cacheScope(), token, payload)
+	}
 	return payload, nil
 }
 
+// cacheScope identifies the tokens accepted by this verifier in a TokenCache.
+func (tv *tokenVerifier) cacheScope() string {
+	return tv.issuerPrefix + tv.projectID
+}
+
 func (tv *tokenVerifier) verifyContent(token string, isEmulator bool) (*Token, error) {
 	if token == "" {
-		return nil, &internal.FirebaseError{
+		return nil, withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    fmt.Sprintf("%s must be a non-empty string", tv.shortName),
-			Ext:       map[string]interface{}{authErrorCode: tv.invalidTokenCode},
-		}
+		}, tv.invalidTokenCode)
 	}
 
 	payload, err := tv.verifyHeaderAndBody(token, isEmulator)
 	if err != nil {
-		return nil, &internal.FirebaseError{
+		return nil, withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String: fmt.Sprintf(
 				"%s; see %s for details on how to retrieve a valid %s",
 				err.Error(), tv.docURL, tv.shortName),
-			Ext: map[string]interface{}{authErrorCode: tv.invalidTokenCode},
-		}
+		}, tv.invalidTokenCode)
 	}
 
 	return payload, nil
@@ -208,19 +225,17 @@ func (tv *tokenVerifier) verifyContent(token string, isEmulator bool) (*Token, e
 
 func (tv *tokenVerifier) verifyTimestamps(payload *Token) error {
 	if (payload.IssuedAt - clockSkewSeconds) > tv.clock.Now().Unix() {
-		return &internal.FirebaseError{
+		return withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    fmt.Sprintf("%s issued at future timestamp: %d", tv.shortName, payload.IssuedAt),
-			Ext:       map[string]interface{}{authErrorCode: tv.invalidTokenCode},
-		}
+		}, tv.invalidTokenCode)
 	}
 
 	if (payload.Expires + clockSkewSeconds) < tv.clock.Now().Unix() {
-		return &internal.FirebaseError{
+		return withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    fmt.Sprintf("%s has expired at: %d", tv.shortName, payload.Expires),
-			Ext:       map[string]interface{}{authErrorCode: tv.expiredTokenCode},
-		}
+		}, tv.expiredTokenCode)
 	}
 
 	return nil
@@ -229,19 +244,17 @@ func (tv *tokenVerifier) verifyTimestamps(payload *Token) error {
 func (tv *tokenVerifier) verifySignature(ctx context.Context, token string) error {
 	keys, err := tv.keySource.Keys(ctx)
 	if err != nil {
-		return &internal.FirebaseError{
+		return withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.Unknown,
 			String:    err.Error(),
-			Ext:       map[string]interface{}{authErrorCode: certificateFetchFailed},
-		}
+		}, certificateFetchFailed)
 	}
 
 	if !tv.verifySignatureWithKeys(ctx, token, keys) {
-		return &internal.FirebaseError{
+		return withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.InvalidArgument,
 			String:    "failed to verify token signature",
-			Ext:       map[string]interface{}{authErrorCode: tv.invalidTokenCode},
-		}
+		}, tv.invalidTokenCode)
 	}
 
 	return nil
@@ -258,11 +271,11 @@ func (tv *tokenVerifier) verifyHeaderAndBody(token string, isEmulator bool) (*To
 		return nil, errors.New("incorrect number of segments")
 	}
 
-	if err := decode(segments[0], &header); err != nil {
+	if err := tv.decode(segments[0], &header); err != nil {
 		return nil, err
 	}
 
-	if err := decode(segments[1], &payload); err != nil {
+	if err := tv.decode(segments[1], &payload); err != nil {
 		return nil, err
 	}
 
@@ -296,7 +309,7 @@ func (tv *tokenVerifier) verifyHeaderAndBody(token string, isEmulator bool) (*To
 	payload.UID = payload.Subject
 
 	var customClaims map[string]interface{}
-	if err := decode(segments[1], &customClaims); err != nil {
+	if err := tv.decode(segments[1], &customClaims); err != nil {
 		return nil, err
 	}
 	for _, standardClaim := range []string{"iss", "aud", "exp", "iat", "sub", "uid"} {
@@ -307,10 +320,10 @@ func (tv *tokenVerifier) verifyHeaderAndBody(token string, isEmulator bool) (*To
 	return &payload, nil
 }
 
-func (tv *tokenVerifier) verifySignatureWithKeys(ctx context.Context, token string, keys []*publicKey) bool {
+func (tv *tokenVerifier) verifySignatureWithKeys(ctx context.Context, token string, keys []*PublicKey) bool {
 	segments := strings.Split(token, ".")
 	var h jwtHeader
-	decode(segments[0], &h)
+	tv.decode(segments[0], &h)
 
 	verified := false
 	for _, k := range keys {
@@ -331,6 +344,20 @@ func (tv *tokenVerifier) getProjectIDMatchMessage() string {
 			" authenticate this SDK", tv.shortName)
 }
 
+// decode accepts a JWT segment, and decodes it into the given interface using the JSONCodec
+// configured on the tokenVerifier.
+func (tv *tokenVerifier) decode(segment string, i interface{}) error {
+	if tv.codec == nil {
+		return decode(segment, i)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return tv.codec.Unmarshal(decoded, i)
+}
+
 // decode accepts a JWT segment, and decodes it into the given interface.
 func decode(segment string, i interface{}) error {
 	decoded, err := base64.RawURLEncoding.DecodeString(segment)
@@ -340,7 +367,7 @@ func decode(segment string, i interface{}) error {
 	return json.NewDecoder(bytes.NewBuffer(decoded)).Decode(i)
 }
 
-func verifyJWTSignature(parts []string, k *publicKey) error {
+func verifyJWTSignature(parts []string, k *PublicKey) error {
 	content := parts[0] + "." + parts[1]
 	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
 	if err != nil {
@@ -352,26 +379,29 @@ func verifyJWTSignature(parts []string, k *publicKey) error {
 	return rsa.VerifyPKCS1v15(k.Key, crypto.SHA256, h.Sum(nil), []byte(signature))
 }
 
-// publicKey represents a parsed RSA public key along with its unique key ID.
-type publicKey struct {
+// PublicKey represents a parsed RSA public key along with its unique key ID.
+type PublicKey struct {
 	Kid string
 	Key *rsa.PublicKey
 }
 
-// keySource is used to obtain a set of public keys, which can be used to verify cryptographic
+// KeySource is used to obtain a set of public keys, which can be used to verify cryptographic
 // signatures.
-type keySource interface {
-	Keys(context.Context) ([]*publicKey, error)
+//
+// Implementations must be safe for concurrent use.
+type KeySource interface {
+	Keys(context.Context) ([]*PublicKey, error)
 }
 
 // httpKeySource fetches RSA public keys from a remote HTTP server, and caches them in
 // memory. It also handles cache! invalidation and refresh based on the standard HTTP
-// cache-control headers.
+// cache-control headers, unless a TTL override is set.
 type httpKeySource struct {
 	KeyURI     string
 	HTTPClient *http.Client
-	CachedKeys []*publicKey
+	CachedKeys []*PublicKey
 	ExpiryTime time.Time
+	TTL        time.Duration
 	Clock      internal.Clock
 	Mutex      *sync.Mutex
 }
@@ -387,7 +417,7 @@ func newHTTPKeySource(uri string, hc *http.Client) *httpKeySource {
 
 // Keys returns the RSA Public Keys hosted at this key source's URI. Refreshes the data if
 // the cache is stale.
-func (k *httpKeySource) Keys(ctx context.Context) ([]*publicKey, error) {
+func (k *httpKeySource) Keys(ctx context.Context) ([]*PublicKey, error) {
 	k.Mutex.Lock()
 	defer k.Mutex.Unlock()
 	if len(k.CachedKeys) == 0 || k.hasExpired() {
@@ -399,6 +429,30 @@ func (k *httpKeySource) Keys(ctx context.Context) ([]*publicKey, error) {
 	return k.CachedKeys, nil
 }
 
+// Refresh fetches the RSA Public Keys hosted at this key source's URI, regardless of the state of
+// the cache.
+func (k *httpKeySource) Refresh(ctx context.Context) error {
+	k.Mutex.Lock()
+	defer k.Mutex.Unlock()
+	return k.refreshKeys(ctx)
+}
+
+// Invalidate discards the cached keys, forcing them to be fetched again on the next call to Keys.
+func (k *httpKeySource) Invalidate() {
+	k.Mutex.Lock()
+	defer k.Mutex.Unlock()
+	k.CachedKeys = nil
+	k.ExpiryTime = time.Time{}
+}
+
+// SetTTL overrides the cache duration advertised in the cache-control headers. A zero or negative
+// TTL restores the default behavior. The new TTL takes effect the next time keys are fetched.
+func (k *httpKeySource) SetTTL(ttl time.Duration) {
+	k.Mutex.Lock()
+	defer k.Mutex.Unlock()
+	k.TTL = ttl
+}
+
 // hasExpired indicates whether the cache has expired.
 func (k *httpKeySource) hasExpired() bool {
 	return k.Clock.Now().After(k.ExpiryTime)
@@ -432,24 +486,26 @@ func (k *httpKeySource) refreshKeys(ctx context.Context) error {
 		return err
 	}
 
-	maxAge, err := findMaxAge(resp)
-	if err != nil {
-		return err
+	maxAge := &k.TTL
+	if k.TTL <= 0 {
+		if maxAge, err = findMaxAge(resp); err != nil {
+			return err
+		}
 	}
 
-	k.CachedKeys = append([]*publicKey(nil), newKeys...)
+	k.CachedKeys = append([]*PublicKey(nil), newKeys...)
 	k.ExpiryTime = k.Clock.Now().Add(*maxAge)
 	return nil
 }
 
-func parsePublicKeys(keys []byte) ([]*publicKey, error) {
+func parsePublicKeys(keys []byte) ([]*PublicKey, error) {
 	m := make(map[string]string)
 	err := json.Unmarshal(keys, &m)
 	if err != nil {
 		return nil, err
 	}
 
-	var result []*publicKey
+	var result []*PublicKey
 	for kid, key := range m {
 		pubKey, err := parsePublicKey(kid, []byte(key))
 		if err != nil {
@@ -460,7 +516,7 @@ func parsePublicKeys(keys []byte) ([]*publicKey, error) {
 	return result, nil
 }
 
-func parsePublicKey(kid string, key []byte) (*publicKey, error) {
+func parsePublicKey(kid string, key []byte) (*PublicKey, error) {
 	block, _ := pem.Decode(key)
 	if block == nil {
 		return nil, errors.New("failed to decode the certificate as PEM")
@@ -473,7 +529,7 @@ func parsePublicKey(kid string, key []byte) (*publicKey, error) {
 	if !ok {
 		return nil, errors.New("certificate is not an RSA key")
 	}
-	return &publicKey{kid, pk}, nil
+	return &PublicKey{kid, pk}, nil
 }
 
 func findMaxAge(resp *http.Response) (*time.Duration, error) {
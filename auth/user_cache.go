@@ -0,0 +1,183 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// UserCache is a read-through cache for user records.
+//
+// When a UserCache is configured on a client, GetUser and GetUsers consult it for users looked up
+// by UID before calling the backend, and store the records fetched from the backend in it. Entries
+// are removed when the corresponding user is updated or deleted via the same client. Cache keys
+// are derived from the tenant ID and the UID of the user.
+//
+// Implementations must be safe for concurrent use.
+type UserCache interface {
+	// Get returns the user record stored under the given key, and a boolean indicating whether
+	// the record was found and has not expired.
+	Get(ctx context.Context, key string) (*UserRecord, bool)
+
+	// Set stores the given user record under the given key for the specified duration.
+	Set(ctx context.Context, key string, user *UserRecord, ttl time.Duration)
+
+	// Delete removes the entry stored under the given key, if any.
+	Delete(ctx context.Context, key string)
+}
+
+// SetUserCache configures a read-through cache for user lookups, with records cached for the
+// specified duration.
+//
+// Passing a nil cache disables caching. Tenant-aware clients obtained from the TenantManager
+// after this call share the same cache.
+//
+// Revocation checks performed by VerifyIDTokenAndCheckRevoked and
+// VerifySessionCookieAndCheckRevoked always bypass the cache. Updates made to users outside this
+// client (for example from another process, or via ImportUsers) are not visible until the cached
+// entries expire.
+func (c *baseClient) SetUserCache(cache UserCache, ttl time.Duration) {
+	c.userCache.set(cache, ttl)
+}
+
+// userCacheConfig holds the UserCache of a client, which may be replaced while the client is in
+// use.
+type userCacheConfig struct {
+	mu    sync.RWMutex
+	cache UserCache
+	ttl   time.Duration
+}
+
+func (uc *userCacheConfig) get() (UserCache, time.Duration) {
+	if uc == nil {
+		return nil, 0
+	}
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.cache, uc.ttl
+}
+
+func (uc *userCacheConfig) set(cache UserCache, ttl time.Duration) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.cache = cache
+	uc.ttl = ttl
+}
+
+// clone returns a copy of the config, so that the caches of tenant-aware clients can be replaced
+// independently of the parent client.
+func (uc *userCacheConfig) clone() *userCacheConfig {
+	cache, ttl := uc.get()
+	return &userCacheConfig{cache: cache, ttl: ttl}
+}
+
+func (c *baseClient) hasUserCache() bool {
+	cache, _ := c.userCache.get()
+	return cache != nil
+}
+
+func (c *baseClient) userCacheKey(uid string) string {
+	return c.tenantID + "/" + uid
+}
+
+// cachedUser returns a copy of the cached record of the user, so that callers cannot modify the
+// cached record.
+func (c *baseClient) cachedUser(ctx context.Context, uid string) (*UserRecord, bool) {
+	cache, _ := c.userCache.get()
+	if cache == nil {
+		return nil, false
+	}
+	user, ok := cache.Get(ctx, c.userCacheKey(uid))
+	if !ok || user == nil {
+		return nil, false
+	}
+	return copyUserRecord(user), true
+}
+
+func (c *baseClient) cachedUserForIdentifier(ctx context.Context, id UserIdentifier) (*UserRecord, bool) {
+	switch uid := id.(type) {
+	case UIDIdentifier:
+		return c.cachedUser(ctx, uid.UID)
+	case *UIDIdentifier:
+		return c.cachedUser(ctx, uid.UID)
+	default:
+		return nil, false
+	}
+}
+
+func (c *baseClient) cacheUser(ctx context.Context, user *UserRecord) {
+	cache, ttl := c.userCache.get()
+	if cache == nil || ttl <= 0 {
+		return
+	}
+	cache.Set(ctx, c.userCacheKey(user.UID), copyUserRecord(user), ttl)
+}
+
+func (c *baseClient) invalidateCachedUser(ctx context.Context, uid string) {
+	cache, _ := c.userCache.get()
+	if cache == nil {
+		return
+	}
+	cache.Delete(ctx, c.userCacheKey(uid))
+}
+
+// copyUserRecord returns a deep copy of the given user record.
+func copyUserRecord(u *UserRecord) *UserRecord {
+	dup := *u
+	if u.UserInfo != nil {
+		info := *u.UserInfo
+		dup.UserInfo = &info
+	}
+	if u.CustomClaims != nil {
+		dup.CustomClaims = make(map[string]interface{}, len(u.CustomClaims))
+		for k, v := range u.CustomClaims {
+			dup.CustomClaims[k] = v
+		}
+	}
+	if u.ProviderUserInfo != nil {
+		dup.ProviderUserInfo = make([]*UserInfo, len(u.ProviderUserInfo))
+		for i, p := range u.ProviderUserInfo {
+			if p != nil {
+				info := *p
+				dup.ProviderUserInfo[i] = &info
+			}
+		}
+	}
+	if u.UserMetadata != nil {
+		metadata := *u.UserMetadata
+		dup.UserMetadata = &metadata
+	}
+	if u.MultiFactor != nil {
+		factors := make([]*MultiFactorInfo, len(u.MultiFactor.EnrolledFactors))
+		for i, f := range u.MultiFactor.EnrolledFactors {
+			if f == nil {
+				continue
+			}
+			factor := *f
+			if f.Phone != nil {
+				phone := *f.Phone
+				factor.Phone = &phone
+			}
+			if f.TOTP != nil {
+				factor.TOTP = &TOTPMultiFactorInfo{}
+			}
+			factors[i] = &factor
+		}
+		dup.MultiFactor = &MultiFactorSettings{EnrolledFactors: factors}
+	}
+	return &dup
+}
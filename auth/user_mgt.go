@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -82,10 +81,12 @@ type PhoneMultiFactorInfo struct {
 type TOTPMultiFactorInfo struct{}
 
 type multiFactorEnrollments struct {
-	Enrollments []*multiFactorInfoResponse `json:"enrollments"`
+	Enrollments []*multiFactorInfoResponse `json:"enrollments,omitempty"`
 }
 
 // MultiFactorInfo describes a user enrolled second phone factor.
+//
+// EnrollmentTimestamp is in milliseconds since epoch.
 type MultiFactorInfo struct {
 	UID                 string
 	DisplayName         string
@@ -182,11 +183,15 @@ func (u *UserToCreate) set(key string, value interface{}) *UserToCreate {
 	return u
 }
 
+// enrolledAtFormat is the RFC 3339 format, with millisecond precision, expected by the backend for
+// the enrollment time of second factors.
+const enrolledAtFormat = "2006-01-02T15:04:05.000Z07:00"
+
 // Converts a client format second factor object to server format.
 func convertMultiFactorInfoToServerFormat(mfaInfo MultiFactorInfo) (multiFactorInfoResponse, error) {
 	authFactorInfo := multiFactorInfoResponse{DisplayName: mfaInfo.DisplayName}
 	if mfaInfo.EnrollmentTimestamp != 0 {
-		authFactorInfo.EnrolledAt = time.Unix(mfaInfo.EnrollmentTimestamp, 0).Format("2006-01-02T15:04:05Z07:00Z")
+		authFactorInfo.EnrolledAt = time.UnixMilli(mfaInfo.EnrollmentTimestamp).UTC().Format(enrolledAtFormat)
 	}
 	if mfaInfo.UID != "" {
 		authFactorInfo.MFAEnrollmentID = mfaInfo.UID
@@ -196,7 +201,7 @@ func convertMultiFactorInfoToServerFormat(mfaInfo MultiFactorInfo) (multiFactorI
 	case phoneMultiFactorID:
 		authFactorInfo.PhoneInfo = mfaInfo.Phone.PhoneNumber
 	case totpMultiFactorID:
-		authFactorInfo.TOTPInfo = (*TOTPInfo)(mfaInfo.TOTP)
+		authFactorInfo.TOTPInfo = &TOTPInfo{}
 	default:
 		out, _ := json.Marshal(mfaInfo)
 		return multiFactorInfoResponse{}, fmt.Errorf("unsupported second factor %s provided", string(out))
@@ -234,9 +239,11 @@ func (u *UserToCreate) validatedRequest() (map[string]interface{}, error) {
 		}
 	}
 	if phone, ok := req["phoneNumber"]; ok {
-		if err := validatePhone(phone.(string)); err != nil {
+		normalized, err := NormalizePhoneNumber(phone.(string))
+		if err != nil {
 			return nil, err
 		}
+		req["phoneNumber"] = normalized
 	}
 	if url, ok := req["photoUrl"]; ok {
 		if err := validatePhotoURL(url.(string)); err != nil {
@@ -298,6 +305,11 @@ func (u *UserToUpdate) PhotoURL(url string) *UserToUpdate {
 }
 
 // MFASettings setter.
+//
+// The given factors replace all the second factors currently enrolled by the user. Factors with a
+// UID are kept with their existing enrollment ID, while factors without one are enrolled anew.
+// Passing a MultiFactorSettings with no EnrolledFactors unenrolls all the second factors of the
+// user.
 func (u *UserToUpdate) MFASettings(mfaSettings MultiFactorSettings) *UserToUpdate {
 	return u.set("mfaSettings", mfaSettings)
 }
@@ -399,8 +411,12 @@ func (u *UserToUpdate) validatedRequest() (map[string]interface{}, error) {
 	if phone, ok := req["phoneNumber"]; ok {
 		if phone == "" {
 			handleDeletion("phoneNumber", "deleteProvider", "phone")
-		} else if err := validatePhone(phone.(string)); err != nil {
-			return nil, err
+		} else {
+			normalized, err := NormalizePhoneNumber(phone.(string))
+			if err != nil {
+				return nil, err
+			}
+			req["phoneNumber"] = normalized
 		}
 	}
 
@@ -494,11 +510,15 @@ func (u *UserToUpdate) validatedRequest() (map[string]interface{}, error) {
 }
 
 func marshalCustomClaims(claims map[string]interface{}) (string, error) {
+	var reserved []string
 	for _, key := range reservedClaims {
 		if _, ok := claims[key]; ok {
-			return "", fmt.Errorf("claim %q is reserved and must not be set", key)
+			reserved = append(reserved, key)
 		}
 	}
+	if len(reserved) > 0 {
+		return "", &CustomClaimsError{ReservedClaims: reserved, MaxSize: maxLenPayloadCC}
+	}
 
 	b, err := json.Marshal(claims)
 	if err != nil {
@@ -509,7 +529,7 @@ func marshalCustomClaims(claims map[string]interface{}) (string, error) {
 		s = "{}" // claims map has been explicitly set to nil for deletion.
 	}
 	if len(s) > maxLenPayloadCC {
-		return "", fmt.Errorf("serialized custom claims must not exceed %d characters", maxLenPayloadCC)
+		return "", &CustomClaimsError{Size: len(s), MaxSize: maxLenPayloadCC}
 	}
 	return s, nil
 }
@@ -646,13 +666,8 @@ func validateUID(uid string) error {
 }
 
 func validatePhone(phone string) error {
-	if phone == "" {
-		return fmt.Errorf("phone number must be a non-empty string")
-	}
-	if !regexp.MustCompile(`\+.*[0-9A-Za-z]`).MatchString(phone) {
-		return fmt.Errorf("phone number must be a valid, E.164 compliant identifier")
-	}
-	return nil
+	_, err := NormalizePhoneNumber(phone)
+	return err
 }
 
 func validateProviderUserInfo(p *UserProvider) error {
@@ -721,6 +736,10 @@ func validateAndFormatMfaSettings(mfaSettings MultiFactorSettings, methodType st
 		if err != nil {
 			return nil, err
 		}
+		if obj.PhoneInfo != "" {
+			// The phone number has already been validated above.
+			obj.PhoneInfo, _ = NormalizePhoneNumber(obj.PhoneInfo)
+		}
 		mfaInfo = append(mfaInfo, &obj)
 	}
 	return mfaInfo, nil
@@ -729,7 +748,23 @@ func validateAndFormatMfaSettings(mfaSettings MultiFactorSettings, methodType st
 // End of validators
 
 // GetUser gets the user data corresponding to the specified user ID.
+//
+// If a UserCache is configured on the client, GetUser returns the cached record when available.
 func (c *baseClient) GetUser(ctx context.Context, uid string) (*UserRecord, error) {
+	if user, ok := c.cachedUser(ctx, uid); ok {
+		return user, nil
+	}
+
+	user, err := c.getUserByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cacheUser(ctx, user)
+	return user, nil
+}
+
+func (c *baseClient) getUserByUID(ctx context.Context, uid string) (*UserRecord, error) {
 	return c.getUser(ctx, &userQuery{
 		field: "localId",
 		value: uid,
@@ -750,12 +785,13 @@ func (c *baseClient) GetUserByEmail(ctx context.Context, email string) (*UserRec
 
 // GetUserByPhoneNumber gets the user data corresponding to the specified user phone number.
 func (c *baseClient) GetUserByPhoneNumber(ctx context.Context, phone string) (*UserRecord, error) {
-	if err := validatePhone(phone); err != nil {
+	normalized, err := NormalizePhoneNumber(phone)
+	if err != nil {
 		return nil, err
 	}
 	return c.getUser(ctx, &userQuery{
 		field: "phoneNumber",
-		value: phone,
+		value: normalized,
 		label: "phone number",
 	})
 }
@@ -795,14 +831,11 @@ func (c *baseClient) GetUserByProviderUID(ctx context.Context, providerID string
 	}
 
 	if len(getUsersResult.Users) == 0 {
-		return nil, &internal.FirebaseError{
+		return nil, withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.NotFound,
 			String:    fmt.Sprintf("cannot find user from providerID: { %s, %s }", providerID, providerUID),
 			Response:  nil,
-			Ext: map[string]interface{}{
-				authErrorCode: userNotFound,
-			},
-		}
+		}, userNotFound)
 	}
 
 	return getUsersResult.Users[0], nil
@@ -840,14 +873,11 @@ func (c *baseClient) getUser(ctx context.Context, query *userQuery) (*UserRecord
 	}
 
 	if len(parsed.Users) == 0 {
-		return nil, &internal.FirebaseError{
+		return nil, withAuthErrorCode(&internal.FirebaseError{
 			ErrorCode: internal.NotFound,
 			String:    fmt.Sprintf("no user exists with the %s", query.description()),
 			Response:  resp.LowLevelResponse(),
-			Ext: map[string]interface{}{
-				authErrorCode: userNotFound,
-			},
-		}
+		}, userNotFound)
 	}
 
 	return parsed.Users[0].makeUserRecord()
@@ -897,6 +927,9 @@ type PhoneIdentifier struct {
 }
 
 func (id PhoneIdentifier) matches(ur *UserRecord) bool {
+	if normalized, err := NormalizePhoneNumber(id.PhoneNumber); err == nil {
+		return normalized == ur.PhoneNumber
+	}
 	return id.PhoneNumber == ur.PhoneNumber
 }
 
@@ -963,9 +996,11 @@ func (req *getAccountInfoRequest) validate() error {
 	}
 
 	for i := range req.PhoneNumber {
-		if err := validatePhone(req.PhoneNumber[i]); err != nil {
+		normalized, err := NormalizePhoneNumber(req.PhoneNumber[i])
+		if err != nil {
 			return err
 		}
+		req.PhoneNumber[i] = normalized
 	}
 
 	for i := range req.FederatedUserID {
@@ -999,6 +1034,9 @@ func isUserFound(id UserIdentifier, urs [](*UserRecord)) bool {
 // Returns the corresponding user records. An error is returned instead if any
 // of the identifiers are invalid or if more than 100 identifiers are
 // specified.
+//
+// If a UserCache is configured on the client, users identified by a UIDIdentifier are served
+// from the cache when available, and only the remaining identifiers are sent to the backend.
 func (c *baseClient) GetUsers(
 	ctx context.Context, identifiers []UserIdentifier,
 ) (*GetUsersResult, error) {
@@ -1018,18 +1056,41 @@ func (c *baseClient) GetUsers(
 		return nil, err
 	}
 
-	var parsed getAccountInfoResponse
-	if _, err := c.post(ctx, "/accounts:lookup", request, &parsed); err != nil {
-		return nil, err
+	var userRecords [](*UserRecord)
+	pending := len(identifiers)
+	if c.hasUserCache() {
+		request = getAccountInfoRequest{}
+		pending = 0
+		for _, id := range identifiers {
+			if user, ok := c.cachedUserForIdentifier(ctx, id); ok {
+				if !isUserFound(UIDIdentifier{user.UID}, userRecords) {
+					userRecords = append(userRecords, user)
+				}
+				continue
+			}
+			id.populate(&request)
+			pending++
+		}
 	}
 
-	var userRecords [](*UserRecord)
-	for _, user := range parsed.Users {
-		userRecord, err := user.makeUserRecord()
-		if err != nil {
+	if pending > 0 {
+		var parsed getAccountInfoResponse
+		if _, err := c.post(ctx, "/accounts:lookup", request, &parsed); err != nil {
 			return nil, err
 		}
-		userRecords = append(userRecords, userRecord)
+
+		cached := len(userRecords)
+		for _, user := range parsed.Users {
+			userRecord, err := user.makeUserRecord()
+			if err != nil {
+				return nil, err
+			}
+			if isUserFound(UIDIdentifier{userRecord.UID}, userRecords[:cached]) {
+				continue
+			}
+			c.cacheUser(ctx, userRecord)
+			userRecords = append(userRecords, userRecord)
+		}
 	}
 
 	var notFound []UserIdentifier
@@ -1097,7 +1158,7 @@ func (r *userQueryResponse) makeExportedUserRecord() (*ExportedUserRecord, error
 		if err != nil {
 			return nil, err
 		}
-		lastRefreshTimestamp = t.Unix() * 1000
+		lastRefreshTimestamp = t.UnixMilli()
 	}
 
 	// Map the MFA info to a slice of enrolled factors. Currently there is only
@@ -1110,7 +1171,7 @@ func (r *userQueryResponse) makeExportedUserRecord() (*ExportedUserRecord, error
 			if err != nil {
 				return nil, err
 			}
-			enrollmentTimestamp = t.Unix() * 1000
+			enrollmentTimestamp = t.UnixMilli()
 		}
 
 		if factor.PhoneInfo != "" {
@@ -1221,7 +1282,10 @@ func (c *baseClient) RevokeRefreshTokens(ctx context.Context, uid string) error
 // expiration or when token refresh is forced), and next time the user signs in. The claims
 // can be accessed via the user's ID token JWT. If a reserved OIDC claim is specified (sub, iat,
 // iss, etc), an error is thrown. Claims payload must also not be larger then 1000 characters
-// when serialized into a JSON string.
+// when serialized into a JSON string. Invalid claims are reported via a *CustomClaimsError.
+//
+// SetCustomUserClaims overwrites any existing claims of the user. Use MergeCustomUserClaims to
+// add claims to the existing ones instead.
 func (c *baseClient) SetCustomUserClaims(ctx context.Context, uid string, customClaims map[string]interface{}) error {
 	if customClaims == nil || len(customClaims) == 0 {
 		customClaims = map[string]interface{}{}
@@ -1244,6 +1308,8 @@ func (c *baseClient) updateUser(ctx context.Context, uid string, user *UserToUpd
 	request["localId"] = uid
 
 	_, err = c.post(ctx, "/accounts:update", request, nil)
+	// Invalidate even if the request failed, since the update may have been applied.
+	c.invalidateCachedUser(ctx, uid)
 	return err
 }
 
@@ -1257,6 +1323,7 @@ func (c *baseClient) DeleteUser(ctx context.Context, uid string) error {
 		"localId": uid,
 	}
 	_, err := c.post(ctx, "/accounts:delete", payload, nil)
+	c.invalidateCachedUser(ctx, uid)
 	return err
 }
 
@@ -1332,7 +1399,11 @@ func (c *baseClient) DeleteUsers(ctx context.Context, uids []string) (*DeleteUse
 	}
 
 	resp := batchDeleteAccountsResponse{}
-	if _, err := c.post(ctx, "/accounts:batchDelete", payload, &resp); err != nil {
+	_, err := c.post(ctx, "/accounts:batchDelete", payload, &resp)
+	for _, uid := range uids {
+		c.invalidateCachedUser(ctx, uid)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -1474,12 +1545,12 @@ func handleHTTPError(resp *internal.Response) error {
 	code, detail := parseErrorResponse(resp)
 	if authErr, ok := serverError[code]; ok {
 		err.ErrorCode = authErr.code
-		err.Ext[authErrorCode] = authErr.authCode
 		if detail != "" {
 			err.String = fmt.Sprintf("%s: %s", authErr.message, detail)
 		} else {
 			err.String = authErr.message
 		}
+		withAuthErrorCode(err, authErr.authCode)
 	}
 
 	return err
@@ -71,7 +71,7 @@ var testUser = &UserRecord{
 			{
 				UID:                 "enrolledPhoneFactor",
 				FactorID:            "phone",
-				EnrollmentTimestamp: 1614776780000,
+				EnrollmentTimestamp: 1614776780542,
 				Phone: &PhoneMultiFactorInfo{
 					PhoneNumber: "+1234567890",
 				},
@@ -81,7 +81,7 @@ var testUser = &UserRecord{
 			{
 				UID:                 "enrolledTOTPFactor",
 				FactorID:            "totp",
-				EnrollmentTimestamp: 1614776780000,
+				EnrollmentTimestamp: 1614776780542,
 				TOTP:                &TOTPMultiFactorInfo{},
 				DisplayName:         "My MFA TOTP",
 			},
@@ -147,6 +147,54 @@ func TestGetUser(t *testing.T) {
 	}
 }
 
+func TestGetUserMetadataTimestamps(t *testing.T) {
+	resp := `{
+		"users": [{
+			"localId": "testuser",
+			"createdAt": "1234567890000",
+			"lastLoginAt": "1233211232000",
+			"lastRefreshAt": "2021-03-03T13:06:20.542896Z",
+			"mfaInfo": [{
+				"mfaEnrollmentId": "enrolledTOTPFactor",
+				"displayName": "My TOTP",
+				"totpInfo": {},
+				"enrolledAt": "2021-03-03T13:06:21.123Z"
+			}]
+		}]
+	}`
+	s := echoServer([]byte(resp), t)
+	defer s.Close()
+
+	user, err := s.Client.GetUser(context.Background(), "testuser")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &UserMetadata{
+		CreationTimestamp:    1234567890000,
+		LastLogInTimestamp:   1233211232000,
+		LastRefreshTimestamp: 1614776780542,
+	}
+	if !reflect.DeepEqual(user.UserMetadata, want) {
+		t.Errorf("GetUser().UserMetadata = %#v; want = %#v", user.UserMetadata, want)
+	}
+
+	wantMFA := &MultiFactorSettings{
+		EnrolledFactors: []*MultiFactorInfo{
+			{
+				UID:                 "enrolledTOTPFactor",
+				DisplayName:         "My TOTP",
+				EnrollmentTimestamp: 1614776781123,
+				FactorID:            "totp",
+				TOTP:                &TOTPMultiFactorInfo{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(user.MultiFactor, wantMFA) {
+		t.Errorf("GetUser().MultiFactor = %#v; want = %#v", user.MultiFactor, wantMFA)
+	}
+}
+
 func TestGetUserByEmail(t *testing.T) {
 	s := echoServer(testGetUserResponse, t)
 	defer s.Close()
@@ -419,7 +467,7 @@ func TestGetUsersInvalidPhoneNumber(t *testing.T) {
 	getUsersResult, err := client.GetUsers(context.Background(), []UserIdentifier{
 		PhoneIdentifier{"invalid phone number"},
 	})
-	want := "phone number must be a valid, E.164 compliant identifier"
+	want := `phone number must be a valid, E.164 compliant identifier: "invalid phone number" must start with a "+" followed by the country code`
 	if getUsersResult != nil || err == nil || err.Error() != want {
 		t.Errorf("GetUsers() = (%v, %q); want = (nil, %q)", getUsersResult, err, want)
 	}
@@ -517,6 +565,177 @@ func TestGetUsersMultipleIdentifierTypes(t *testing.T) {
 	}
 }
 
+type mockUserCache struct {
+	users map[string]*UserRecord
+	ttl   time.Duration
+}
+
+func newMockUserCache() *mockUserCache {
+	return &mockUserCache{users: make(map[string]*UserRecord)}
+}
+
+func (m *mockUserCache) Get(ctx context.Context, key string) (*UserRecord, bool) {
+	u, ok := m.users[key]
+	return u, ok
+}
+
+func (m *mockUserCache) Set(ctx context.Context, key string, user *UserRecord, ttl time.Duration) {
+	m.users[key] = user
+	m.ttl = ttl
+}
+
+func (m *mockUserCache) Delete(ctx context.Context, key string) {
+	delete(m.users, key)
+}
+
+func TestGetUserWithCache(t *testing.T) {
+	s := echoServer(testGetUserResponse, t)
+	defer s.Close()
+
+	cache := newMockUserCache()
+	s.Client.SetUserCache(cache, time.Minute)
+	for i := 0; i < 2; i++ {
+		user, err := s.Client.GetUser(context.Background(), "testuser")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(user, testUser) {
+			t.Errorf("GetUser() = %#v; want = %#v", user, testUser)
+		}
+	}
+
+	if len(s.Req) != 1 {
+		t.Errorf("GetUser() Requests = %d; want = 1", len(s.Req))
+	}
+	if _, ok := cache.users["/testuser"]; !ok || cache.ttl != time.Minute {
+		t.Errorf("UserCache = %v; want = {/testuser: %v}", cache.users, testUser)
+	}
+
+	if err := s.Client.SetCustomUserClaims(context.Background(), "testuser", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.users) != 0 {
+		t.Errorf("UserCache = %v; want = {}", cache.users)
+	}
+}
+
+func TestGetUserWithCacheReturnsCopies(t *testing.T) {
+	s := echoServer(testGetUserResponse, t)
+	defer s.Close()
+
+	s.Client.SetUserCache(newMockUserCache(), time.Minute)
+	user, err := s.Client.GetUser(context.Background(), "testuser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.DisplayName = "Modified"
+	user.CustomClaims["admin"] = false
+	user.ProviderUserInfo[0].Email = "modified@example.com"
+
+	user, err = s.Client.GetUser(context.Background(), "testuser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(user, testUser) {
+		t.Errorf("GetUser() = %#v; want = %#v", user, testUser)
+	}
+}
+
+type recordingUserCache struct {
+	*mockUserCache
+	onDelete func()
+}
+
+func (r *recordingUserCache) Delete(ctx context.Context, key string) {
+	r.onDelete()
+	r.mockUserCache.Delete(ctx, key)
+}
+
+func TestUserCacheInvalidatedAfterWrite(t *testing.T) {
+	s := echoServer([]byte("{}"), t)
+	defer s.Close()
+
+	var requests []int
+	cache := &recordingUserCache{
+		mockUserCache: newMockUserCache(),
+		onDelete: func() {
+			requests = append(requests, len(s.Req))
+		},
+	}
+	s.Client.SetUserCache(cache, time.Minute)
+
+	if err := s.Client.DeleteUser(context.Background(), "uid1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Client.RevokeRefreshTokens(context.Background(), "uid1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Client.DeleteUsers(context.Background(), []string{"uid1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Requests made before invalidation = %v; want = %v", requests, want)
+	}
+}
+
+func TestGetUsersWithCache(t *testing.T) {
+	mockUsers := []byte(`
+			{
+				"users": [{
+					"localId": "uid2",
+					"email": "user2@example.com"
+				}]
+			}`)
+	s := echoServer(mockUsers, t)
+	defer s.Close()
+
+	cache := newMockUserCache()
+	cache.users["/uid1"] = &UserRecord{UserInfo: &UserInfo{UID: "uid1"}}
+	s.Client.SetUserCache(cache, time.Minute)
+
+	identifiers := []UserIdentifier{
+		&UIDIdentifier{"uid1"},
+		&EmailIdentifier{"user2@example.com"},
+		&UIDIdentifier{"this-user-doesnt-exist"},
+	}
+	getUsersResult, err := s.Client.GetUsers(context.Background(), identifiers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !sameUsers(getUsersResult.Users, []string{"uid1", "uid2"}) {
+		t.Errorf("GetUsers() = %v; want = (uids from) %v (in any order)",
+			getUsersResult.Users, []string{"uid1", "uid2"})
+	}
+	if len(getUsersResult.NotFound) != 1 {
+		t.Errorf("GetUsers() = %d; want = 1", len(getUsersResult.NotFound))
+	}
+
+	want := `{"localId":["this-user-doesnt-exist"],"email":["user2@example.com"]}`
+	if got := string(s.Rbody); got != want {
+		t.Errorf("GetUsers() Req = %v; want = %v", got, want)
+	}
+	if _, ok := cache.users["/uid2"]; !ok {
+		t.Errorf("UserCache = %v; want = {/uid1, /uid2}", cache.users)
+	}
+
+	// No request is made when all users are served from the cache.
+	getUsersResult, err = s.Client.GetUsers(context.Background(), []UserIdentifier{
+		UIDIdentifier{"uid1"}, UIDIdentifier{"uid2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameUsers(getUsersResult.Users, []string{"uid1", "uid2"}) || len(getUsersResult.NotFound) != 0 {
+		t.Errorf("GetUsers() = %v; want = (uids from) %v", getUsersResult.Users, []string{"uid1", "uid2"})
+	}
+	if len(s.Req) != 1 {
+		t.Errorf("GetUsers() Requests = %d; want = 1", len(s.Req))
+	}
+}
+
 func TestGetNonExistingUser(t *testing.T) {
 	resp := `{
 		"kind" : "identitytoolkit#GetAccountInfoResponse",
@@ -608,6 +827,113 @@ func TestListUsers(t *testing.T) {
 		"maxResults=1000&nextPageToken=pageToken")
 }
 
+func TestListUsersWithOptions(t *testing.T) {
+	testListUsersResponse, err := ioutil.ReadFile("../testdata/list_users.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := echoServer(testListUsersResponse, t)
+	defer s.Close()
+
+	iter := s.Client.UsersWithOptions(context.Background(), &ListUsersOptions{
+		PageToken:  "pageToken",
+		PageSize:   10,
+		MaxResults: 2,
+	})
+	if token := iter.ResumeToken(); token != "pageToken" {
+		t.Errorf("ResumeToken() = %q; want = %q", token, "pageToken")
+	}
+
+	user, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.PasswordHash != "passwordhash1" {
+		t.Errorf("Next() PasswordHash = %q; want = %q", user.PasswordHash, "passwordhash1")
+	}
+	// The current page has not been fully consumed yet.
+	if token := iter.ResumeToken(); token != "pageToken" {
+		t.Errorf("ResumeToken() = %q; want = %q", token, "pageToken")
+	}
+
+	if _, err := iter.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := iter.Next(); err != iterator.Done {
+		t.Errorf("Next() = %v; want = %v", err, iterator.Done)
+	}
+
+	if len(s.Req) != 1 {
+		t.Errorf("Users() Requests = %d; want = 1", len(s.Req))
+	}
+	want := "maxResults=2&nextPageToken=pageToken"
+	if got := s.Req[0].URL.Query().Encode(); got != want {
+		t.Errorf("Users() = %q; want = %q", got, want)
+	}
+}
+
+func TestListUsersResumeToken(t *testing.T) {
+	s := echoServer([]byte(`{"users": [{"localId": "uid1"}], "nextPageToken": "nextToken"}`), t)
+	defer s.Close()
+
+	iter := s.Client.UsersWithOptions(context.Background(), &ListUsersOptions{PageSize: 1})
+	if _, err := iter.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if token := iter.ResumeToken(); token != "nextToken" {
+		t.Errorf("ResumeToken() = %q; want = %q", token, "nextToken")
+	}
+
+	want := "maxResults=1"
+	if got := s.Req[0].URL.Query().Encode(); got != want {
+		t.Errorf("Users() = %q; want = %q", got, want)
+	}
+}
+
+func TestListUsersRetriesFailedPage(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"users": [{"localId": "uid1"}]}`))
+	}))
+	defer ts.Close()
+	s := echoServer(nil, t)
+	defer s.Close()
+	s.Client.userManagementEndpoint = ts.URL
+
+	iter := s.Client.Users(context.Background(), "")
+	user, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UID != "uid1" {
+		t.Errorf("Next() UID = %q; want = %q", user.UID, "uid1")
+	}
+	if requests != 2 {
+		t.Errorf("Users() Requests = %d; want = 2", requests)
+	}
+}
+
+func TestListUsersInvalidOptions(t *testing.T) {
+	client := &Client{baseClient: &baseClient{}}
+	cases := []*ListUsersOptions{
+		{PageSize: -1},
+		{PageSize: 1001},
+		{MaxResults: -1},
+	}
+	for _, opts := range cases {
+		iter := client.UsersWithOptions(context.Background(), opts)
+		if user, err := iter.Next(); user != nil || err == nil || err == iterator.Done {
+			t.Errorf("UsersWithOptions(%#v).Next() = (%v, %v); want = (nil, error)", opts, user, err)
+		}
+	}
+}
+
 func TestInvalidCreateUser(t *testing.T) {
 	cases := []struct {
 		params *UserToCreate
@@ -621,10 +947,16 @@ func TestInvalidCreateUser(t *testing.T) {
 			"phone number must be a non-empty string",
 		}, {
 			(&UserToCreate{}).PhoneNumber("1234"),
-			"phone number must be a valid, E.164 compliant identifier",
+			`phone number must be a valid, E.164 compliant identifier: "1234" must start with a "+" followed by the country code`,
 		}, {
 			(&UserToCreate{}).PhoneNumber("+_!@#$"),
-			"phone number must be a valid, E.164 compliant identifier",
+			`phone number must be a valid, E.164 compliant identifier: "+_!@#$" contains the invalid character '_'`,
+		}, {
+			(&UserToCreate{}).PhoneNumber("+0123456789"),
+			`phone number must be a valid, E.164 compliant identifier: the country code of "+0123456789" must not start with 0`,
+		}, {
+			(&UserToCreate{}).PhoneNumber("+1234567890123456"),
+			`phone number must be a valid, E.164 compliant identifier: "+1234567890123456" has 16 digits, but at most 15 are allowed`,
 		}, {
 			(&UserToCreate{}).UID(""),
 			"uid must be a non-empty string",
@@ -762,6 +1094,10 @@ var createUserCases = []struct {
 		(&UserToCreate{}).PhoneNumber("+1"),
 		map[string]interface{}{"phoneNumber": "+1"},
 	},
+	{
+		(&UserToCreate{}).PhoneNumber("+1 555.555.0100"),
+		map[string]interface{}{"phoneNumber": "+15555550100"},
+	},
 	{
 		(&UserToCreate{}).DisplayName("a"),
 		map[string]interface{}{"displayName": "a"},
@@ -899,7 +1235,7 @@ func TestInvalidUpdateUser(t *testing.T) {
 			`malformed email string: "invalid"`,
 		}, {
 			(&UserToUpdate{}).PhoneNumber("1"),
-			"phone number must be a valid, E.164 compliant identifier",
+			`phone number must be a valid, E.164 compliant identifier: "1" must start with a "+" followed by the country code`,
 		}, {
 			(&UserToUpdate{}).CustomClaims(map[string]interface{}{"a": strings.Repeat("a", 993)}),
 			"serialized custom claims must not exceed 1000 characters",
@@ -1026,6 +1362,10 @@ var updateUserCases = []struct {
 		(&UserToUpdate{}).PhoneNumber("+1"),
 		map[string]interface{}{"phoneNumber": "+1"},
 	},
+	{
+		(&UserToUpdate{}).PhoneNumber("+1 (555) 555-0100"),
+		map[string]interface{}{"phoneNumber": "+15555550100"},
+	},
 	{
 		(&UserToUpdate{}).DisplayName("a"),
 		map[string]interface{}{"displayName": "a"},
@@ -1083,7 +1423,7 @@ var updateUserCases = []struct {
 					},
 					DisplayName:         "Spouse's phone number",
 					FactorID:            "phone",
-					EnrollmentTimestamp: time.Now().Unix(),
+					EnrollmentTimestamp: 1614776780542,
 				}, {
 					UID: "enrolledSecondFactor2",
 					Phone: &PhoneMultiFactorInfo{
@@ -1107,7 +1447,7 @@ var updateUserCases = []struct {
 				MFAEnrollmentID: "enrolledSecondFactor1",
 				PhoneInfo:       "+11234567890",
 				DisplayName:     "Spouse's phone number",
-				EnrolledAt:      time.Now().Format("2006-01-02T15:04:05Z07:00Z"),
+				EnrolledAt:      "2021-03-03T13:06:20.542Z",
 			},
 			{
 				MFAEnrollmentID: "enrolledSecondFactor2",
@@ -1121,9 +1461,42 @@ var updateUserCases = []struct {
 		}},
 		},
 	},
+	{
+		(&UserToUpdate{}).MFASettings(MultiFactorSettings{
+			EnrolledFactors: []*MultiFactorInfo{
+				{
+					UID:         "enrolledTOTPFactor",
+					DisplayName: "Authenticator app",
+					FactorID:    "totp",
+				}, {
+					DisplayName: "Work phone",
+					FactorID:    "phone",
+					Phone: &PhoneMultiFactorInfo{
+						PhoneNumber: "+11234567890",
+					},
+				},
+			},
+		}),
+		map[string]interface{}{"mfa": multiFactorEnrollments{Enrollments: []*multiFactorInfoResponse{
+			{
+				MFAEnrollmentID: "enrolledTOTPFactor",
+				DisplayName:     "Authenticator app",
+				TOTPInfo:        &TOTPInfo{},
+			},
+			{
+				DisplayName: "Work phone",
+				PhoneInfo:   "+11234567890",
+			},
+		}},
+		},
+	},
 	{
 		(&UserToUpdate{}).MFASettings(MultiFactorSettings{}),
-		map[string]interface{}{"mfa": multiFactorEnrollments{Enrollments: nil}},
+		map[string]interface{}{"mfa": map[string]interface{}{}},
+	},
+	{
+		(&UserToUpdate{}).MFASettings(MultiFactorSettings{EnrolledFactors: []*MultiFactorInfo{}}),
+		map[string]interface{}{"mfa": map[string]interface{}{}},
 	},
 	{
 		(&UserToUpdate{}).ProviderToLink(&UserProvider{
@@ -1579,7 +1952,7 @@ func TestUserToImportError(t *testing.T) {
 		},
 		{
 			(&UserToImport{}).UID("test").PhoneNumber("not-a-phone"),
-			"phone number must be a valid, E.164 compliant identifier",
+			`phone number must be a valid, E.164 compliant identifier: "not-a-phone" must start with a "+" followed by the country code`,
 		},
 		{
 			(&UserToImport{}).UID("test").CustomClaims(map[string]interface{}{"key": strings.Repeat("a", 1000)}),
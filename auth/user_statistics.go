@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/api/iterator"
+)
+
+// UserStatistics contains aggregate counts of the users of a project or tenant.
+type UserStatistics struct {
+	// TotalUsers is the number of user accounts.
+	TotalUsers int
+
+	// DisabledUsers is the number of disabled user accounts.
+	DisabledUsers int
+
+	// EmailVerifiedUsers is the number of users whose email address has been verified.
+	EmailVerifiedUsers int
+
+	// MultiFactorUsers is the number of users with at least one enrolled second factor.
+	MultiFactorUsers int
+
+	// UsersByProvider maps each provider ID (e.g. "password", "google.com" or "phone") to the
+	// number of users linked to that provider. A user linked to several providers is counted once
+	// for each of them.
+	UsersByProvider map[string]int
+
+	// UsersWithoutProvider is the number of users that are not linked to any provider, such as
+	// anonymous users and users that only sign in with custom tokens.
+	UsersWithoutProvider int
+}
+
+// UserStatistics computes aggregate counts of all the users, for dashboards and capacity reviews.
+//
+// The users are listed in the same way as Users, which requires one request for every 1000 users.
+// The next page of users is fetched while the current page is being aggregated. The counts are a
+// snapshot as of the time each page is fetched, and may not reflect changes made while the users
+// are being listed.
+func (c *baseClient) UserStatistics(ctx context.Context) (*UserStatistics, error) {
+	type page struct {
+		users []*ExportedUserRecord
+		err   error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pages := make(chan page, 1)
+	go func() {
+		defer close(pages)
+		pager := iterator.NewPager(c.Users(ctx, ""), maxReturnedResults, "")
+		for {
+			var users []*ExportedUserRecord
+			token, err := pager.NextPage(&users)
+			select {
+			case pages <- page{users, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil || token == "" {
+				return
+			}
+		}
+	}()
+
+	stats := &UserStatistics{UsersByProvider: make(map[string]int)}
+	for p := range pages {
+		if p.err != nil {
+			return nil, p.err
+		}
+		for _, u := range p.users {
+			stats.add(u.UserRecord)
+		}
+	}
+	return stats, nil
+}
+
+func (s *UserStatistics) add(u *UserRecord) {
+	s.TotalUsers++
+	if u.Disabled {
+		s.DisabledUsers++
+	}
+	if u.EmailVerified {
+		s.EmailVerifiedUsers++
+	}
+	if u.MultiFactor != nil && len(u.MultiFactor.EnrolledFactors) > 0 {
+		s.MultiFactorUsers++
+	}
+	if len(u.ProviderUserInfo) == 0 {
+		s.UsersWithoutProvider++
+	}
+	for _, p := range u.ProviderUserInfo {
+		s.UsersByProvider[p.ProviderID]++
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"firebase.google.com/go/v4/errorutils"
+)
+
+func TestUserStatistics(t *testing.T) {
+	resp, err := ioutil.ReadFile("../testdata/list_users.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := echoServer(resp, t)
+	defer s.Close()
+
+	stats, err := s.Client.UserStatistics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &UserStatistics{
+		TotalUsers:         3,
+		EmailVerifiedUsers: 3,
+		MultiFactorUsers:   2,
+		UsersByProvider: map[string]int{
+			"password": 3,
+			"phone":    3,
+		},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("UserStatistics() = %#v; want = %#v", stats, want)
+	}
+}
+
+func TestUserStatisticsMultiplePages(t *testing.T) {
+	pages := map[string]string{
+		"": `{"users": [
+			{"localId": "user1", "disabled": true, "providerUserInfo": [{"providerId": "google.com"}]},
+			{"localId": "user2"}
+		], "nextPageToken": "page2"}`,
+		"page2": `{"users": [
+			{"localId": "user3", "emailVerified": true,
+				"providerUserInfo": [{"providerId": "google.com"}, {"providerId": "password"}],
+				"mfaInfo": [{"mfaEnrollmentId": "enrolledPhoneFactor", "phoneInfo": "+11234567890"}]}
+		]}`,
+	}
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("nextPageToken")
+		tokens = append(tokens, token)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, pages[token])
+	}))
+	defer ts.Close()
+	s := echoServer(nil, t)
+	defer s.Close()
+	s.Client.userManagementEndpoint = ts.URL
+
+	stats, err := s.Client.UserStatistics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &UserStatistics{
+		TotalUsers:         3,
+		DisabledUsers:      1,
+		EmailVerifiedUsers: 1,
+		MultiFactorUsers:   1,
+		UsersByProvider: map[string]int{
+			"google.com": 2,
+			"password":   1,
+		},
+		UsersWithoutProvider: 1,
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("UserStatistics() = %#v; want = %#v", stats, want)
+	}
+	if !reflect.DeepEqual(tokens, []string{"", "page2"}) {
+		t.Errorf("UserStatistics() page tokens = %v; want = [\"\" \"page2\"]", tokens)
+	}
+}
+
+func TestUserStatisticsError(t *testing.T) {
+	s := echoServer([]byte(`{"error": {"message": "INSUFFICIENT_PERMISSION"}}`), t)
+	defer s.Close()
+	s.Status = http.StatusForbidden
+
+	stats, err := s.Client.UserStatistics(context.Background())
+	if stats != nil || err == nil {
+		t.Fatalf("UserStatistics() = (%v, %v); want = (nil, error)", stats, err)
+	}
+	if !errorutils.IsPermissionDenied(err) {
+		t.Errorf("UserStatistics() err = %v; want = permission denied error", err)
+	}
+}
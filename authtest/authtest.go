@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authtest provides helpers for end-to-end tests of code that uses Firebase
+// Authentication.
+//
+// SignInWithCustomToken plays the role of a client app, by exchanging a custom token minted with
+// auth.Client.CustomToken for an ID token, which can then be passed to the code under test:
+//
+//	token, err := client.CustomToken(ctx, "test-user")
+//	// Handle error...
+//	idToken, err := authtest.SignInWithCustomToken(ctx, apiKey, token, "")
+//	// Handle error...
+//	verified, err := client.VerifyIDToken(ctx, idToken)
+//
+// The token is exchanged with the Auth emulator if the FIREBASE_AUTH_EMULATOR_HOST environment
+// variable is set, and with the Firebase Auth service otherwise.
+package authtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+)
+
+const (
+	identityToolkitURL   = "https://identitytoolkit.googleapis.com"
+	emulatorHostEnvVar   = "FIREBASE_AUTH_EMULATOR_HOST"
+	signInWithCustomPath = "/v1/accounts:signInWithCustomToken?key=%s"
+)
+
+// SignInWithCustomToken exchanges a custom token for a Firebase ID token.
+//
+// The custom token is sent to the Identity Toolkit signInWithCustomToken endpoint of the
+// project associated with the given Web API key. If the FIREBASE_AUTH_EMULATOR_HOST environment
+// variable is set, the request is sent to the Auth emulator instead, in which case any non-empty
+// API key is accepted. If tenantID is not empty, the user is signed into the specified tenant.
+func SignInWithCustomToken(ctx context.Context, apiKey, token, tenantID string) (string, error) {
+	payload := map[string]interface{}{
+		"token":             token,
+		"returnSecureToken": true,
+	}
+	if tenantID != "" {
+		payload["tenantId"] = tenantID
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := identityToolkitURL
+	if host := os.Getenv(emulatorHostEnvVar); host != "" {
+		baseURL = fmt.Sprintf("http://%s/identitytoolkit.googleapis.com", host)
+	}
+
+	url := baseURL + fmt.Sprintf(signInWithCustomPath, apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(b))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected http status code: %d; body: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		IDToken string `json:"idToken"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	return result.IDToken, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSignInWithCustomToken(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wantPath := "/identitytoolkit.googleapis.com/v1/accounts:signInWithCustomToken"
+		if r.Method != http.MethodPost || r.URL.Path != wantPath || r.URL.Query().Get("key") != "test-api-key" {
+			t.Errorf("Request = %s %s; want = POST %s?key=test-api-key", r.Method, r.URL, wantPath)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"idToken": "test.id.token", "refreshToken": "refresh"}`))
+	}))
+	defer srv.Close()
+	t.Setenv(emulatorHostEnvVar, strings.TrimPrefix(srv.URL, "http://"))
+
+	cases := []struct {
+		tenantID string
+		want     map[string]interface{}
+	}{
+		{"", map[string]interface{}{"token": "custom.token", "returnSecureToken": true}},
+		{"tenant-1", map[string]interface{}{"token": "custom.token", "returnSecureToken": true, "tenantId": "tenant-1"}},
+	}
+	for _, tc := range cases {
+		idToken, err := SignInWithCustomToken(context.Background(), "test-api-key", "custom.token", tc.tenantID)
+		if idToken != "test.id.token" || err != nil {
+			t.Errorf("SignInWithCustomToken(%q) = (%q, %v); want = (%q, nil)", tc.tenantID, idToken, err, "test.id.token")
+		}
+		if !reflect.DeepEqual(body, tc.want) {
+			t.Errorf("SignInWithCustomToken(%q) body = %v; want = %v", tc.tenantID, body, tc.want)
+		}
+	}
+}
+
+func TestSignInWithCustomTokenError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"message": "INVALID_CUSTOM_TOKEN"}}`))
+	}))
+	defer srv.Close()
+	t.Setenv(emulatorHostEnvVar, strings.TrimPrefix(srv.URL, "http://"))
+
+	idToken, err := SignInWithCustomToken(context.Background(), "test-api-key", "invalid", "")
+	if idToken != "" || err == nil || !strings.Contains(err.Error(), "INVALID_CUSTOM_TOKEN") {
+		t.Errorf("SignInWithCustomToken() = (%q, %v); want = error", idToken, err)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultChunkMaxPaths    = 500
+	defaultChunkMaxBytes    = 1 << 20
+	defaultChunkConcurrency = 4
+)
+
+// ChunkedUpdateOptions configures how UpdateChunked splits and applies an update.
+type ChunkedUpdateOptions struct {
+	// MaxPaths is the maximum number of paths written by a single request. If zero, at most 500
+	// paths are written per request.
+	MaxPaths int
+
+	// MaxBytes is the maximum size of the JSON payload of a single request. If zero, payloads are
+	// limited to 1 MiB. A single path whose value exceeds the limit is rejected.
+	MaxBytes int
+
+	// MaxConcurrency is the maximum number of requests in flight at any time. If zero, at most 4
+	// requests are sent concurrently.
+	MaxConcurrency int
+}
+
+// ChunkedUpdateResult describes the outcome of UpdateChunked.
+type ChunkedUpdateResult struct {
+	// SuccessCount is the number of paths that were written.
+	SuccessCount int
+
+	// FailureCount is the number of paths that could not be written.
+	FailureCount int
+
+	// Failures contains an entry for each request that failed.
+	Failures []*ChunkFailure
+}
+
+// ChunkFailure describes a request of UpdateChunked that failed.
+type ChunkFailure struct {
+	// Updates are the path-value pairs that could not be written.
+	Updates map[string]interface{}
+
+	// Err is the error returned by the request.
+	Err error
+}
+
+type chunkEntry struct {
+	path string
+	raw  json.RawMessage
+}
+
+// UpdateChunked modifies the specified descendants of the current location to the provided
+// values, splitting the update into multiple requests.
+//
+// Unlike Update, UpdateChunked accepts any number of paths. The paths are relative to the current
+// location, and may point to nested descendants (e.g. "users/alice/name"). A path must not be
+// an ancestor of another path in the same update. The paths are split into requests that are
+// bounded in the number of paths and payload size, and the requests are sent concurrently.
+//
+// The update is not atomic: each request is applied independently, and in no particular order.
+// An error is returned if the update is invalid, in which case nothing is written. Otherwise, the
+// paths that could not be written are reported in the returned ChunkedUpdateResult.
+func (r *Ref) UpdateChunked(
+	ctx context.Context, v map[string]interface{}, opts *ChunkedUpdateOptions) (*ChunkedUpdateResult, error) {
+	conf := ChunkedUpdateOptions{}
+	if opts != nil {
+		conf = *opts
+	}
+	if conf.MaxPaths < 0 || conf.MaxBytes < 0 || conf.MaxConcurrency < 0 {
+		return nil, errors.New("chunked update options must not be negative")
+	}
+	if conf.MaxPaths == 0 {
+		conf.MaxPaths = defaultChunkMaxPaths
+	}
+	if conf.MaxBytes == 0 {
+		conf.MaxBytes = defaultChunkMaxBytes
+	}
+	if conf.MaxConcurrency == 0 {
+		conf.MaxConcurrency = defaultChunkConcurrency
+	}
+
+	entries, err := newChunkEntries(v)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := splitChunks(entries, conf.MaxPaths, conf.MaxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChunkedUpdateResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, conf.MaxConcurrency)
+	for _, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(updates map[string]interface{}) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := ctx.Err()
+			if err == nil {
+				err = r.Update(ctx, updates)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.FailureCount += len(updates)
+				result.Failures = append(result.Failures, &ChunkFailure{Updates: updates, Err: err})
+			} else {
+				result.SuccessCount += len(updates)
+			}
+		}(chunk)
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// newChunkEntries validates and serializes the given updates, and returns them sorted by path.
+func newChunkEntries(v map[string]interface{}) ([]*chunkEntry, error) {
+	if len(v) == 0 {
+		return nil, errors.New("value argument must be a non-empty map")
+	}
+
+	var entries []*chunkEntry
+	paths := make(map[string]string, len(v))
+	for path, val := range v {
+		segs := parsePath(path)
+		if len(segs) == 0 {
+			return nil, errors.New("path must not be empty")
+		}
+		if strings.ContainsAny(path, invalidChars) {
+			return nil, fmt.Errorf("invalid path with illegal characters: %q", path)
+		}
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value at path %q: %v", path, err)
+		}
+		key := strings.Join(segs, "/")
+		if other, ok := paths[key]; ok {
+			return nil, fmt.Errorf("path %q collides with path %q", path, other)
+		}
+		paths[key] = path
+		entries = append(entries, &chunkEntry{key, raw})
+	}
+
+	for _, e := range entries {
+		segs := strings.Split(e.path, "/")
+		for i := 1; i < len(segs); i++ {
+			if other, ok := paths[strings.Join(segs[:i], "/")]; ok {
+				return nil, fmt.Errorf("path %q collides with path %q", paths[e.path], other)
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].path < entries[j].path
+	})
+	return entries, nil
+}
+
+// splitChunks groups the entries into updates of at most maxPaths paths and maxBytes bytes.
+func splitChunks(entries []*chunkEntry, maxPaths, maxBytes int) ([]map[string]interface{}, error) {
+	var chunks []map[string]interface{}
+	var curr map[string]interface{}
+	var size int
+	for _, e := range entries {
+		// Each entry is serialized as "path":value, followed by a comma or a closing brace.
+		key, _ := json.Marshal(e.path)
+		n := len(key) + len(e.raw) + 2
+		if n+1 > maxBytes {
+			return nil, fmt.Errorf("value at path %q exceeds the maximum payload size", e.path)
+		}
+		if curr == nil || len(curr) == maxPaths || size+n > maxBytes {
+			curr = make(map[string]interface{})
+			chunks = append(chunks, curr)
+			size = 1
+		}
+		curr[e.path] = e.raw
+		size += n
+	}
+	return chunks, nil
+}
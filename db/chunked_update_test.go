@@ -0,0 +1,168 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUpdateChunked(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	updates := make(map[string]interface{})
+	for i := 0; i < 25; i++ {
+		updates[fmt.Sprintf("users/user%02d/score", i)] = i
+	}
+	result, err := testref.UpdateChunked(context.Background(), updates, &ChunkedUpdateOptions{
+		MaxPaths:       10,
+		MaxConcurrency: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SuccessCount != 25 || result.FailureCount != 0 || len(result.Failures) != 0 {
+		t.Errorf("UpdateChunked() = %+v; want = 25 successes", result)
+	}
+
+	if len(mock.Reqs) != 3 {
+		t.Fatalf("UpdateChunked() = %d requests; want = 3", len(mock.Reqs))
+	}
+	got := make(map[string]interface{})
+	for i, req := range mock.Reqs {
+		if req.Method != "PATCH" || req.Path != "/peter.json" {
+			t.Errorf("Request(%d) = %s %s; want = PATCH /peter.json", i, req.Method, req.Path)
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal(req.Body, &body); err != nil {
+			t.Fatal(err)
+		}
+		if want := []int{10, 10, 5}[i]; len(body) != want {
+			t.Errorf("Request(%d) = %d paths; want = %d", i, len(body), want)
+		}
+		for k, v := range body {
+			got[k] = int(v.(float64))
+		}
+	}
+	if !reflect.DeepEqual(got, updates) {
+		t.Errorf("UpdateChunked() = %v; want = %v", got, updates)
+	}
+}
+
+func TestUpdateChunkedMaxBytes(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	value := strings.Repeat("x", 100)
+	updates := map[string]interface{}{"a": value, "b": value, "c": value}
+	result, err := testref.UpdateChunked(context.Background(), updates, &ChunkedUpdateOptions{
+		MaxBytes: 250,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SuccessCount != 3 || len(mock.Reqs) != 2 {
+		t.Errorf("UpdateChunked() = (%+v, %d requests); want = (3 successes, 2 requests)", result, len(mock.Reqs))
+	}
+	for _, req := range mock.Reqs {
+		if len(req.Body) > 250 {
+			t.Errorf("UpdateChunked() = %d bytes; want <= 250", len(req.Body))
+		}
+	}
+
+	if _, err := testref.UpdateChunked(context.Background(), updates, &ChunkedUpdateOptions{
+		MaxBytes: 100,
+	}); err == nil {
+		t.Errorf("UpdateChunked() = nil; want = error")
+	}
+}
+
+func TestUpdateChunkedPartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	var count int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["b"]; ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": "test error"}`))
+			return
+		}
+		mu.Lock()
+		count += len(body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+
+	updates := map[string]interface{}{"a": 1, "b": 2, "c": 3}
+	result, err := testref.UpdateChunked(context.Background(), updates, &ChunkedUpdateOptions{
+		MaxPaths: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SuccessCount != 2 || result.FailureCount != 1 || count != 2 {
+		t.Errorf("UpdateChunked() = %+v; want = 2 successes and 1 failure", result)
+	}
+	if len(result.Failures) != 1 {
+		t.Fatalf("Failures = %d; want = 1", len(result.Failures))
+	}
+	f := result.Failures[0]
+	want := "http error status: 500; reason: test error"
+	if _, ok := f.Updates["b"]; !ok || len(f.Updates) != 1 || f.Err == nil || f.Err.Error() != want {
+		t.Errorf("Failures[0] = (%v, %v); want = (b, %q)", f.Updates, f.Err, want)
+	}
+}
+
+func TestUpdateChunkedInvalid(t *testing.T) {
+	cases := []struct {
+		updates map[string]interface{}
+		opts    *ChunkedUpdateOptions
+	}{
+		{updates: nil},
+		{updates: map[string]interface{}{}},
+		{updates: map[string]interface{}{"": 1}},
+		{updates: map[string]interface{}{"a.b": 1}},
+		{updates: map[string]interface{}{"a": func() {}}},
+		{updates: map[string]interface{}{"a/b": 1, "/a/b/": 2}},
+		{updates: map[string]interface{}{"a": 1, "a-b": 2, "a/c": 3}},
+		{updates: map[string]interface{}{"a": 1}, opts: &ChunkedUpdateOptions{MaxPaths: -1}},
+		{updates: map[string]interface{}{"a": 1}, opts: &ChunkedUpdateOptions{MaxConcurrency: -1}},
+	}
+	for _, tc := range cases {
+		mock := &mockServer{}
+		srv := mock.Start(client)
+		result, err := testref.UpdateChunked(context.Background(), tc.updates, tc.opts)
+		if result != nil || err == nil {
+			t.Errorf("UpdateChunked(%v) = (%v, %v); want = (nil, error)", tc.updates, result, err)
+		}
+		if len(mock.Reqs) != 0 {
+			t.Errorf("UpdateChunked(%v) = %d requests; want = 0", tc.updates, len(mock.Reqs))
+		}
+		srv.Close()
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"runtime"
@@ -36,6 +38,16 @@ const authVarOverride = "auth_variable_override"
 const emulatorDatabaseEnvVar = "FIREBASE_DATABASE_EMULATOR_HOST"
 const emulatorNamespaceParam = "ns"
 
+const (
+	rtdbErrorCode = "rtdbErrorCode"
+	rtdbErrorPath = "rtdbErrorPath"
+
+	permissionDenied   = "PERMISSION_DENIED"
+	notFound           = "NOT_FOUND"
+	preconditionFailed = "PRECONDITION_FAILED"
+	quotaExceeded      = "QUOTA_EXCEEDED"
+)
+
 // errInvalidURL tells whether the given database url is invalid
 // It is invalid if it is malformed, or not of the format "host:port"
 var errInvalidURL = errors.New("invalid database url")
@@ -48,7 +60,14 @@ var emulatorToken = &oauth2.Token{
 type Client struct {
 	hc           *internal.HTTPClient
 	dbURLConfig  *dbURLConfig
+	readReplica  *dbURLConfig
 	authOverride string
+	hooks        *Hooks
+	decoder      Decoder
+
+	isEmulator         bool
+	databaseID         string
+	managementEndpoint string
 }
 
 type dbURLConfig struct {
@@ -67,10 +86,19 @@ type dbURLConfig struct {
 // This function can only be invoked from within the SDK. Client applications should access the
 // Database service through firebase.App.
 func NewClient(ctx context.Context, c *internal.DatabaseConfig) (*Client, error) {
-	urlConfig, isEmulator, err := parseURLConfig(c.URL)
+	urlConfig, isEmulator, err := parseURLConfig(c.URL, c.EmulatorHost)
 	if err != nil {
 		return nil, err
 	}
+	// The database ID is derived from the database URL, and not from the URL of the proxy.
+	dbID := databaseID(urlConfig)
+	if !isEmulator && c.Endpoint != "" {
+		urlConfig = overrideURLConfig(urlConfig, c.Endpoint)
+	}
+	managementEndpoint := defaultManagementEndpoint
+	if c.ManagementEndpoint != "" {
+		managementEndpoint = c.ManagementEndpoint
+	}
 
 	var ao []byte
 	if c.AuthOverride == nil || len(c.AuthOverride) > 0 {
@@ -93,10 +121,14 @@ func NewClient(ctx context.Context, c *internal.DatabaseConfig) (*Client, error)
 	}
 
 	hc.CreateErrFn = handleRTDBError
+	hc.MetricsFn = c.MetricsFn
 	return &Client{
-		hc:           hc,
-		dbURLConfig:  urlConfig,
-		authOverride: string(ao),
+		hc:                 hc,
+		dbURLConfig:        urlConfig,
+		authOverride:       string(ao),
+		isEmulator:         isEmulator,
+		databaseID:         dbID,
+		managementEndpoint: fmt.Sprintf("%s/%s", managementEndpoint, managementAPIVersion),
 	}, nil
 }
 
@@ -118,19 +150,62 @@ func (c *Client) NewRef(path string) *Ref {
 
 func (c *Client) sendAndUnmarshal(
 	ctx context.Context, req *internal.Request, v interface{}) (*internal.Response, error) {
-	if strings.ContainsAny(req.URL, invalidChars) {
-		return nil, fmt.Errorf("invalid path with illegal characters: %q", req.URL)
+	return c.sendWithHooks(ctx, c.dbURLConfig, req, v)
+}
+
+func (c *Client) send(
+	ctx context.Context, urlConfig *dbURLConfig, req *internal.Request, v interface{}) (*internal.Response, error) {
+	path, err := c.resolve(urlConfig, req)
+	if err != nil {
+		return nil, err
 	}
 
-	req.URL = fmt.Sprintf("%s%s.json", c.dbURLConfig.BaseURL, req.URL)
+	resp, err := c.hc.Do(ctx, req)
+	if err != nil {
+		setErrorPath(err, path)
+		return nil, err
+	}
+
+	if v != nil {
+		if err := c.decoderFor(ctx).Unmarshal(resp.Body, v); err != nil {
+			return nil, fmt.Errorf("error while parsing response: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+// resolve replaces the database path in the URL of the request with the full URL of that path,
+// and adds the query parameters sent with every request. It returns the database path.
+func (c *Client) resolve(urlConfig *dbURLConfig, req *internal.Request) (string, error) {
+	path := req.URL
+	if strings.ContainsAny(path, invalidChars) {
+		return "", fmt.Errorf("invalid path with illegal characters: %q", path)
+	}
+
+	req.URL = fmt.Sprintf("%s%s.json", urlConfig.BaseURL, path)
 	if c.authOverride != "" {
 		req.Opts = append(req.Opts, internal.WithQueryParam(authVarOverride, c.authOverride))
 	}
+	if urlConfig.Namespace != "" {
+		req.Opts = append(req.Opts, internal.WithQueryParam(emulatorNamespaceParam, urlConfig.Namespace))
+	}
+	return path, nil
+}
+
+// resolveSettings sets the URL of a request for a settings path of the database, such as
+// /.settings/rules. Unlike data paths, settings paths contain illegal characters, and requests for
+// them are not subject to the auth variable override.
+func (c *Client) resolveSettings(req *internal.Request) {
+	req.URL = fmt.Sprintf("%s%s.json", c.dbURLConfig.BaseURL, req.URL)
 	if c.dbURLConfig.Namespace != "" {
 		req.Opts = append(req.Opts, internal.WithQueryParam(emulatorNamespaceParam, c.dbURLConfig.Namespace))
 	}
+}
 
-	return c.hc.DoAndUnmarshal(ctx, req, v)
+func setErrorPath(err error, path string) {
+	if fe, ok := err.(*internal.FirebaseError); ok {
+		fe.Ext[rtdbErrorPath] = path
+	}
 }
 
 func parsePath(path string) []string {
@@ -153,9 +228,68 @@ func handleRTDBError(resp *internal.Response) error {
 		err.String = fmt.Sprintf("http error status: %d; reason: %s", resp.Status, p.Error)
 	}
 
+	switch resp.Status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		err.Ext[rtdbErrorCode] = permissionDenied
+	case http.StatusNotFound:
+		err.Ext[rtdbErrorCode] = notFound
+	case http.StatusPreconditionFailed:
+		err.ErrorCode = internal.FailedPrecondition
+		err.Ext[rtdbErrorCode] = preconditionFailed
+	case http.StatusTooManyRequests:
+		err.Ext[rtdbErrorCode] = quotaExceeded
+	}
+
 	return err
 }
 
+// IsPermissionDenied checks if the given error was due to the security rules of the database,
+// or the credentials of the client, not permitting the operation.
+func IsPermissionDenied(err error) bool {
+	return hasRTDBErrorCode(err, permissionDenied)
+}
+
+// IsNotFound checks if the given error was due to the database, or the specified path, not
+// being found.
+func IsNotFound(err error) bool {
+	return hasRTDBErrorCode(err, notFound)
+}
+
+// IsPreconditionFailed checks if the given error was due to a conditional request, such as
+// SetIfUnchanged or a transaction, failing because the data at the path has changed.
+func IsPreconditionFailed(err error) bool {
+	return hasRTDBErrorCode(err, preconditionFailed)
+}
+
+// IsQuotaExceeded checks if the given error was due to the database exceeding a usage quota or
+// rate limit.
+func IsQuotaExceeded(err error) bool {
+	return hasRTDBErrorCode(err, quotaExceeded)
+}
+
+// ErrorPath returns the database path of the request that caused the given error.
+//
+// Returns an empty string if the error was not caused by a database request.
+func ErrorPath(err error) string {
+	fe, ok := err.(*internal.FirebaseError)
+	if !ok {
+		return ""
+	}
+
+	path, _ := fe.Ext[rtdbErrorPath].(string)
+	return path
+}
+
+func hasRTDBErrorCode(err error, code string) bool {
+	fe, ok := err.(*internal.FirebaseError)
+	if !ok {
+		return false
+	}
+
+	got, ok := fe.Ext[rtdbErrorCode]
+	return ok && got == code
+}
+
 // parseURLConfig returns the dbURLConfig for the database
 // dbURL may be either:
 //   - a production url (https://foo-bar.firebaseio.com/)
@@ -163,22 +297,35 @@ func handleRTDBError(resp *internal.Response) error {
 //
 // The following rules will apply for determining the output:
 //   - If the url does not use an https scheme it will be assumed to be an emulator url and be used.
+//   - else If an emulator host is configured on the App it will be used.
 //   - else If the FIREBASE_DATABASE_EMULATOR_HOST environment variable is set it will be used.
 //   - else the url will be assumed to be a production url and be used.
-func parseURLConfig(dbURL string) (*dbURLConfig, bool, error) {
+func parseURLConfig(dbURL, emulatorHost string) (*dbURLConfig, bool, error) {
 	parsedURL, err := url.ParseRequestURI(dbURL)
 	if err == nil && parsedURL.Scheme != "https" {
-		cfg, err := parseEmulatorHost(dbURL, parsedURL)
+		cfg, err := parseEmulatorHost(dbURL, parsedURL, "")
 		return cfg, true, err
 	}
 
-	environmentEmulatorURL := os.Getenv(emulatorDatabaseEnvVar)
-	if environmentEmulatorURL != "" {
-		parsedURL, err = url.ParseRequestURI(environmentEmulatorURL)
+	emulatorURL := emulatorHost
+	if emulatorURL == "" {
+		emulatorURL = os.Getenv(emulatorDatabaseEnvVar)
+	}
+	if emulatorURL != "" {
+		parsedURL, err = url.ParseRequestURI(emulatorURL)
+		if err != nil {
+			// Hosts that start with an IP address (127.0.0.1:9000) are not valid request URIs.
+			parsedURL, err = url.Parse("//" + emulatorURL)
+		}
 		if err != nil {
-			return nil, false, fmt.Errorf("%s: %w", environmentEmulatorURL, errInvalidURL)
+			return nil, false, fmt.Errorf("%s: %w", emulatorURL, errInvalidURL)
 		}
-		cfg, err := parseEmulatorHost(environmentEmulatorURL, parsedURL)
+		// The emulator serves the database named in the URL, unless the host specifies one.
+		var defaultNamespace string
+		if u, err := url.ParseRequestURI(dbURL); err == nil && u.Scheme == "https" {
+			defaultNamespace = strings.Split(u.Hostname(), ".")[0]
+		}
+		cfg, err := parseEmulatorHost(emulatorURL, parsedURL, defaultNamespace)
 		return cfg, true, err
 	}
 
@@ -192,7 +339,17 @@ func parseURLConfig(dbURL string) (*dbURLConfig, bool, error) {
 	}, false, nil
 }
 
-func parseEmulatorHost(rawEmulatorHostURL string, parsedEmulatorHost *url.URL) (*dbURLConfig, error) {
+// overrideURLConfig routes the requests for a database through the given base URL. The
+// database is then identified by the namespace query parameter, as with the emulator.
+func overrideURLConfig(cfg *dbURLConfig, endpoint string) *dbURLConfig {
+	u, _ := url.Parse(cfg.BaseURL)
+	return &dbURLConfig{
+		BaseURL:   endpoint,
+		Namespace: strings.Split(u.Hostname(), ".")[0],
+	}
+}
+
+func parseEmulatorHost(rawEmulatorHostURL string, parsedEmulatorHost *url.URL, defaultNamespace string) (*dbURLConfig, error) {
 	if strings.Contains(rawEmulatorHostURL, "//") {
 		return nil, fmt.Errorf(`invalid %s: "%s". It must follow format "host:port": %w`, emulatorDatabaseEnvVar, rawEmulatorHostURL, errInvalidURL)
 	}
@@ -204,9 +361,13 @@ func parseEmulatorHost(rawEmulatorHostURL string, parsedEmulatorHost *url.URL) (
 
 	namespace := parsedEmulatorHost.Query().Get(emulatorNamespaceParam)
 	if namespace == "" {
-		if strings.Contains(rawEmulatorHostURL, ".") {
+		// The primary domain of an IP address (127.0.0.1:9000) is not a database name.
+		if strings.Contains(rawEmulatorHostURL, ".") && net.ParseIP(parsedEmulatorHost.Hostname()) == nil {
 			namespace = strings.Split(rawEmulatorHostURL, ".")[0]
 		}
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
 		if namespace == "" {
 			return nil, fmt.Errorf(`invalid database URL: "%s". Database URL must be a valid URL to a Firebase Realtime Database instance (include ?ns=<db-name> query param)`, parsedEmulatorHost)
 		}
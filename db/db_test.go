@@ -94,6 +94,7 @@ func TestNewClient(t *testing.T) {
 		Name              string
 		URL               string
 		EnvURL            string
+		EmulatorHost      string
 		ExpectedBaseURL   string
 		ExpectedNamespace string
 		ExpectError       bool
@@ -103,6 +104,12 @@ func TestNewClient(t *testing.T) {
 		{Name: "emulator - missing namespace should error", URL: "localhost:9000", ExpectError: true},
 		{Name: "emulator - if url contains hostname it uses the primary domain", URL: "rtdb-go.emulator:9000", ExpectedBaseURL: "http://rtdb-go.emulator:9000", ExpectedNamespace: "rtdb-go"},
 		{Name: "emulator env - success", EnvURL: testEmulatorURL, ExpectedBaseURL: testEmulatorBaseURL, ExpectedNamespace: testEmulatorNamespace},
+		{Name: "emulator host - success", URL: testURL, EmulatorHost: testEmulatorURL, ExpectedBaseURL: testEmulatorBaseURL, ExpectedNamespace: testEmulatorNamespace},
+		{Name: "emulator host - ip address", URL: testURL, EmulatorHost: "127.0.0.1:9000?ns=test-db", ExpectedBaseURL: "http://127.0.0.1:9000", ExpectedNamespace: testEmulatorNamespace},
+		{Name: "emulator host - ip address uses the database url", URL: testURL, EmulatorHost: "127.0.0.1:9000", ExpectedBaseURL: "http://127.0.0.1:9000", ExpectedNamespace: "test-db"},
+		{Name: "emulator host - ip address without namespace should error", EmulatorHost: "127.0.0.1:9000", ExpectError: true},
+		{Name: "emulator env - ip address uses the database url", URL: testURL, EnvURL: "127.0.0.1:9000", ExpectedBaseURL: "http://127.0.0.1:9000", ExpectedNamespace: "test-db"},
+		{Name: "emulator host - overrides env", EnvURL: "localhost:9001?ns=other-db", EmulatorHost: testEmulatorURL, ExpectedBaseURL: testEmulatorBaseURL, ExpectedNamespace: testEmulatorNamespace},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -113,6 +120,7 @@ func TestNewClient(t *testing.T) {
 				Opts:         testOpts,
 				URL:          tc.URL,
 				AuthOverride: make(map[string]interface{}),
+				EmulatorHost: tc.EmulatorHost,
 			})
 			if err != nil && tc.ExpectError {
 				return
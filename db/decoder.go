@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+type decoderKey struct{}
+
+// Decoder decodes the JSON values read from the database into Go values.
+type Decoder interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// NumberDecoder is a Decoder that behaves like the encoding/json package, except that numbers are
+// decoded into json.Number instead of float64 when the destination is an interface{} value.
+//
+// Use NumberDecoder to read integers that cannot be represented exactly as float64, such as large
+// int64 values, into maps and other untyped values.
+var NumberDecoder Decoder = numberDecoder{}
+
+type numberDecoder struct{}
+
+func (numberDecoder) Unmarshal(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// SetDecoder configures the Decoder used to decode the values read from the database.
+//
+// The Decoder applies to Ref.Get, Query.Get and all the other methods that decode database values,
+// including the nodes returned by Query.GetOrdered and Ref.Transaction. The Decoder can be
+// overridden for a specific call via WithDecoder. Passing nil restores the default decoding, which
+// uses the encoding/json package. This method should be called before the client is used
+// concurrently.
+func (c *Client) SetDecoder(d Decoder) {
+	c.decoder = d
+}
+
+// WithDecoder returns a copy of the context that makes the reads performed with it decode values
+// using the given Decoder, instead of the one configured on the client.
+func WithDecoder(ctx context.Context, d Decoder) context.Context {
+	return context.WithValue(ctx, decoderKey{}, d)
+}
+
+// decoderFor returns the Decoder to be used for the reads performed with the given context.
+func (c *Client) decoderFor(ctx context.Context) Decoder {
+	if d, ok := ctx.Value(decoderKey{}).(Decoder); ok && d != nil {
+		return d
+	}
+	if c.decoder != nil {
+		return c.decoder
+	}
+	return defaultDecoder{}
+}
+
+type defaultDecoder struct{}
+
+func (defaultDecoder) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
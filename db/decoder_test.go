@@ -0,0 +1,150 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const largeInt = 9007199254740993
+
+var largeIntResp = json.RawMessage(`{"a": 9007199254740993, "b": 1}`)
+
+func TestSetDecoder(t *testing.T) {
+	mock := &mockServer{Resp: largeIntResp}
+	srv := mock.Start(client)
+	defer srv.Close()
+	client.SetDecoder(NumberDecoder)
+	defer client.SetDecoder(nil)
+
+	var got map[string]interface{}
+	if err := testref.Get(context.Background(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["a"] != json.Number("9007199254740993") {
+		t.Errorf("Get() = %v; want = %d", got["a"], int64(largeInt))
+	}
+}
+
+func TestWithDecoder(t *testing.T) {
+	mock := &mockServer{Resp: largeIntResp}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var got map[string]interface{}
+	ctx := WithDecoder(context.Background(), NumberDecoder)
+	if err := testref.OrderByKey().Get(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["a"] != json.Number("9007199254740993") {
+		t.Errorf("Get() = %v; want = %d", got["a"], int64(largeInt))
+	}
+
+	if err := testref.Get(context.Background(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["a"].(float64); !ok {
+		t.Errorf("Get() = %T; want = float64", got["a"])
+	}
+}
+
+type failingDecoder struct{}
+
+func (failingDecoder) Unmarshal(data []byte, v interface{}) error {
+	return errors.New("decoder error")
+}
+
+func TestDecoderError(t *testing.T) {
+	mock := &mockServer{Resp: largeIntResp}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var got map[string]interface{}
+	ctx := WithDecoder(context.Background(), failingDecoder{})
+	want := "error while parsing response: decoder error"
+	if err := testref.Get(ctx, &got); err == nil || err.Error() != want {
+		t.Errorf("Get() = %v; want = %q", err, want)
+	}
+}
+
+func TestGetOrderedLargeNumbers(t *testing.T) {
+	mock := &mockServer{Resp: largeIntResp}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	result, err := testref.OrderByValue().GetOrdered(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 || result[0].Key() != "b" || result[1].Key() != "a" {
+		t.Fatalf("GetOrdered() = %v; want = [b a]", result)
+	}
+	var got int64
+	if err := result[1].Unmarshal(&got); err != nil || got != largeInt {
+		t.Errorf("Unmarshal() = (%d, %v); want = (%d, nil)", got, err, int64(largeInt))
+	}
+
+	var v interface{}
+	ctx := WithDecoder(context.Background(), NumberDecoder)
+	result, err = testref.OrderByValue().GetOrdered(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result[1].Unmarshal(&v); err != nil || v != json.Number("9007199254740993") {
+		t.Errorf("Unmarshal() = (%v, %v); want = (%d, nil)", v, err, int64(largeInt))
+	}
+}
+
+func TestComparableKeyLargeNumbers(t *testing.T) {
+	cases := []struct {
+		a, b interface{}
+		want int
+	}{
+		{json.Number("9007199254740993"), json.Number("9007199254740992"), 1},
+		{json.Number("-9007199254740993"), json.Number("-9007199254740992"), -1},
+		{json.Number("9007199254740993"), float64(9007199254740992), 1},
+		{json.Number("9007199254740992"), float64(9007199254740992), 0},
+		{json.Number("99999999999999999999"), json.Number("99999999999999999998"), 1},
+		{json.Number("1.5"), json.Number("2"), -1},
+		{2, json.Number("2"), 0},
+		{json.Number("1"), "1", -1},
+	}
+	for _, tc := range cases {
+		got := newComparableKey(tc.a).Compare(newComparableKey(tc.b))
+		if got != tc.want {
+			t.Errorf("Compare(%v, %v) = %d; want = %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestNumberDecoder(t *testing.T) {
+	var v interface{}
+	if err := NumberDecoder.Unmarshal([]byte(`[1.5, 9007199254740993]`), &v); err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{json.Number("1.5"), json.Number("9007199254740993")}
+	if l, ok := v.([]interface{}); !ok || len(l) != 2 || l[0] != want[0] || l[1] != want[1] {
+		t.Errorf("Unmarshal() = %v; want = %v", v, want)
+	}
+
+	for _, data := range []string{``, `{`, `1 2`} {
+		if err := NumberDecoder.Unmarshal([]byte(data), &v); err == nil {
+			t.Errorf("Unmarshal(%q) = nil; want = error", data)
+		}
+	}
+}
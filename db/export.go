@@ -0,0 +1,94 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// tooLargeReason is the error reported by the database when a read exceeds the maximum response
+// size of a single request.
+const tooLargeReason = "exceeds the maximum size"
+
+// Export writes the data at the current database location to w, as JSON.
+//
+// The data is exported with format=export, so that the priorities of the nodes are included in
+// the output. The response is copied to w as it is received, without being held in memory. If the
+// location holds more data than can be downloaded in a single request, Export lists its children
+// with a shallow read, and exports each child separately. The priority of a node that is exported
+// this way is not included in the output.
+//
+// Export is not atomic: when the data is exported in multiple requests, writes made concurrently
+// may be reflected in some children and not others. If Export returns an error, the data written
+// to w is incomplete.
+func (r *Ref) Export(ctx context.Context, w io.Writer) error {
+	body, err := r.client.openBody(ctx, r.Path, map[string]string{"format": "export"}, "application/json")
+	if err == nil {
+		defer body.Close()
+		_, err = io.Copy(w, body)
+		return err
+	}
+	if !isTooLarge(err) {
+		return err
+	}
+
+	var shallow interface{}
+	if err := r.GetShallow(ctx, &shallow); err != nil {
+		return err
+	}
+	children, ok := shallow.(map[string]interface{})
+	if !ok {
+		return err
+	}
+	keys := make([]string, 0, len(children))
+	for k := range children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		name, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			name = append([]byte(","), name...)
+		}
+		if _, err := w.Write(append(name, ':')); err != nil {
+			return err
+		}
+		if err := r.Child(k).Export(ctx, w); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "}")
+	return err
+}
+
+func isTooLarge(err error) bool {
+	var fe *internal.FirebaseError
+	return errors.As(err, &fe) && fe.ErrorCode == internal.InvalidArgument &&
+		strings.Contains(fe.String, tooLargeReason)
+}
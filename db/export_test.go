@@ -0,0 +1,132 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const tooLargeResp = `{"error": "Data requested exceeds the maximum size that can be accessed with a single request."}`
+
+func TestExport(t *testing.T) {
+	resp := `{".priority":1,"name":"Peter Parker"}`
+	mock := &mockServer{Resp: json.RawMessage(resp)}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	if err := testref.Export(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != resp {
+		t.Errorf("Export() = %q; want = %q", buf.String(), resp)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "GET",
+		Path:   "/peter.json",
+		Query:  map[string]string{"format": "export"},
+	})
+}
+
+func TestExportPaginated(t *testing.T) {
+	// Only the leaves of the tree can be read in a single request.
+	data := map[string]interface{}{
+		"peter": map[string]interface{}{
+			"profile": map[string]interface{}{".priority": 2, "name": "Peter Parker"},
+			"friends": map[string]interface{}{"mary": map[string]interface{}{"name": "Mary Jane"}},
+		},
+	}
+	var reqs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r.URL.Path+"?"+r.URL.RawQuery)
+		var node interface{} = data
+		for _, s := range parsePath(strings.TrimSuffix(r.URL.Path, ".json")) {
+			node = node.(map[string]interface{})[s]
+		}
+		m := node.(map[string]interface{})
+		if r.URL.Query().Get("shallow") == "true" {
+			keys := make(map[string]bool)
+			for k := range m {
+				keys[k] = true
+			}
+			json.NewEncoder(w).Encode(keys)
+			return
+		}
+		for _, v := range m {
+			if _, ok := v.(map[string]interface{}); ok {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(tooLargeResp))
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(m)
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+
+	var buf bytes.Buffer
+	if err := testref.Export(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var got interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Export() = %q; want = valid JSON: %v", buf.String(), err)
+	}
+	want := map[string]interface{}{
+		"profile": map[string]interface{}{".priority": float64(2), "name": "Peter Parker"},
+		"friends": map[string]interface{}{"mary": map[string]interface{}{"name": "Mary Jane"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Export() = %v; want = %v", got, want)
+	}
+
+	wantReqs := []string{
+		"/peter.json?format=export",
+		"/peter.json?shallow=true",
+		"/peter/friends.json?format=export",
+		"/peter/friends.json?shallow=true",
+		"/peter/friends/mary.json?format=export",
+		"/peter/profile.json?format=export",
+	}
+	if !reflect.DeepEqual(reqs, wantReqs) {
+		t.Errorf("Export() = %v; want = %v", reqs, wantReqs)
+	}
+}
+
+func TestExportError(t *testing.T) {
+	mock := &mockServer{
+		Resp:   map[string]string{"error": "Permission denied"},
+		Status: http.StatusUnauthorized,
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	err := testref.Export(context.Background(), &buf)
+	want := "http error status: 401; reason: Permission denied"
+	if err == nil || err.Error() != want || !IsPermissionDenied(err) {
+		t.Errorf("Export() = %v; want = %q", err, want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Export() = %q; want = empty", buf.String())
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// Operation is the kind of database operation performed by a request.
+type Operation string
+
+const (
+	// OperationGet reads data, including queries and transaction reads.
+	OperationGet Operation = "get"
+
+	// OperationSet writes data, including conditional and transaction writes.
+	OperationSet Operation = "set"
+
+	// OperationPush creates a child with a generated key.
+	OperationPush Operation = "push"
+
+	// OperationUpdate updates the children of a node.
+	OperationUpdate Operation = "update"
+
+	// OperationDelete deletes data.
+	OperationDelete Operation = "delete"
+)
+
+var operationsByMethod = map[string]Operation{
+	http.MethodGet:    OperationGet,
+	http.MethodPut:    OperationSet,
+	http.MethodPost:   OperationPush,
+	http.MethodPatch:  OperationUpdate,
+	http.MethodDelete: OperationDelete,
+}
+
+// OperationInfo describes a request made to the database.
+type OperationInfo struct {
+	// Path is the database path the request refers to.
+	Path string
+
+	// Operation is the kind of operation performed.
+	Operation Operation
+
+	// PayloadSize is the size in bytes of the data sent with the request.
+	PayloadSize int
+
+	// The following fields are only populated when the After hook is called.
+
+	// ResponseSize is the size in bytes of the response body.
+	ResponseSize int
+
+	// StatusCode is the HTTP status code of the response, or zero if no response was received.
+	StatusCode int
+
+	// Latency is the time taken by the request, including any retries.
+	Latency time.Duration
+
+	// Err is the error the request failed with, if any.
+	Err error
+}
+
+// Hooks are functions called around every request made to the database via a Client, for
+// auditing and metrics.
+type Hooks struct {
+	// Before is called before a request is sent. If it returns an error, the request is not sent
+	// and the operation fails with that error. The After hook is not called in that case.
+	Before func(ctx context.Context, info *OperationInfo) error
+
+	// After is called once a request has completed, successfully or not.
+	After func(ctx context.Context, info *OperationInfo)
+}
+
+// SetHooks configures the hooks called around every request made via the client, including
+// the requests made by Ref, Query, transactions and write batchers. Passing nil removes the hooks.
+//
+// For listeners and exports, the After hook is called once the connection is established, and
+// the response size is zero because the response is still being received.
+//
+// The hooks are called from the goroutine performing the operation, and must be safe for
+// concurrent use. This method should be called before the client is used concurrently.
+func (c *Client) SetHooks(hooks *Hooks) {
+	c.hooks = hooks
+}
+
+// sendWithHooks sends the request via send, calling the hooks of the client around it.
+func (c *Client) sendWithHooks(
+	ctx context.Context, urlConfig *dbURLConfig, req *internal.Request, v interface{}) (*internal.Response, error) {
+	return c.withHooks(ctx, req, func() (*internal.Response, error) {
+		return c.send(ctx, urlConfig, req, v)
+	})
+}
+
+// withHooks calls the hooks of the client around do, which sends the given request.
+func (c *Client) withHooks(
+	ctx context.Context, req *internal.Request, do func() (*internal.Response, error)) (*internal.Response, error) {
+	if c.hooks == nil {
+		return do()
+	}
+
+	info := &OperationInfo{
+		Path:      req.URL,
+		Operation: operationsByMethod[req.Method],
+	}
+	if req.Body != nil {
+		b, err := req.Body.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		info.PayloadSize = len(b)
+		req.Body = &rawJSONEntity{b}
+	}
+
+	if c.hooks.Before != nil {
+		if err := c.hooks.Before(ctx, info); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := do()
+	info.Latency = time.Since(start)
+	info.Err = err
+	if resp != nil {
+		info.ResponseSize = len(resp.Body)
+		info.StatusCode = resp.Status
+	} else if fe, ok := err.(*internal.FirebaseError); ok && fe.Response != nil {
+		info.StatusCode = fe.Response.StatusCode
+	}
+
+	if c.hooks.After != nil {
+		c.hooks.After(ctx, info)
+	}
+	return resp, err
+}
+
+// rawJSONEntity is a request payload that has already been serialized into JSON.
+type rawJSONEntity struct {
+	b []byte
+}
+
+func (e *rawJSONEntity) Bytes() ([]byte, error) {
+	return e.b, nil
+}
+
+func (e *rawJSONEntity) Mime() string {
+	return "application/json"
+}
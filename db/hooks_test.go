@@ -0,0 +1,144 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	mock := &mockServer{Resp: map[string]interface{}{"name": "Peter Parker"}}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var before, after []*OperationInfo
+	client.SetHooks(&Hooks{
+		Before: func(ctx context.Context, info *OperationInfo) error {
+			copied := *info
+			before = append(before, &copied)
+			return nil
+		},
+		After: func(ctx context.Context, info *OperationInfo) {
+			after = append(after, info)
+		},
+	})
+	defer client.SetHooks(nil)
+
+	ctx := context.Background()
+	var got map[string]interface{}
+	if err := testref.Get(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	value := map[string]interface{}{"name": "Peter Parker"}
+	if err := testref.Set(ctx, value); err != nil {
+		t.Fatal(err)
+	}
+	if err := testref.Child("age").Delete(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		path        string
+		op          Operation
+		payloadSize int
+	}{
+		{"/peter", OperationGet, 0},
+		{"/peter", OperationSet, len(serialize(value))},
+		{"/peter/age", OperationDelete, 0},
+	}
+	if len(before) != len(want) || len(after) != len(want) {
+		t.Fatalf("Hooks = (%d, %d); want = (%d, %d)", len(before), len(after), len(want), len(want))
+	}
+	for i, w := range want {
+		for _, info := range []*OperationInfo{before[i], after[i]} {
+			if info.Path != w.path || info.Operation != w.op || info.PayloadSize != w.payloadSize {
+				t.Errorf("Hooks[%d] = (%q, %q, %d); want = (%q, %q, %d)",
+					i, info.Path, info.Operation, info.PayloadSize, w.path, w.op, w.payloadSize)
+			}
+		}
+		if before[i].StatusCode != 0 || before[i].Latency != 0 {
+			t.Errorf("Before[%d] = %#v; want no response fields", i, before[i])
+		}
+		if after[i].Err != nil || after[i].Latency <= 0 {
+			t.Errorf("After[%d] = (%v, %v); want = (nil, > 0)", i, after[i].Err, after[i].Latency)
+		}
+	}
+	if after[0].StatusCode != http.StatusOK || after[0].ResponseSize != len(serialize(value)) {
+		t.Errorf("After[0] = (%d, %d); want = (%d, %d)",
+			after[0].StatusCode, after[0].ResponseSize, http.StatusOK, len(serialize(value)))
+	}
+
+	// The request body is sent as serialized for the hooks.
+	if body := mock.Reqs[1].Body; string(body) != string(serialize(value)) {
+		t.Errorf("Set() Body = %s; want = %s", body, serialize(value))
+	}
+}
+
+func TestHooksError(t *testing.T) {
+	mock := &mockServer{
+		Resp:   map[string]string{"error": "Permission denied"},
+		Status: http.StatusUnauthorized,
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var after *OperationInfo
+	client.SetHooks(&Hooks{
+		After: func(ctx context.Context, info *OperationInfo) {
+			after = info
+		},
+	})
+	defer client.SetHooks(nil)
+
+	err := testref.Update(context.Background(), map[string]interface{}{"age": 18})
+	if err == nil {
+		t.Fatal("Update() = nil; want = error")
+	}
+	if after == nil || after.Err != err || after.StatusCode != http.StatusUnauthorized ||
+		after.Operation != OperationUpdate {
+		t.Errorf("After = %#v; want = update error with status 401", after)
+	}
+}
+
+func TestHooksBeforeAborts(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	denied := errors.New("access denied by audit policy")
+	var afterCalled bool
+	client.SetHooks(&Hooks{
+		Before: func(ctx context.Context, info *OperationInfo) error {
+			return denied
+		},
+		After: func(ctx context.Context, info *OperationInfo) {
+			afterCalled = true
+		},
+	})
+	defer client.SetHooks(nil)
+
+	if err := testref.Set(context.Background(), "value"); err != denied {
+		t.Errorf("Set() = %v; want = %v", err, denied)
+	}
+	if afterCalled {
+		t.Errorf("After called; want not called")
+	}
+	if len(mock.Reqs) != 0 {
+		t.Errorf("Requests = %d; want = 0", len(mock.Reqs))
+	}
+}
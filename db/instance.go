@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	defaultManagementEndpoint = "https://firebasedatabase.googleapis.com"
+	managementAPIVersion      = "v1beta"
+)
+
+// InstanceType is the type of a Realtime Database instance.
+type InstanceType string
+
+const (
+	// DefaultDatabase is the default database instance of a project.
+	DefaultDatabase InstanceType = "DEFAULT_DATABASE"
+
+	// UserDatabase is an additional database instance created by the user.
+	UserDatabase InstanceType = "USER_DATABASE"
+)
+
+// InstanceState is the lifecycle state of a Realtime Database instance.
+type InstanceState string
+
+const (
+	// InstanceActive indicates that the database instance is in use.
+	InstanceActive InstanceState = "ACTIVE"
+
+	// InstanceDisabled indicates that the database instance has been disabled, and can be
+	// re-enabled.
+	InstanceDisabled InstanceState = "DISABLED"
+
+	// InstanceDeleted indicates that the database instance has been deleted.
+	InstanceDeleted InstanceState = "DELETED"
+)
+
+// Instance contains the metadata of a Realtime Database instance, as reported by the Realtime
+// Database management API.
+//
+// Usage metrics such as the number of concurrent connections and storage size are not part of
+// the instance metadata. They are published to Cloud Monitoring, and the operations from which
+// they are derived can be streamed with Client.Profile.
+type Instance struct {
+	// Name is the fully qualified resource name of the instance
+	// (projects/{project-number}/locations/{location-id}/instances/{database-id}).
+	Name string `json:"name"`
+
+	// Project is the number of the project that owns the instance.
+	Project string `json:"project"`
+
+	// DatabaseURL is the URL used to access the instance.
+	DatabaseURL string        `json:"databaseUrl"`
+	Type        InstanceType  `json:"type"`
+	State       InstanceState `json:"state"`
+}
+
+// Instance retrieves the metadata of the database instance this client is connected to.
+//
+// Instance is not supported when the client is connected to the Realtime Database emulator.
+func (c *Client) Instance(ctx context.Context) (*Instance, error) {
+	id, err := c.instanceID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Database IDs are globally unique, so the project and location may be wildcarded.
+	req := &internal.Request{
+		Method:      http.MethodGet,
+		URL:         fmt.Sprintf("%s/projects/-/locations/-/instances/%s", c.managementEndpoint, id),
+		CreateErrFn: handleManagementError,
+	}
+	var result Instance
+	if _, err := c.hc.DoAndUnmarshal(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) instanceID() (string, error) {
+	if c.isEmulator {
+		return "", fmt.Errorf("instance metadata is not available from the emulator")
+	}
+	if c.databaseID == "" {
+		return "", fmt.Errorf("failed to extract database name from url: %q", c.dbURLConfig.BaseURL)
+	}
+	return c.databaseID, nil
+}
+
+// databaseID returns the ID of the database instance identified by the given URL config, which
+// must not have been overridden by an endpoint override. Returns an empty string if the URL does
+// not identify a database.
+func databaseID(cfg *dbURLConfig) string {
+	if cfg.Namespace != "" {
+		return cfg.Namespace
+	}
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return strings.Split(u.Hostname(), ".")[0]
+}
+
+func handleManagementError(resp *internal.Response) error {
+	err := internal.NewFirebaseErrorOnePlatform(resp)
+	switch resp.Status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		err.Ext[rtdbErrorCode] = permissionDenied
+	case http.StatusNotFound:
+		err.Ext[rtdbErrorCode] = notFound
+	case http.StatusTooManyRequests:
+		err.Ext[rtdbErrorCode] = quotaExceeded
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+func TestInstance(t *testing.T) {
+	var tr *testReq
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr, _ = newTestReq(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"name": "projects/123/locations/us-central1/instances/test-db",
+			"project": "projects/123",
+			"databaseUrl": "https://test-db.firebaseio.com",
+			"type": "DEFAULT_DATABASE",
+			"state": "ACTIVE"
+		}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts: testOpts,
+		URL:  "https://test-db.firebaseio.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.managementEndpoint = srv.URL
+
+	got, err := c.Instance(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Instance{
+		Name:        "projects/123/locations/us-central1/instances/test-db",
+		Project:     "projects/123",
+		DatabaseURL: "https://test-db.firebaseio.com",
+		Type:        DefaultDatabase,
+		State:       InstanceActive,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Instance() = %#v; want = %#v", got, want)
+	}
+	if tr.Method != http.MethodGet || tr.Path != "/projects/-/locations/-/instances/test-db" {
+		t.Errorf("Instance() = %s %s; want = GET /projects/-/locations/-/instances/test-db", tr.Method, tr.Path)
+	}
+}
+
+func TestInstanceEndpointOverrides(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "projects/123/locations/us-central1/instances/test-db"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts:               testOpts,
+		URL:                "https://test-db.europe-west1.firebasedatabase.app",
+		Endpoint:           "https://rtdb-proxy.example.com",
+		ManagementEndpoint: srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Instance(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/v1beta/projects/-/locations/-/instances/test-db"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Instance() = %v; want = %v", paths, want)
+	}
+}
+
+func TestInstanceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"status": "NOT_FOUND", "message": "instance not found"}}`))
+	}))
+	defer srv.Close()
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts: testOpts,
+		URL:  "https://test-db.firebaseio.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.managementEndpoint = srv.URL
+
+	instance, err := c.Instance(context.Background())
+	if instance != nil || !IsNotFound(err) || err.Error() != "instance not found" {
+		t.Errorf("Instance() = (%v, %v); want = (nil, %q)", instance, err, "instance not found")
+	}
+}
+
+func TestInstanceEmulator(t *testing.T) {
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts: testOpts,
+		URL:  "localhost:9000?ns=test-db",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if instance, err := c.Instance(context.Background()); instance != nil || err == nil {
+		t.Errorf("Instance() = (%v, %v); want = (nil, error)", instance, err)
+	}
+}
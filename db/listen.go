@@ -0,0 +1,295 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// EventType is the type of an Event received by a listener.
+type EventType string
+
+const (
+	// EventPut indicates that the data at the path of the event was replaced with the data of the
+	// event. The first event received by a listener is a put of the entire data at the location.
+	EventPut EventType = "put"
+
+	// EventPatch indicates that each child of the data of the event replaced the corresponding
+	// child of the path of the event.
+	EventPatch EventType = "patch"
+
+	// EventKeepAlive is sent periodically by the server while the data does not change.
+	EventKeepAlive EventType = "keep-alive"
+
+	// EventCancel indicates that the server stopped sending events, typically because the
+	// security rules no longer allow reading the location. It is the last event of a listener.
+	EventCancel EventType = "cancel"
+
+	// EventAuthRevoked indicates that the credentials of the listener expired. The listener
+	// reconnects with refreshed credentials.
+	EventAuthRevoked EventType = "auth_revoked"
+
+	// EventDisconnected indicates that the connection to the server was lost, and that the
+	// listener is reconnecting. It is generated by the SDK rather than sent by the server. The
+	// listener is connected again once the next EventPut is received.
+	EventDisconnected EventType = "disconnected"
+)
+
+// Event is a change to the data at a location, received by a listener.
+type Event struct {
+	Type EventType
+
+	// Path is the path of the changed data, relative to the location of the listener. It is "/"
+	// for changes to the location itself.
+	Path string
+
+	// Data is the JSON-encoded data of put and patch events.
+	Data []byte
+
+	// Err is the reason a listener was canceled, for cancel events.
+	Err error
+
+	decoder Decoder
+}
+
+// Unmarshal parses the data of the event, and stores it in the value pointed to by v. The data is
+// decoded with the Decoder of the listener, as configured via SetDecoder or WithDecoder.
+func (e *Event) Unmarshal(v interface{}) error {
+	if e.decoder == nil {
+		return json.Unmarshal(e.Data, v)
+	}
+	return e.decoder.Unmarshal(e.Data, v)
+}
+
+// Delays between attempts to reconnect a listener.
+var (
+	listenMinRetryDelay = 500 * time.Millisecond
+	listenMaxRetryDelay = 30 * time.Second
+)
+
+// Listen listens to changes to the data at the current database location, using the streaming
+// protocol of the Realtime Database REST API.
+//
+// The returned channel receives an EventPut with the current data, followed by an event for
+// each change. If the connection is interrupted, the listener sends an EventDisconnected,
+// reconnects with exponential backoff, and resumes with an EventPut of the current data. The
+// channel is closed when the context is done, or after an EventCancel.
+//
+// Listen returns an error if the initial connection fails, for instance because the security rules
+// do not allow reading the location. Callers must receive from the channel until it is closed,
+// or cancel the context.
+func (r *Ref) Listen(ctx context.Context) (<-chan *Event, error) {
+	body, err := r.client.openStream(ctx, r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *Event)
+	go r.listen(ctx, body, events)
+	return events, nil
+}
+
+func (r *Ref) listen(ctx context.Context, body io.ReadCloser, events chan<- *Event) {
+	defer close(events)
+	delay := listenMinRetryDelay
+	for {
+		if body != nil {
+			done := readEvents(ctx, body, events, r.client.decoderFor(ctx))
+			body.Close()
+			if done || ctx.Err() != nil {
+				return
+			}
+			if !sendEvent(ctx, events, &Event{Type: EventDisconnected}) {
+				return
+			}
+			delay = listenMinRetryDelay
+		}
+
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return
+		}
+		delay *= 2
+		if delay > listenMaxRetryDelay {
+			delay = listenMaxRetryDelay
+		}
+
+		var err error
+		body, err = r.client.openStream(ctx, r.Path)
+		if err != nil && isPermanentListenError(err) {
+			sendEvent(ctx, events, &Event{Type: EventCancel, Err: err})
+			return
+		}
+	}
+}
+
+// isPermanentListenError checks if a listener should stop reconnecting after the given error.
+func isPermanentListenError(err error) bool {
+	return IsPermissionDenied(err) || IsNotFound(err)
+}
+
+// readEvents reads server-sent events from the body, and sends them to the channel. It returns
+// true if the listener should stop, and false if it should reconnect.
+func readEvents(ctx context.Context, body io.Reader, events chan<- *Event, decoder Decoder) bool {
+	var done bool
+	readServerSentEvents(body, func(eventType, data string) bool {
+		e, err := newEvent(EventType(eventType), data)
+		if err != nil {
+			return true
+		}
+		e.decoder = decoder
+		if !sendEvent(ctx, events, e) || e.Type == EventCancel {
+			done = true
+			return false
+		}
+		return e.Type != EventAuthRevoked
+	})
+	return done
+}
+
+// readServerSentEvents reads server-sent events from the body, and passes the type and data of
+// each of them to fn, until fn returns false or the body cannot be read anymore.
+func readServerSentEvents(body io.Reader, fn func(eventType, data string) bool) {
+	reader := bufio.NewReader(body)
+	var eventType string
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if eventType == "" {
+				continue
+			}
+			if !fn(eventType, strings.Join(data, "\n")) {
+				return
+			}
+			eventType, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
+
+func newEvent(eventType EventType, data string) (*Event, error) {
+	switch eventType {
+	case EventPut, EventPatch:
+		var payload struct {
+			Path string          `json:"path"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return nil, err
+		}
+		return &Event{Type: eventType, Path: payload.Path, Data: payload.Data}, nil
+	case EventKeepAlive, EventAuthRevoked:
+		return &Event{Type: eventType}, nil
+	case EventCancel:
+		reason := "listener canceled by the server"
+		var msg string
+		if json.Unmarshal([]byte(data), &msg) == nil && msg != "" {
+			reason = fmt.Sprintf("%s: %s", reason, msg)
+		}
+		return &Event{Type: eventType, Err: errors.New(reason)}, nil
+	default:
+		return nil, fmt.Errorf("unknown event type: %q", eventType)
+	}
+}
+
+func sendEvent(ctx context.Context, events chan<- *Event, e *Event) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// openStream opens a streaming connection to the given path, and returns the body of the response.
+func (c *Client) openStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	return c.openBody(ctx, path, nil, "text/event-stream")
+}
+
+// openBody sends a GET request to the given path, and returns the body of the response without
+// reading it into memory. The request is sent with the options and hooks of the client, but is
+// not retried.
+func (c *Client) openBody(
+	ctx context.Context, path string, params map[string]string, accept string) (io.ReadCloser, error) {
+	resp, err := c.openResponse(ctx, path, params, accept)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// openResponse behaves like openBody, but returns the whole response. Error responses are
+// returned as errors.
+func (c *Client) openResponse(
+	ctx context.Context, path string, params map[string]string, accept string) (*http.Response, error) {
+	req := newOpenRequest(path, params, accept)
+	return c.open(ctx, req, func() error {
+		_, err := c.resolve(c.dbURLConfig, req)
+		return err
+	})
+}
+
+func newOpenRequest(path string, params map[string]string, accept string) *internal.Request {
+	return &internal.Request{
+		Method: http.MethodGet,
+		URL:    path,
+		Opts: []internal.HTTPOption{
+			internal.WithHeader("Accept", accept),
+			internal.WithQueryParams(params),
+		},
+	}
+}
+
+// open sends a request whose path is resolved into a URL by the given function, and returns the
+// response without reading its body.
+func (c *Client) open(ctx context.Context, req *internal.Request, resolve func() error) (*http.Response, error) {
+	path := req.URL
+	var resp *http.Response
+	_, err := c.withHooks(ctx, req, func() (*internal.Response, error) {
+		if err := resolve(); err != nil {
+			return nil, err
+		}
+		var err error
+		resp, err = c.hc.DoStream(ctx, req)
+		if err != nil {
+			setErrorPath(err, path)
+			return nil, err
+		}
+		return &internal.Response{Status: resp.StatusCode, Header: resp.Header}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func startStreamServer(t *testing.T, handler func(conn int, w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	var conns int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Get("Accept"); accept != "text/event-stream" {
+			t.Errorf("Accept = %q; want = %q", accept, "text/event-stream")
+		}
+		if r.URL.Path != "/peter.json" {
+			t.Errorf("Path = %q; want = %q", r.URL.Path, "/peter.json")
+		}
+		handler(int(atomic.AddInt32(&conns, 1)), w, r)
+	}))
+	client.dbURLConfig.BaseURL = srv.URL
+	return srv
+}
+
+func writeEvent(w http.ResponseWriter, eventType, data string) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, data)
+	w.(http.Flusher).Flush()
+}
+
+func TestListen(t *testing.T) {
+	srv := startStreamServer(t, func(conn int, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvent(w, "put", `{"path": "/", "data": {"name": "Peter Parker", "age": 17}}`)
+		writeEvent(w, "keep-alive", "null")
+		writeEvent(w, "patch", `{"path": "/", "data": {"age": 18}}`)
+		writeEvent(w, "cancel", `"permission denied"`)
+	})
+	defer srv.Close()
+
+	events, err := testref.Listen(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*Event
+	for e := range events {
+		got = append(got, e)
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("Listen() = %d events; want = 4", len(got))
+	}
+	wantTypes := []EventType{EventPut, EventKeepAlive, EventPatch, EventCancel}
+	for i, e := range got {
+		if e.Type != wantTypes[i] {
+			t.Errorf("Listen() event[%d] = %q; want = %q", i, e.Type, wantTypes[i])
+		}
+	}
+
+	var p person
+	if err := got[0].Unmarshal(&p); err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Path != "/" || p != (person{"Peter Parker", 17}) {
+		t.Errorf("Listen() put = (%q, %v); want = (%q, %v)", got[0].Path, p, "/", person{"Peter Parker", 17})
+	}
+	var patch map[string]interface{}
+	if err := got[2].Unmarshal(&patch); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(patch, map[string]interface{}{"age": float64(18)}) {
+		t.Errorf("Listen() patch = %v", patch)
+	}
+	want := "listener canceled by the server: permission denied"
+	if got[3].Err == nil || got[3].Err.Error() != want {
+		t.Errorf("Listen() cancel = %v; want = %q", got[3].Err, want)
+	}
+}
+
+func TestListenHooksAndDecoder(t *testing.T) {
+	srv := startStreamServer(t, func(conn int, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvent(w, "put", `{"path": "/", "data": {"id": 9007199254740993}}`)
+		writeEvent(w, "cancel", "null")
+	})
+	defer srv.Close()
+
+	var after []*OperationInfo
+	client.SetHooks(&Hooks{
+		After: func(ctx context.Context, info *OperationInfo) {
+			after = append(after, info)
+		},
+	})
+	defer client.SetHooks(nil)
+
+	events, err := testref.Listen(WithDecoder(context.Background(), NumberDecoder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	put := <-events
+	for range events {
+	}
+
+	if len(after) != 1 {
+		t.Fatalf("Hooks = %d; want = 1", len(after))
+	}
+	if info := after[0]; info.Path != "/peter" || info.Operation != OperationGet ||
+		info.StatusCode != http.StatusOK || info.Err != nil {
+		t.Errorf("After = %#v; want = (%q, %q, %d, nil)", info, "/peter", OperationGet, http.StatusOK)
+	}
+
+	var got map[string]interface{}
+	if err := put.Unmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if id := got["id"]; id != json.Number("9007199254740993") {
+		t.Errorf("Unmarshal() = %#v; want = json.Number(%q)", id, "9007199254740993")
+	}
+}
+
+func TestListenReconnects(t *testing.T) {
+	defer func(d time.Duration) { listenMinRetryDelay = d }(listenMinRetryDelay)
+	listenMinRetryDelay = time.Millisecond
+
+	srv := startStreamServer(t, func(conn int, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch conn {
+		case 1:
+			writeEvent(w, "put", `{"path": "/", "data": 1}`)
+		case 2:
+			writeEvent(w, "auth_revoked", `"credential is no longer valid"`)
+			writeEvent(w, "put", `{"path": "/", "data": "unreachable"}`)
+		case 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 4:
+			writeEvent(w, "put", `{"path": "/", "data": 2}`)
+			writeEvent(w, "cancel", "null")
+		}
+	})
+	defer srv.Close()
+
+	events, err := testref.Listen(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for e := range events {
+		got = append(got, fmt.Sprintf("%s %s", e.Type, e.Data))
+	}
+
+	want := []string{"put 1", "disconnected ", "auth_revoked ", "disconnected ", "put 2", "cancel "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Listen() = %q; want = %q", got, want)
+	}
+}
+
+func TestListenStopsOnPermanentError(t *testing.T) {
+	defer func(d time.Duration) { listenMinRetryDelay = d }(listenMinRetryDelay)
+	listenMinRetryDelay = time.Millisecond
+
+	srv := startStreamServer(t, func(conn int, w http.ResponseWriter, r *http.Request) {
+		if conn == 1 {
+			w.Header().Set("Content-Type", "text/event-stream")
+			writeEvent(w, "put", `{"path": "/", "data": 1}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Permission denied"}`))
+	})
+	defer srv.Close()
+
+	events, err := testref.Listen(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*Event
+	for e := range events {
+		got = append(got, e)
+	}
+	if len(got) != 3 || got[0].Type != EventPut || got[1].Type != EventDisconnected ||
+		got[2].Type != EventCancel || !IsPermissionDenied(got[2].Err) {
+		t.Errorf("Listen() = %v; want = put, disconnected and permission denied cancel events", got)
+	}
+}
+
+func TestListenError(t *testing.T) {
+	srv := startStreamServer(t, func(conn int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Permission denied"}`))
+	})
+	defer srv.Close()
+
+	events, err := testref.Listen(context.Background())
+	if events != nil || !IsPermissionDenied(err) || ErrorPath(err) != "/peter" {
+		t.Errorf("Listen() = (%v, %v); want = (nil, permission denied error)", events, err)
+	}
+}
+
+func TestListenContextCanceled(t *testing.T) {
+	srv := startStreamServer(t, func(conn int, w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvent(w, "put", `{"path": "/", "data": 1}`)
+		<-r.Context().Done()
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := testref.Listen(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e := <-events; e.Type != EventPut {
+		t.Errorf("Listen() event = %q; want = %q", e.Type, EventPut)
+	}
+	cancel()
+	select {
+	case e, ok := <-events:
+		if ok {
+			t.Errorf("Listen() = %v; want closed channel", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Listen() channel not closed after the context was canceled")
+	}
+}
+
+func TestListenInvalidPath(t *testing.T) {
+	if _, err := client.NewRef("/foo$").Listen(context.Background()); err == nil {
+		t.Errorf("Listen() = nil; want error")
+	}
+}
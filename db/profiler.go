@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const profilePath = "/.settings/profile"
+
+// profileLogEvent is the type of the server-sent events that carry the operations recorded by the
+// database profiler.
+const profileLogEvent = "log"
+
+// ProfileOperation is an operation recorded by the database profiler.
+type ProfileOperation struct {
+	// Name is the type of the operation, such as "realtime-read", "rest-write",
+	// "listener-broadcast" or "concurrent-connect".
+	Name string `json:"name"`
+
+	// Path contains the segments of the path of the data accessed by the operation, if any.
+	Path []string `json:"path"`
+
+	// Bytes is the size of the data sent or received by the operation, if applicable.
+	Bytes int64 `json:"bytes"`
+
+	// Millis is the time taken by the database to perform the operation, in milliseconds, if
+	// applicable.
+	Millis float64 `json:"millis"`
+
+	// Time is the time at which the operation was recorded.
+	Time time.Time `json:"-"`
+
+	// Data is the JSON-encoded record of the operation, which contains further fields depending
+	// on the type of the operation.
+	Data []byte `json:"-"`
+}
+
+// Profile streams the operations performed by the database, as recorded by the database profiler.
+//
+// The profiler reports the reads, writes, listener broadcasts and connections of all the clients
+// of the database, along with their size and duration, which makes it possible to compute usage
+// and capacity metrics such as bandwidth and concurrent connections. Profiling affects the
+// performance of the database, and should only be enabled for short periods.
+//
+// The returned channel is closed when the context is done, or when the server ends the stream.
+// Callers must receive from the channel until it is closed, or cancel the context. Records that
+// cannot be parsed are skipped.
+func (c *Client) Profile(ctx context.Context) (<-chan *ProfileOperation, error) {
+	req := newOpenRequest(profilePath, nil, "text/event-stream")
+	resp, err := c.open(ctx, req, func() error {
+		c.resolveSettings(req)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make(chan *ProfileOperation)
+	go func() {
+		defer close(ops)
+		defer resp.Body.Close()
+		readProfile(ctx, resp.Body, ops)
+	}()
+	return ops, nil
+}
+
+func readProfile(ctx context.Context, body io.Reader, ops chan<- *ProfileOperation) {
+	readServerSentEvents(body, func(eventType, data string) bool {
+		if eventType != profileLogEvent {
+			return true
+		}
+		op, err := newProfileOperation([]byte(data))
+		if err != nil {
+			return true
+		}
+		select {
+		case ops <- op:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+func newProfileOperation(data []byte) (*ProfileOperation, error) {
+	var op struct {
+		ProfileOperation
+		Timestamp float64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &op); err != nil {
+		return nil, err
+	}
+	result := op.ProfileOperation
+	result.Time = time.UnixMilli(int64(op.Timestamp))
+	result.Data = data
+	return &result, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProfile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Get("Accept"); accept != "text/event-stream" {
+			t.Errorf("Accept = %q; want = %q", accept, "text/event-stream")
+		}
+		if r.URL.Path != "/.settings/profile.json" {
+			t.Errorf("Path = %q; want = %q", r.URL.Path, "/.settings/profile.json")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeEvent(w, "log", `{"name": "concurrent-connect", "timestamp": 1700000000000}`)
+		writeEvent(w, "keep-alive", "null")
+		writeEvent(w, "log", `not json`)
+		writeEvent(w, "log", `{"name": "rest-write", "timestamp": 1700000000500, "path": ["users", "u1"], `+
+			`"bytes": 128, "millis": 1.5, "allowed": true}`)
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+
+	ops, err := client.Profile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*ProfileOperation
+	for op := range ops {
+		got = append(got, op)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Profile() = %d operations; want = 2", len(got))
+	}
+	if got[0].Name != "concurrent-connect" || !got[0].Time.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Profile()[0] = %#v; want = concurrent-connect", got[0])
+	}
+	want := &ProfileOperation{
+		Name:   "rest-write",
+		Path:   []string{"users", "u1"},
+		Bytes:  128,
+		Millis: 1.5,
+		Time:   time.UnixMilli(1700000000500),
+		Data: []byte(`{"name": "rest-write", "timestamp": 1700000000500, "path": ["users", "u1"], ` +
+			`"bytes": 128, "millis": 1.5, "allowed": true}`),
+	}
+	if !reflect.DeepEqual(got[1], want) {
+		t.Errorf("Profile()[1] = %#v; want = %#v", got[1], want)
+	}
+}
+
+func TestProfileError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Permission denied"}`))
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+
+	ops, err := client.Profile(context.Background())
+	if ops != nil || !IsPermissionDenied(err) {
+		t.Errorf("Profile() = (%v, %v); want = (nil, permission denied)", ops, err)
+	}
+}
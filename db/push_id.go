@@ -0,0 +1,69 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// pushChars are the characters used in push IDs, in ascending ASCII order so that the IDs sort
+// lexicographically by creation time.
+const pushChars = "-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
+
+var pushIDs = &pushIDGenerator{}
+
+// pushIDGenerator generates push IDs in the same format as the Realtime Database server.
+//
+// A push ID consists of 8 characters that encode the creation time in milliseconds, followed by 12
+// random characters. IDs generated within the same millisecond increment the random characters of
+// the previous ID, so that they remain unique and ordered.
+type pushIDGenerator struct {
+	mu       sync.Mutex
+	lastTime int64
+	lastRand [12]int
+}
+
+func (g *pushIDGenerator) next(now time.Time) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ts := now.UnixNano() / int64(time.Millisecond)
+	if ts == g.lastTime {
+		i := len(g.lastRand) - 1
+		for ; i >= 0 && g.lastRand[i] == len(pushChars)-1; i-- {
+			g.lastRand[i] = 0
+		}
+		if i >= 0 {
+			g.lastRand[i]++
+		}
+	} else {
+		for i := range g.lastRand {
+			g.lastRand[i] = rand.Intn(len(pushChars))
+		}
+	}
+	g.lastTime = ts
+
+	var id [20]byte
+	for i := 7; i >= 0; i-- {
+		id[i] = pushChars[ts%int64(len(pushChars))]
+		ts /= int64(len(pushChars))
+	}
+	for i, r := range g.lastRand {
+		id[8+i] = pushChars[r]
+	}
+	return string(id[:])
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPushIDs(t *testing.T) {
+	g := &pushIDGenerator{}
+	now := time.Unix(1700000000, 0)
+
+	var ids []string
+	for i := 0; i < 100; i++ {
+		ids = append(ids, g.next(now))
+	}
+	ids = append(ids, g.next(now.Add(time.Millisecond)))
+
+	for i, id := range ids {
+		if len(id) != 20 {
+			t.Errorf("next() = %q; want = 20 characters", id)
+		}
+		if strings.Trim(id, pushChars) != "" {
+			t.Errorf("next() = %q; want = only push characters", id)
+		}
+		if i > 0 && id <= ids[i-1] {
+			t.Errorf("next() = %q; want > %q", id, ids[i-1])
+		}
+	}
+	if ids[0][:8] != ids[99][:8] || ids[0][:8] == ids[100][:8] {
+		t.Errorf("next() timestamps = [%q, %q, %q]", ids[0][:8], ids[99][:8], ids[100][:8])
+	}
+}
+
+func TestPushIDsOverflow(t *testing.T) {
+	g := &pushIDGenerator{}
+	now := time.Unix(1700000000, 0)
+	first := g.next(now)
+	for i := range g.lastRand {
+		if i > 0 {
+			g.lastRand[i] = len(pushChars) - 1
+		}
+	}
+	g.lastRand[0] = 0
+
+	want := first[:8] + "0" + strings.Repeat("-", 11)
+	if got := g.next(now); got != want {
+		t.Errorf("next() = %q; want = %q", got, want)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"sort"
 	"strconv"
@@ -115,14 +116,16 @@ func (q *Query) Get(ctx context.Context, v interface{}) error {
 		URL:    q.path,
 		Opts:   []internal.HTTPOption{internal.WithQueryParams(qp)},
 	}
-	_, err := q.client.sendAndUnmarshal(ctx, req, v)
+	_, err := q.client.sendReadAndUnmarshal(ctx, req, v)
 	return err
 }
 
 // GetOrdered executes the Query and returns the results as an ordered slice.
 func (q *Query) GetOrdered(ctx context.Context) ([]QueryNode, error) {
+	// Numbers are kept as json.Number, so that the nodes can be decoded without loss of precision.
+	decoder := q.client.decoderFor(ctx)
 	var temp interface{}
-	if err := q.Get(ctx, &temp); err != nil {
+	if err := q.Get(WithDecoder(ctx, NumberDecoder), &temp); err != nil {
 		return nil, err
 	}
 	if temp == nil {
@@ -133,11 +136,48 @@ func (q *Query) GetOrdered(ctx context.Context) ([]QueryNode, error) {
 	sort.Sort(sn)
 	result := make([]QueryNode, len(sn))
 	for i, v := range sn {
+		v.decoder = decoder
 		result[i] = v
 	}
 	return result, nil
 }
 
+// Exists checks whether the Query matches any data.
+//
+// Unless a limit is already set on the Query, Exists limits the results to the first match, so
+// that at most one child node is downloaded.
+func (q *Query) Exists(ctx context.Context) (bool, error) {
+	if q.limFirst == 0 && q.limLast == 0 {
+		q = q.LimitToFirst(1)
+	}
+	n, err := q.Count(ctx)
+	return n > 0, err
+}
+
+// Count returns the number of child nodes matched by the Query.
+//
+// The Realtime Database REST API does not support shallow reads of queries. Therefore Count
+// downloads the matching child nodes. Use Ref.Count to count the children of a location without
+// downloading them.
+func (q *Query) Count(ctx context.Context) (int, error) {
+	var v interface{}
+	if err := q.Get(ctx, &v); err != nil {
+		return 0, err
+	}
+	return childCount(v), nil
+}
+
+// childCount returns the number of child nodes in the given JSON value.
+func childCount(v interface{}) int {
+	switch children := v.(type) {
+	case map[string]interface{}:
+		return len(children)
+	case []interface{}:
+		return len(children)
+	}
+	return 0
+}
+
 // OrderByChild returns a Query that orders data by child values before applying filters.
 //
 // Returned Query can be used to set additional parameters, and execute complex database queries
@@ -258,15 +298,31 @@ const (
 	typeObject    = 5
 )
 
-// comparableKey is a union type of numeric values and strings.
+// comparableKey is a union type of numeric values and strings. Integral json.Number values are
+// held in Int, so that they are compared exactly.
 type comparableKey struct {
 	Num *float64
+	Int *big.Int
 	Str *string
 }
 
+func (k *comparableKey) isNumeric() bool {
+	return k.Num != nil || k.Int != nil
+}
+
+// bigFloat returns the numeric value of the key as an exact big.Float.
+func (k *comparableKey) bigFloat() *big.Float {
+	if k.Int != nil {
+		return new(big.Float).SetInt(k.Int)
+	}
+	return big.NewFloat(*k.Num)
+}
+
 func (k *comparableKey) Compare(o *comparableKey) int {
 	if k.Str != nil && o.Str != nil {
 		return strings.Compare(*k.Str, *o.Str)
+	} else if k.Int != nil && o.Int != nil {
+		return k.Int.Cmp(o.Int)
 	} else if k.Num != nil && o.Num != nil {
 		if *k.Num < *o.Num {
 			return -1
@@ -274,7 +330,9 @@ func (k *comparableKey) Compare(o *comparableKey) int {
 			return 0
 		}
 		return 1
-	} else if k.Num != nil {
+	} else if k.isNumeric() && o.isNumeric() {
+		return k.bigFloat().Cmp(o.bigFloat())
+	} else if k.isNumeric() {
 		// numeric keys appear before string keys
 		return -1
 	}
@@ -286,12 +344,19 @@ func newComparableKey(v interface{}) *comparableKey {
 		return &comparableKey{Str: &s}
 	}
 
-	// Numeric values could be int (in the case of array indices and type constants), or float64 (if
-	// the value was received as json).
+	// Numeric values could be int (in the case of array indices and type constants), or json.Number
+	// (if the value was received as json).
 	if i, ok := v.(int); ok {
 		f := float64(i)
 		return &comparableKey{Num: &f}
 	}
+	if n, ok := v.(json.Number); ok {
+		if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+			return &comparableKey{Int: i}
+		}
+		f, _ := n.Float64()
+		return &comparableKey{Num: &f}
+	}
 
 	f := v.(float64)
 	return &comparableKey{Num: &f}
@@ -302,6 +367,7 @@ type queryNodeImpl struct {
 	Value     interface{}
 	Index     interface{}
 	IndexType int
+	decoder   Decoder
 }
 
 func (q *queryNodeImpl) Key() string {
@@ -317,7 +383,10 @@ func (q *queryNodeImpl) Unmarshal(v interface{}) error {
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(b, v)
+	if q.decoder == nil {
+		return json.Unmarshal(b, v)
+	}
+	return q.decoder.Unmarshal(b, v)
 }
 
 func newQueryNode(key, val interface{}, order orderBy) *queryNodeImpl {
@@ -355,11 +424,13 @@ func (s sortableNodes) Less(i, j int) bool {
 	var aKey, bKey *comparableKey
 	if a.IndexType == b.IndexType {
 		// If the indices have the same type and are comparable (i.e. numeric or string), compare
-		// them directly. Otherwise, compare the keys.
-		if (a.IndexType == typeNumeric || a.IndexType == typeString) && a.Index != b.Index {
-			aKey, bKey = newComparableKey(a.Index), newComparableKey(b.Index)
-		} else {
-			aKey, bKey = a.CompKey, b.CompKey
+		// them directly. Otherwise, or if the indices are equal, compare the keys.
+		aKey, bKey = a.CompKey, b.CompKey
+		if a.IndexType == typeNumeric || a.IndexType == typeString {
+			ai, bi := newComparableKey(a.Index), newComparableKey(b.Index)
+			if ai.Compare(bi) != 0 {
+				aKey, bKey = ai, bi
+			}
 		}
 	} else {
 		// If the indices are of different types, use the type ordering of Firebase.
@@ -416,6 +487,8 @@ func getIndexType(index interface{}) int {
 			return typeBoolTrue
 		}
 		return typeBoolFalse
+	} else if _, ok := index.(json.Number); ok {
+		return typeNumeric
 	} else if _, ok := index.(float64); ok {
 		return typeNumeric
 	} else if _, ok := index.(string); ok {
@@ -283,6 +283,70 @@ func TestLimitLastQuery(t *testing.T) {
 	})
 }
 
+func TestQueryExists(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	cases := []struct {
+		resp interface{}
+		want bool
+	}{
+		{nil, false},
+		{map[string]interface{}{}, false},
+		{map[string]interface{}{"m1": "Hello"}, true},
+	}
+	var want []*testReq
+	for _, tc := range cases {
+		mock.Resp = tc.resp
+		got, err := testref.OrderByChild("messages").EqualTo("Hello").Exists(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("Exists(%v) = %v; want = %v", tc.resp, got, tc.want)
+		}
+		want = append(want, &testReq{
+			Method: "GET",
+			Path:   "/peter.json",
+			Query:  map[string]string{"limitToFirst": "1", "equalTo": "\"Hello\"", "orderBy": "\"messages\""},
+		})
+	}
+	checkAllRequests(t, mock.Reqs, want)
+}
+
+func TestQueryExistsWithLimit(t *testing.T) {
+	mock := &mockServer{Resp: map[string]interface{}{"m1": "Hello"}}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	got, err := testref.OrderByChild("messages").LimitToLast(10).Exists(context.Background())
+	if err != nil || !got {
+		t.Errorf("Exists() = (%v, %v); want = (true, nil)", got, err)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "GET",
+		Path:   "/peter.json",
+		Query:  map[string]string{"limitToLast": "10", "orderBy": "\"messages\""},
+	})
+}
+
+func TestQueryCount(t *testing.T) {
+	mock := &mockServer{Resp: []interface{}{"Hello", "Bye", "Hi"}}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	got, err := testref.OrderByKey().LimitToFirst(3).Count(context.Background())
+	if err != nil || got != 3 {
+		t.Errorf("Count() = (%d, %v); want = (3, nil)", got, err)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "GET",
+		Path:   "/peter.json",
+		Query:  map[string]string{"limitToFirst": "3", "orderBy": "\"$key\""},
+	})
+}
+
 func TestInvalidLimitQuery(t *testing.T) {
 	want := map[string]interface{}{"m1": "Hello", "m2": "Bye"}
 	mock := &mockServer{Resp: want}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+type readReplicaKey struct{}
+
+// SetReadReplicaURL configures the URL of a read endpoint of the database, such as a follower
+// that serves reads on behalf of the primary instance.
+//
+// Once set, Ref.Get, Ref.GetShallow and Query.Get are sent to the read endpoint, unless disabled
+// for a specific call via WithReadReplica. Reads that return an ETag (GetWithETag, GetIfChanged
+// and Transaction), as well as all writes, are always sent to the primary instance. Data served
+// by the read endpoint may lag behind the primary instance.
+//
+// The URL must be an HTTPS URL. Passing an empty string sends all the requests to the primary
+// instance again. This method should be called before the client is used concurrently.
+func (c *Client) SetReadReplicaURL(replicaURL string) error {
+	if replicaURL == "" {
+		c.readReplica = nil
+		return nil
+	}
+	parsed, err := url.ParseRequestURI(replicaURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("%s: %w", replicaURL, errInvalidURL)
+	}
+	c.readReplica = &dbURLConfig{
+		BaseURL: strings.TrimSuffix(replicaURL, "/"),
+	}
+	return nil
+}
+
+// WithReadReplica returns a copy of the context that controls whether the reads performed with it
+// are sent to the read endpoint configured via SetReadReplicaURL.
+//
+// Use WithReadReplica(ctx, false) for reads that must observe the latest writes. The context has
+// no effect if no read endpoint is configured on the client.
+func WithReadReplica(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, readReplicaKey{}, enabled)
+}
+
+// sendReadAndUnmarshal sends a read request that may be served by the read endpoint.
+func (c *Client) sendReadAndUnmarshal(
+	ctx context.Context, req *internal.Request, v interface{}) (*internal.Response, error) {
+	urlConfig := c.dbURLConfig
+	if c.readReplica != nil {
+		if enabled, ok := ctx.Value(readReplicaKey{}).(bool); !ok || enabled {
+			urlConfig = c.readReplica
+		}
+	}
+	return c.sendWithHooks(ctx, urlConfig, req, v)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+func TestReadReplica(t *testing.T) {
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts:         testOpts,
+		URL:          testURL,
+		Version:      "1.2.3",
+		AuthOverride: map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary := &mockServer{Resp: "primary"}
+	srv := primary.Start(c)
+	defer srv.Close()
+
+	replica := &mockServer{Resp: "replica"}
+	replicaSrv := replica.Start(&Client{dbURLConfig: &dbURLConfig{}})
+	defer replicaSrv.Close()
+
+	if err := c.SetReadReplicaURL("https://replica.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	c.readReplica.BaseURL = replicaSrv.URL
+
+	ctx := context.Background()
+	ref := c.NewRef("peter")
+	var got string
+	if err := ref.Get(ctx, &got); err != nil || got != "replica" {
+		t.Errorf("Get() = (%q, %v); want = (%q, nil)", got, err, "replica")
+	}
+	if err := ref.GetShallow(ctx, &got); err != nil || got != "replica" {
+		t.Errorf("GetShallow() = (%q, %v); want = (%q, nil)", got, err, "replica")
+	}
+	if err := ref.OrderByKey().Get(ctx, &got); err != nil || got != "replica" {
+		t.Errorf("Query.Get() = (%q, %v); want = (%q, nil)", got, err, "replica")
+	}
+
+	primaryCtx := WithReadReplica(ctx, false)
+	if err := ref.Get(primaryCtx, &got); err != nil || got != "primary" {
+		t.Errorf("Get(primary) = (%q, %v); want = (%q, nil)", got, err, "primary")
+	}
+	if err := ref.OrderByKey().Get(primaryCtx, &got); err != nil || got != "primary" {
+		t.Errorf("Query.Get(primary) = (%q, %v); want = (%q, nil)", got, err, "primary")
+	}
+	if _, err := ref.GetWithETag(ctx, &got); err != nil || got != "primary" {
+		t.Errorf("GetWithETag() = (%q, %v); want = (%q, nil)", got, err, "primary")
+	}
+	if err := ref.Set(ctx, "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replica.Reqs) != 3 {
+		t.Errorf("Replica requests = %d; want = 3", len(replica.Reqs))
+	}
+	if len(primary.Reqs) != 4 {
+		t.Errorf("Primary requests = %d; want = 4", len(primary.Reqs))
+	}
+
+	if err := c.SetReadReplicaURL(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ref.Get(WithReadReplica(ctx, true), &got); err != nil || got != "primary" {
+		t.Errorf("Get(no replica) = (%q, %v); want = (%q, nil)", got, err, "primary")
+	}
+}
+
+func TestInvalidReadReplicaURL(t *testing.T) {
+	c := &Client{}
+	for _, url := range []string{"replica.example.com", "http://replica.example.com", "https://"} {
+		if err := c.SetReadReplicaURL(url); !errors.Is(err, errInvalidURL) {
+			t.Errorf("SetReadReplicaURL(%q) = %v; want = %v", url, err, errInvalidURL)
+		}
+	}
+}
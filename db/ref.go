@@ -16,10 +16,11 @@ package db
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"firebase.google.com/go/v4/internal"
 )
@@ -43,11 +44,12 @@ type TransactionNode interface {
 }
 
 type transactionNodeImpl struct {
-	Raw []byte
+	Raw     []byte
+	decoder Decoder
 }
 
 func (t *transactionNodeImpl) Unmarshal(v interface{}) error {
-	return json.Unmarshal(t.Raw, v)
+	return t.decoder.Unmarshal(t.Raw, v)
 }
 
 // Parent returns a reference to the parent of the current node.
@@ -78,7 +80,7 @@ func (r *Ref) Get(ctx context.Context, v interface{}) error {
 	req := &internal.Request{
 		Method: http.MethodGet,
 	}
-	_, err := r.sendAndUnmarshal(ctx, req, v)
+	_, err := r.sendReadAndUnmarshal(ctx, req, v)
 	return err
 }
 
@@ -108,10 +110,34 @@ func (r *Ref) GetShallow(ctx context.Context, v interface{}) error {
 			internal.WithQueryParam("shallow", "true"),
 		},
 	}
-	_, err := r.sendAndUnmarshal(ctx, req, v)
+	_, err := r.sendReadAndUnmarshal(ctx, req, v)
 	return err
 }
 
+// Exists checks whether there is any data at the current database location.
+//
+// Exists performs a shallow read, and therefore does not download the child nodes of the current
+// location.
+func (r *Ref) Exists(ctx context.Context) (bool, error) {
+	var v interface{}
+	if err := r.GetShallow(ctx, &v); err != nil {
+		return false, err
+	}
+	return v != nil, nil
+}
+
+// Count returns the number of child nodes of the current database location.
+//
+// Count performs a shallow read, and therefore only downloads the keys of the child nodes. Count
+// returns 0 if the location does not exist, or if it holds a primitive value.
+func (r *Ref) Count(ctx context.Context) (int, error) {
+	var v interface{}
+	if err := r.GetShallow(ctx, &v); err != nil {
+		return 0, err
+	}
+	return childCount(v), nil
+}
+
 // GetIfChanged retrieves the value and ETag of the current database location only if the specified
 // ETag does not match.
 //
@@ -136,7 +162,7 @@ func (r *Ref) GetIfChanged(ctx context.Context, etag string, v interface{}) (boo
 		return false, etag, nil
 	}
 
-	if err := json.Unmarshal(resp.Body, v); err != nil {
+	if err := r.client.decoderFor(ctx).Unmarshal(resp.Body, v); err != nil {
 		return false, "", err
 	}
 
@@ -208,6 +234,25 @@ func (r *Ref) Push(ctx context.Context, v interface{}) (*Ref, error) {
 	return r.Child(d.Name), nil
 }
 
+// PushSilent creates a new child node at the current location, and returns a reference to it.
+//
+// Unlike Push, PushSilent generates the key of the new child node locally, and writes the value
+// with Set. Therefore the server does not send back a response body. The generated keys have
+// the same format as the ones generated by the server, and are ordered by creation time as long
+// as the local clock is accurate. If v is nil, the new child node will be created with empty
+// string as the value.
+func (r *Ref) PushSilent(ctx context.Context, v interface{}) (*Ref, error) {
+	if v == nil {
+		v = ""
+	}
+
+	child := r.Child(pushIDs.next(time.Now()))
+	if err := child.Set(ctx, v); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
 // Update modifies the specified child keys of the current location to the provided values.
 func (r *Ref) Update(ctx context.Context, v map[string]interface{}) error {
 	if len(v) == 0 {
@@ -243,6 +288,46 @@ type UpdateFn func(TransactionNode) (interface{}, error)
 // The update function may also force an early abort by returning an error instead of returning a
 // value.
 func (r *Ref) Transaction(ctx context.Context, fn UpdateFn) error {
+	_, err := r.TransactionWithOptions(ctx, fn, nil)
+	return err
+}
+
+// ErrTransactionAborted is returned by Transaction() and TransactionWithOptions() when a
+// transaction could not be committed within the allowed number of retries.
+var ErrTransactionAborted = errors.New("transaction aborted after failed retries")
+
+// TransactionOptions configures how TransactionWithOptions() retries conflicting writes.
+type TransactionOptions struct {
+	// MaxRetries is the maximum number of times the update function is called. Zero means 25.
+	MaxRetries int
+
+	// Backoff is the delay before retrying after the first conflict. The delay doubles after each
+	// subsequent conflict. Zero retries without delay.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between retries. Zero means no limit.
+	MaxBackoff time.Duration
+}
+
+// TransactionWithOptions atomically modifies the data at this location like Transaction(), with
+// the retries configured by opts, which may be nil.
+//
+// On success, it returns the committed value. If the value could not be committed within the
+// allowed number of retries, it returns ErrTransactionAborted. Retries stop early if the context
+// is done while waiting to retry.
+func (r *Ref) TransactionWithOptions(ctx context.Context, fn UpdateFn, opts *TransactionOptions) (TransactionNode, error) {
+	retries := txnRetries
+	var backoff, maxBackoff time.Duration
+	if opts != nil {
+		if opts.MaxRetries < 0 || opts.Backoff < 0 || opts.MaxBackoff < 0 {
+			return nil, errors.New("transaction options must not be negative")
+		}
+		if opts.MaxRetries > 0 {
+			retries = opts.MaxRetries
+		}
+		backoff, maxBackoff = opts.Backoff, opts.MaxBackoff
+	}
+
 	req := &internal.Request{
 		Method: http.MethodGet,
 		Opts: []internal.HTTPOption{
@@ -251,14 +336,24 @@ func (r *Ref) Transaction(ctx context.Context, fn UpdateFn) error {
 	}
 	resp, err := r.sendAndUnmarshal(ctx, req, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	etag := resp.Header.Get("Etag")
-	for i := 0; i < txnRetries; i++ {
-		new, err := fn(&transactionNodeImpl{resp.Body})
+	for i := 0; i < retries; i++ {
+		if i > 0 && backoff > 0 {
+			delay := backoff << (i - 1)
+			if delay <= 0 || (maxBackoff > 0 && delay > maxBackoff) {
+				delay = maxBackoff
+			}
+			if err := sleepWithContext(ctx, delay); err != nil {
+				return nil, err
+			}
+		}
+
+		new, err := fn(&transactionNodeImpl{resp.Body, r.client.decoderFor(ctx)})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		req := &internal.Request{
@@ -271,33 +366,77 @@ func (r *Ref) Transaction(ctx context.Context, fn UpdateFn) error {
 		}
 		resp, err = r.sendAndUnmarshal(ctx, req, nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if resp.Status == http.StatusOK {
-			return nil
+			return &transactionNodeImpl{resp.Body, r.client.decoderFor(ctx)}, nil
 		}
 
 		etag = resp.Header.Get("ETag")
 	}
-	return fmt.Errorf("transaction aborted after failed retries")
+	return nil, ErrTransactionAborted
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
 
 // Delete removes this node from the database.
 func (r *Ref) Delete(ctx context.Context) error {
 	req := &internal.Request{
 		Method: http.MethodDelete,
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParam("print", "silent"),
+		},
 	}
 	_, err := r.sendAndUnmarshal(ctx, req, nil)
 	return err
 }
 
+// DeleteIfUnchanged conditionally removes this node from the database.
+//
+// Removes this node only if the specified ETag matches. Returns true if the node is removed.
+// Returns false if no changes are made to the database.
+func (r *Ref) DeleteIfUnchanged(ctx context.Context, etag string) (bool, error) {
+	req := &internal.Request{
+		Method: http.MethodDelete,
+		Opts: []internal.HTTPOption{
+			internal.WithHeader("If-Match", etag),
+		},
+		SuccessFn: successOrPreconditionFailed,
+	}
+	resp, err := r.sendAndUnmarshal(ctx, req, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if resp.Status == http.StatusPreconditionFailed {
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func (r *Ref) sendAndUnmarshal(
 	ctx context.Context, req *internal.Request, v interface{}) (*internal.Response, error) {
 	req.URL = r.Path
 	return r.client.sendAndUnmarshal(ctx, req, v)
 }
 
+func (r *Ref) sendReadAndUnmarshal(
+	ctx context.Context, req *internal.Request, v interface{}) (*internal.Response, error) {
+	req.URL = r.Path
+	return r.client.sendReadAndUnmarshal(ctx, req, v)
+}
+
 func successOrNotModified(resp *internal.Response) bool {
 	return internal.HasSuccessStatus(resp) || resp.Status == http.StatusNotModified
 }
@@ -16,10 +16,12 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"firebase.google.com/go/v4/errorutils"
 )
@@ -197,6 +199,77 @@ func TestGetShallow(t *testing.T) {
 	checkAllRequests(t, mock.Reqs, want)
 }
 
+func TestExists(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	cases := []struct {
+		resp interface{}
+		want bool
+	}{
+		{nil, false},
+		{"foo", true},
+		{map[string]interface{}{"name": true, "age": true}, true},
+	}
+	wantQuery := map[string]string{"shallow": "true"}
+	var want []*testReq
+	for _, tc := range cases {
+		mock.Resp = tc.resp
+		got, err := testref.Exists(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("Exists(%v) = %v; want = %v", tc.resp, got, tc.want)
+		}
+		want = append(want, &testReq{Method: "GET", Path: "/peter.json", Query: wantQuery})
+	}
+	checkAllRequests(t, mock.Reqs, want)
+}
+
+func TestCount(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	cases := []struct {
+		resp interface{}
+		want int
+	}{
+		{nil, 0},
+		{"foo", 0},
+		{map[string]interface{}{"name": true, "age": true}, 2},
+	}
+	wantQuery := map[string]string{"shallow": "true"}
+	var want []*testReq
+	for _, tc := range cases {
+		mock.Resp = tc.resp
+		got, err := testref.Count(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("Count(%v) = %d; want = %d", tc.resp, got, tc.want)
+		}
+		want = append(want, &testReq{Method: "GET", Path: "/peter.json", Query: wantQuery})
+	}
+	checkAllRequests(t, mock.Reqs, want)
+}
+
+func TestCountError(t *testing.T) {
+	mock := &mockServer{Resp: map[string]string{"error": "test error"}, Status: 500}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	if got, err := testref.Count(context.Background()); got != 0 || err == nil {
+		t.Errorf("Count() = (%d, %v); want = (0, error)", got, err)
+	}
+	if got, err := testref.Exists(context.Background()); got || err == nil {
+		t.Errorf("Exists() = (%v, %v); want = (false, error)", got, err)
+	}
+}
+
 func TestGetWithETag(t *testing.T) {
 	want := map[string]interface{}{"name": "Peter Parker", "age": float64(17)}
 	mock := &mockServer{
@@ -392,6 +465,68 @@ func TestPlatformErrorCodes(t *testing.T) {
 	}
 }
 
+func TestRTDBErrorCodes(t *testing.T) {
+	mock := &mockServer{Resp: map[string]string{"error": "test error"}}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	cases := []struct {
+		name   string
+		status int
+		check  func(err error) bool
+	}{
+		{
+			name:   "PermissionDenied",
+			status: http.StatusUnauthorized,
+			check:  IsPermissionDenied,
+		},
+		{
+			name:   "PermissionDenied",
+			status: http.StatusForbidden,
+			check:  IsPermissionDenied,
+		},
+		{
+			name:   "NotFound",
+			status: http.StatusNotFound,
+			check:  IsNotFound,
+		},
+		{
+			name:   "PreconditionFailed",
+			status: http.StatusPreconditionFailed,
+			check:  IsPreconditionFailed,
+		},
+		{
+			name:   "QuotaExceeded",
+			status: http.StatusTooManyRequests,
+			check:  IsQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.Status = tc.status
+			err := testref.Delete(context.Background())
+			if err == nil {
+				t.Fatalf("Delete() = nil; want = error")
+			}
+
+			if !tc.check(err) {
+				t.Errorf("Is%s(err) = false; want = true", tc.name)
+			}
+			if path := ErrorPath(err); path != testref.Path {
+				t.Errorf("ErrorPath(err) = %q; want = %q", path, testref.Path)
+			}
+			if tc.status == http.StatusPreconditionFailed && !errorutils.IsFailedPrecondition(err) {
+				t.Errorf("IsFailedPrecondition(err) = false; want = true")
+			}
+		})
+	}
+
+	if IsPermissionDenied(errors.New("test error")) || ErrorPath(errors.New("test error")) != "" {
+		t.Errorf("IsPermissionDenied(non-firebase error) = true; want = false")
+	}
+}
+
 func TestInvalidPath(t *testing.T) {
 	mock := &mockServer{Resp: "test"}
 	srv := mock.Start(client)
@@ -570,6 +705,28 @@ func TestPushWithValue(t *testing.T) {
 	})
 }
 
+func TestPushSilent(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	want := map[string]interface{}{"name": "Peter Parker", "age": float64(17)}
+	child, err := testref.PushSilent(context.Background(), want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(child.Key) != 20 {
+		t.Errorf("PushSilent() = %q; want = 20 characters", child.Key)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PUT",
+		Path:   "/peter/" + child.Key + ".json",
+		Body:   serialize(want),
+		Query:  map[string]string{"print": "silent"},
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	want := map[string]interface{}{"name": "Peter Parker", "age": float64(17)}
 	mock := &mockServer{Resp: want}
@@ -792,6 +949,115 @@ func TestTransactionAbort(t *testing.T) {
 	checkAllRequests(t, mock.Reqs, wanted)
 }
 
+func TestTransactionWithOptions(t *testing.T) {
+	mock := &mockServer{
+		Resp:   &person{"Peter Parker", 17},
+		Header: map[string]string{"ETag": "mock-etag1"},
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	cnt := 0
+	var fn UpdateFn = func(t TransactionNode) (interface{}, error) {
+		if cnt == 0 {
+			mock.Status = http.StatusPreconditionFailed
+			mock.Header = map[string]string{"ETag": "mock-etag2"}
+			mock.Resp = &person{"Peter Parker", 19}
+		} else if cnt == 1 {
+			mock.Status = http.StatusOK
+			mock.Resp = &person{"Peter Parker", 20}
+		}
+		cnt++
+		var p person
+		if err := t.Unmarshal(&p); err != nil {
+			return nil, err
+		}
+		p.Age++
+		return &p, nil
+	}
+	opts := &TransactionOptions{MaxRetries: 3, Backoff: time.Millisecond}
+	node, err := testref.TransactionWithOptions(context.Background(), fn, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var committed person
+	if err := node.Unmarshal(&committed); err != nil {
+		t.Fatal(err)
+	}
+	if want := (person{"Peter Parker", 20}); committed != want {
+		t.Errorf("TransactionWithOptions() = %v; want = %v", committed, want)
+	}
+	if cnt != 2 {
+		t.Errorf("TransactionWithOptions() retries = %d; want = %d", cnt, 2)
+	}
+}
+
+func TestTransactionWithOptionsAbort(t *testing.T) {
+	mock := &mockServer{
+		Resp:   &person{"Peter Parker", 17},
+		Header: map[string]string{"ETag": "mock-etag1"},
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	cnt := 0
+	var fn UpdateFn = func(t TransactionNode) (interface{}, error) {
+		mock.Status = http.StatusPreconditionFailed
+		cnt++
+		return &person{"Peter Parker", 18}, nil
+	}
+	opts := &TransactionOptions{MaxRetries: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	node, err := testref.TransactionWithOptions(context.Background(), fn, opts)
+	if node != nil || err != ErrTransactionAborted {
+		t.Errorf("TransactionWithOptions() = (%v, %v); want = (nil, %v)", node, err, ErrTransactionAborted)
+	}
+	if cnt != 3 {
+		t.Errorf("TransactionWithOptions() retries = %d; want = %d", cnt, 3)
+	}
+	if len(mock.Reqs) != 4 {
+		t.Errorf("TransactionWithOptions() = %d requests; want = %d", len(mock.Reqs), 4)
+	}
+}
+
+func TestTransactionWithOptionsCanceled(t *testing.T) {
+	mock := &mockServer{
+		Resp:   &person{"Peter Parker", 17},
+		Header: map[string]string{"ETag": "mock-etag1"},
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cnt := 0
+	var fn UpdateFn = func(t TransactionNode) (interface{}, error) {
+		mock.Status = http.StatusPreconditionFailed
+		cnt++
+		cancel()
+		return &person{"Peter Parker", 18}, nil
+	}
+	opts := &TransactionOptions{Backoff: time.Hour}
+	if _, err := testref.TransactionWithOptions(ctx, fn, opts); err != context.Canceled {
+		t.Errorf("TransactionWithOptions() = %v; want = %v", err, context.Canceled)
+	}
+	if cnt != 1 {
+		t.Errorf("TransactionWithOptions() retries = %d; want = %d", cnt, 1)
+	}
+}
+
+func TestTransactionWithInvalidOptions(t *testing.T) {
+	var fn UpdateFn = func(t TransactionNode) (interface{}, error) {
+		return nil, nil
+	}
+	want := "transaction options must not be negative"
+	for _, opts := range []*TransactionOptions{{MaxRetries: -1}, {Backoff: -1}, {MaxBackoff: -1}} {
+		if _, err := testref.TransactionWithOptions(context.Background(), fn, opts); err == nil || err.Error() != want {
+			t.Errorf("TransactionWithOptions(%v) = %v; want = %q", opts, err, want)
+		}
+	}
+}
+
 func TestTransactionFailure(t *testing.T) {
 	mock := &mockServer{
 		Resp:   &person{"Peter Parker", 17},
@@ -839,5 +1105,47 @@ func TestDelete(t *testing.T) {
 	checkOnlyRequest(t, mock.Reqs, &testReq{
 		Method: "DELETE",
 		Path:   "/peter.json",
+		Query:  map[string]string{"print": "silent"},
+	})
+}
+
+func TestDeleteIfUnchanged(t *testing.T) {
+	mock := &mockServer{Resp: "null"}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	ok, err := testref.DeleteIfUnchanged(context.Background(), "mock-etag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("DeleteIfUnchanged() = %v; want = %v", ok, true)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "DELETE",
+		Path:   "/peter.json",
+		Header: http.Header{"If-Match": []string{"mock-etag"}},
+	})
+}
+
+func TestDeleteIfUnchangedError(t *testing.T) {
+	mock := &mockServer{
+		Status: http.StatusPreconditionFailed,
+		Resp:   &person{"Tony Stark", 39},
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	ok, err := testref.DeleteIfUnchanged(context.Background(), "mock-etag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Errorf("DeleteIfUnchanged() = %v; want = %v", ok, false)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "DELETE",
+		Path:   "/peter.json",
+		Header: http.Header{"If-Match": []string{"mock-etag"}},
 	})
 }
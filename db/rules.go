@@ -0,0 +1,81 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const rulesPath = "/.settings/rules"
+
+// RulesJSON returns the security rules of the database.
+//
+// The rules are returned as they were deployed, which may include comments. Therefore they are not
+// necessarily valid JSON.
+func (c *Client) RulesJSON(ctx context.Context) ([]byte, error) {
+	req := &internal.Request{
+		Method: http.MethodGet,
+	}
+	resp, err := c.sendRules(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// SetRulesJSON replaces the security rules of the database with the given rules.
+//
+// The rules are deployed as they are, and may include comments.
+func (c *Client) SetRulesJSON(ctx context.Context, rules []byte) error {
+	return c.putRules(ctx, rules, false)
+}
+
+// ValidateRulesJSON checks that the given security rules can be deployed to the database, without
+// deploying them.
+func (c *Client) ValidateRulesJSON(ctx context.Context, rules []byte) error {
+	return c.putRules(ctx, rules, true)
+}
+
+func (c *Client) putRules(ctx context.Context, rules []byte, dryRun bool) error {
+	if len(rules) == 0 {
+		return errors.New("rules must not be empty")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodPut,
+		Body:   &rawJSONEntity{rules},
+	}
+	if dryRun {
+		req.Opts = append(req.Opts, internal.WithQueryParam("dryRun", "true"))
+	}
+	_, err := c.sendRules(ctx, req)
+	return err
+}
+
+func (c *Client) sendRules(ctx context.Context, req *internal.Request) (*internal.Response, error) {
+	req.URL = rulesPath
+	return c.withHooks(ctx, req, func() (*internal.Response, error) {
+		c.resolveSettings(req)
+		resp, err := c.hc.Do(ctx, req)
+		if err != nil {
+			setErrorPath(err, rulesPath)
+		}
+		return resp, err
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testRules = `{
+  // Only authenticated users can read.
+  "rules": {".read": "auth != null", ".write": false}
+}`
+
+const testPlainRules = `{"rules": {".read": "auth != null", ".write": false}}`
+
+func TestRulesJSON(t *testing.T) {
+	var tr *testReq
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr, _ = newTestReq(r)
+		w.Write([]byte(testRules))
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+
+	rules, err := client.RulesJSON(context.Background())
+	if err != nil || string(rules) != testRules {
+		t.Errorf("RulesJSON() = (%s, %v); want = (%s, nil)", rules, err, testRules)
+	}
+	checkOnlyRequest(t, []*testReq{tr}, &testReq{
+		Method: "GET",
+		Path:   "/.settings/rules.json",
+	})
+}
+
+func TestSetRulesJSON(t *testing.T) {
+	mock := &mockServer{Resp: map[string]string{"status": "ok"}}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	if err := client.SetRulesJSON(context.Background(), []byte(testPlainRules)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ValidateRulesJSON(context.Background(), []byte(testPlainRules)); err != nil {
+		t.Fatal(err)
+	}
+	checkAllRequests(t, mock.Reqs, []*testReq{
+		{
+			Method: "PUT",
+			Path:   "/.settings/rules.json",
+			Body:   []byte(testPlainRules),
+		},
+		{
+			Method: "PUT",
+			Path:   "/.settings/rules.json",
+			Body:   []byte(testPlainRules),
+			Query:  map[string]string{"dryRun": "true"},
+		},
+	})
+}
+
+func TestRulesJSONHooks(t *testing.T) {
+	mock := &mockServer{Resp: map[string]string{"status": "ok"}}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var after []*OperationInfo
+	client.SetHooks(&Hooks{
+		After: func(ctx context.Context, info *OperationInfo) {
+			after = append(after, info)
+		},
+	})
+	defer client.SetHooks(nil)
+
+	if err := client.SetRulesJSON(context.Background(), []byte(testPlainRules)); err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 1 {
+		t.Fatalf("Hooks = %d; want = 1", len(after))
+	}
+	if info := after[0]; info.Path != rulesPath || info.Operation != OperationSet ||
+		info.PayloadSize != len(testPlainRules) || info.StatusCode != http.StatusOK {
+		t.Errorf("After = %#v; want = (%q, %q, %d, %d)", info, rulesPath, OperationSet, len(testPlainRules), http.StatusOK)
+	}
+}
+
+func TestSetRulesJSONError(t *testing.T) {
+	mock := &mockServer{
+		Resp:   map[string]string{"error": "Line 2: invalid rule"},
+		Status: http.StatusBadRequest,
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	want := "http error status: 400; reason: Line 2: invalid rule"
+	err := client.ValidateRulesJSON(context.Background(), []byte(`{"rules": {".read": 1}}`))
+	if err == nil || err.Error() != want || ErrorPath(err) != rulesPath {
+		t.Errorf("ValidateRulesJSON() = %v; want = %q", err, want)
+	}
+}
+
+func TestSetEmptyRulesJSON(t *testing.T) {
+	want := "rules must not be empty"
+	if err := client.SetRulesJSON(context.Background(), nil); err == nil || err.Error() != want {
+		t.Errorf("SetRulesJSON(nil) = %v; want = %q", err, want)
+	}
+	if err := client.ValidateRulesJSON(context.Background(), []byte{}); err == nil || err.Error() != want {
+		t.Errorf("ValidateRulesJSON(empty) = %v; want = %q", err, want)
+	}
+}
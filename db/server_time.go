@@ -0,0 +1,63 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// ServerTimeOffset estimates the difference between the clock of the database server and the
+// local clock, so that local timestamps can be compared with ServerTimestamp values.
+//
+// The returned offset is positive if the server clock is ahead of the local clock. It plays the
+// same role as the .info/serverTimeOffset location of the client SDKs, which is not available via
+// the REST API. The offset is derived from the Date header of a response of the server, and is
+// therefore only accurate to about one second. The request reads a location that does not exist,
+// and the offset is returned even if the security rules deny the read.
+func (c *Client) ServerTimeOffset(ctx context.Context) (time.Duration, error) {
+	// A random key makes sure that the response is neither cached nor large.
+	start := time.Now()
+	resp, err := c.openResponse(ctx, "/"+pushIDs.next(start), nil, "application/json")
+	end := time.Now()
+	if err != nil {
+		// Error responses, such as a denied read, are dated as well.
+		var fe *internal.FirebaseError
+		if !errors.As(err, &fe) || fe.Response == nil {
+			return 0, err
+		}
+		resp = fe.Response
+	} else {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the date of the server response: %v", err)
+	}
+	// The Date header is truncated to the second, and the request took some time. Compare the
+	// middle of the second it denotes with the middle of the request.
+	server := date.Add(500 * time.Millisecond)
+	local := start.Add(end.Sub(start) / 2)
+	return server.Sub(local), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+func TestServerTimeOffset(t *testing.T) {
+	var path, label string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		label = r.Header.Get(internal.CostCenterHeader)
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "Permission denied"}`))
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+	var after []*OperationInfo
+	client.SetHooks(&Hooks{
+		After: func(ctx context.Context, info *OperationInfo) {
+			after = append(after, info)
+		},
+	})
+	defer client.SetHooks(nil)
+
+	ctx := internal.WithCostCenter(context.Background(), "clock-sync")
+	offset, err := client.ServerTimeOffset(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := offset - time.Hour; diff < -time.Second || diff > time.Second {
+		t.Errorf("ServerTimeOffset() = %v; want = %v", offset, time.Hour)
+	}
+	if !strings.HasPrefix(path, "/") || !strings.HasSuffix(path, ".json") || len(path) != 26 {
+		t.Errorf("ServerTimeOffset() = %q; want = /<push id>.json", path)
+	}
+	if label != "clock-sync" {
+		t.Errorf("%s = %q; want = %q", internal.CostCenterHeader, label, "clock-sync")
+	}
+	if len(after) != 1 || after[0].Operation != OperationGet || after[0].StatusCode != http.StatusUnauthorized {
+		t.Errorf("After = %v; want = 1 call with status %d", after, http.StatusUnauthorized)
+	}
+}
+
+func TestServerTimeOffsetError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Date"] = nil
+	}))
+	defer srv.Close()
+	client.dbURLConfig.BaseURL = srv.URL
+
+	if offset, err := client.ServerTimeOffset(context.Background()); err == nil {
+		t.Errorf("ServerTimeOffset() = (%v, nil); want = error", offset)
+	}
+}
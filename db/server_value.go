@@ -0,0 +1,39 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import "encoding/json"
+
+// ServerValue is a placeholder that the server replaces with a value computed when a write is
+// committed. Server values can be written with Set, Push and Update, either directly or nested in
+// the written data.
+type ServerValue struct {
+	value interface{}
+}
+
+// ServerTimestamp is replaced with the time at which the write is committed, in milliseconds
+// since the epoch.
+var ServerTimestamp = ServerValue{value: "timestamp"}
+
+// Increment returns a ServerValue that atomically increments the current value by delta. If the
+// current value is not a number, or does not exist, it is treated as 0.
+func Increment(delta float64) ServerValue {
+	return ServerValue{value: map[string]interface{}{"increment": delta}}
+}
+
+// MarshalJSON marshals a ServerValue into JSON (for internal use only).
+func (s ServerValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{".sv": s.value})
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestServerValueJSON(t *testing.T) {
+	cases := []struct {
+		value ServerValue
+		want  string
+	}{
+		{ServerTimestamp, `{".sv":"timestamp"}`},
+		{Increment(1), `{".sv":{"increment":1}}`},
+		{Increment(-2.5), `{".sv":{"increment":-2.5}}`},
+	}
+	for _, tc := range cases {
+		b, err := json.Marshal(tc.value)
+		if err != nil || string(b) != tc.want {
+			t.Errorf("Marshal() = (%s, %v); want = (%s, nil)", b, err, tc.want)
+		}
+	}
+}
+
+func TestSetServerValues(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	value := map[string]interface{}{
+		"lastSeen": ServerTimestamp,
+		"visits":   Increment(1),
+	}
+	if err := testref.Set(context.Background(), value); err != nil {
+		t.Fatal(err)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PUT",
+		Path:   "/peter.json",
+		Body:   []byte(`{"lastSeen":{".sv":"timestamp"},"visits":{".sv":{"increment":1}}}`),
+		Query:  map[string]string{"print": "silent"},
+	})
+}
+
+func TestUpdateServerValues(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	update := map[string]interface{}{
+		"stats/updatedAt": ServerTimestamp,
+		"stats/count":     Increment(-1),
+	}
+	if err := testref.Update(context.Background(), update); err != nil {
+		t.Fatal(err)
+	}
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PATCH",
+		Path:   "/peter.json",
+		Body:   []byte(`{"stats/count":{".sv":{"increment":-1}},"stats/updatedAt":{".sv":"timestamp"}}`),
+		Query:  map[string]string{"print": "silent"},
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"errors"
+	"hash/fnv"
+)
+
+// ShardSet distributes keys across a fixed set of database instances.
+//
+// Keys are assigned to shards using a stable hash, so a given key always maps to the same
+// Client as long as the set of shards, and their order, does not change. Adding or removing
+// shards remaps existing keys; callers are responsible for migrating data in that case.
+type ShardSet struct {
+	clients []*Client
+}
+
+// NewShardSet creates a new ShardSet from the given database clients.
+//
+// Clients are typically obtained by calling firebase.App.DatabaseWithURL once for each
+// database instance. The order of the clients determines the key assignment.
+func NewShardSet(clients ...*Client) (*ShardSet, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("at least one database client must be specified")
+	}
+	for _, c := range clients {
+		if c == nil {
+			return nil, errors.New("database client must not be nil")
+		}
+	}
+	return &ShardSet{
+		clients: append([]*Client{}, clients...),
+	}, nil
+}
+
+// Len returns the number of shards in the set.
+func (s *ShardSet) Len() int {
+	return len(s.clients)
+}
+
+// ShardIndex returns the index of the shard that the given key is assigned to.
+func (s *ShardSet) ShardIndex(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(s.clients)))
+}
+
+// Shard returns the Client for the shard that the given key is assigned to.
+func (s *ShardSet) Shard(key string) *Client {
+	return s.clients[s.ShardIndex(key)]
+}
+
+// NewRef returns a new database reference representing the node at the specified path, in the
+// shard that the given key is assigned to.
+func (s *ShardSet) NewRef(key, path string) *Ref {
+	return s.Shard(key).NewRef(path)
+}
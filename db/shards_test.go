@@ -0,0 +1,63 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewShardSet(t *testing.T) {
+	s, err := NewShardSet(client, aoClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d; want = 2", s.Len())
+	}
+
+	counts := make([]int, s.Len())
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("user%d", i)
+		idx := s.ShardIndex(key)
+		if idx != s.ShardIndex(key) {
+			t.Errorf("ShardIndex(%q) is not stable", key)
+		}
+		counts[idx]++
+
+		want := []*Client{client, aoClient}[idx]
+		if c := s.Shard(key); c != want {
+			t.Errorf("Shard(%q) = %p; want = %p", key, c, want)
+		}
+		ref := s.NewRef(key, "users/"+key)
+		if ref.client != want || ref.Path != "/users/"+key {
+			t.Errorf("NewRef(%q) = (%p, %q); want = (%p, %q)", key, ref.client, ref.Path, want, "/users/"+key)
+		}
+	}
+	for i, n := range counts {
+		if n == 0 {
+			t.Errorf("Shard %d received no keys", i)
+		}
+	}
+}
+
+func TestNewShardSetError(t *testing.T) {
+	if s, err := NewShardSet(); s != nil || err == nil {
+		t.Errorf("NewShardSet() = (%v, %v); want = (nil, error)", s, err)
+	}
+	if s, err := NewShardSet(client, nil); s != nil || err == nil {
+		t.Errorf("NewShardSet(nil) = (%v, %v); want = (nil, error)", s, err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxBatchedWrites = 500
+	defaultFlushInterval    = time.Second
+)
+
+// WriteBatcherConfig configures when a WriteBatcher flushes its pending writes.
+type WriteBatcherConfig struct {
+	// MaxWrites is the number of pending paths at which the writes are flushed. If zero, the writes
+	// are flushed once 500 paths are pending.
+	MaxWrites int
+
+	// FlushInterval is the maximum time for which writes are held before being flushed. If zero,
+	// the writes are flushed every second.
+	FlushInterval time.Duration
+
+	// OnError is called when a flush performed in the background fails, with the updates that
+	// could not be written. If not set, the first such error is returned by Close.
+	OnError func(err error, updates map[string]interface{})
+}
+
+// WriteBatcher coalesces writes to the descendants of a database location into multi-path updates.
+//
+// Instead of sending a request for each write, writes are held in memory and sent together as a
+// single Update of the common ancestor, once enough writes are pending or the flush interval has
+// elapsed. Repeated writes to the same path are coalesced, so that only the latest value is sent.
+// This significantly reduces the number of requests made by workloads that perform many small
+// writes, such as telemetry collection.
+//
+// Writes are applied to the database in the order in which they were made, but are not visible
+// to reads until flushed. A WriteBatcher is safe for concurrent use. Close must be called to flush
+// the remaining writes and release the resources of the WriteBatcher.
+type WriteBatcher struct {
+	ref    *Ref
+	config WriteBatcherConfig
+
+	mu       sync.Mutex
+	pending  map[string]json.RawMessage
+	closed   bool
+	firstErr error
+
+	flushMu sync.Mutex
+	trigger chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewWriteBatcher creates a WriteBatcher for the descendants of the current location.
+//
+// The config may be nil, in which case the default flush thresholds are used.
+func (r *Ref) NewWriteBatcher(config *WriteBatcherConfig) (*WriteBatcher, error) {
+	b := &WriteBatcher{
+		ref:     r,
+		pending: make(map[string]json.RawMessage),
+		trigger: make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if config != nil {
+		b.config = *config
+	}
+
+	if b.config.MaxWrites < 0 {
+		return nil, errors.New("max writes must not be negative")
+	} else if b.config.MaxWrites == 0 {
+		b.config.MaxWrites = defaultMaxBatchedWrites
+	}
+	if b.config.FlushInterval < 0 {
+		return nil, errors.New("flush interval must not be negative")
+	} else if b.config.FlushInterval == 0 {
+		b.config.FlushInterval = defaultFlushInterval
+	}
+
+	go b.run()
+	return b, nil
+}
+
+// Set schedules the value to be written at the given path, relative to the location of the
+// WriteBatcher.
+//
+// The path and the value are validated immediately, and an error is returned if the path contains
+// illegal characters or the value cannot be converted to JSON. Setting a nil value deletes the
+// data at the path.
+func (b *WriteBatcher) Set(path string, v interface{}) error {
+	segs := parsePath(path)
+	if len(segs) == 0 {
+		return errors.New("path must not be empty")
+	}
+	if strings.ContainsAny(path, invalidChars) {
+		return fmt.Errorf("invalid path with illegal characters: %q", path)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return errors.New("write batcher is closed")
+	}
+	if err := b.merge(segs, raw); err != nil {
+		return err
+	}
+	if len(b.pending) >= b.config.MaxWrites {
+		select {
+		case b.trigger <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Delete schedules the data at the given path, relative to the location of the WriteBatcher, to
+// be deleted.
+func (b *WriteBatcher) Delete(path string) error {
+	return b.Set(path, nil)
+}
+
+// Flush sends the pending writes immediately, and waits for them to be written.
+func (b *WriteBatcher) Flush(ctx context.Context) error {
+	_, err := b.flush(ctx)
+	return err
+}
+
+// Close flushes the pending writes, and stops the WriteBatcher. Writes made after Close are
+// rejected.
+//
+// Close returns the error of the final flush, or the first error of a background flush if no
+// OnError callback is configured.
+func (b *WriteBatcher) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.stop)
+	}
+	b.mu.Unlock()
+	<-b.done
+
+	err := b.Flush(ctx)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.firstErr != nil {
+		return b.firstErr
+	}
+	return err
+}
+
+func (b *WriteBatcher) run() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		case <-b.trigger:
+		}
+		b.backgroundFlush()
+	}
+}
+
+// flush sends the pending writes as a single update. Flushes are serialized, so that the writes
+// are applied in the order in which they were made. Returns the updates that were sent.
+func (b *WriteBatcher) flush(ctx context.Context) (map[string]interface{}, error) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[string]json.RawMessage)
+	b.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	updates := make(map[string]interface{}, len(pending))
+	for path, raw := range pending {
+		updates[path] = raw
+	}
+	return updates, b.ref.Update(ctx, updates)
+}
+
+func (b *WriteBatcher) backgroundFlush() {
+	if updates, err := b.flush(context.Background()); err != nil {
+		if b.config.OnError != nil {
+			b.config.OnError(err, updates)
+			return
+		}
+		b.mu.Lock()
+		if b.firstErr == nil {
+			b.firstErr = err
+		}
+		b.mu.Unlock()
+	}
+}
+
+// merge adds a write to the pending writes. A multi-path update must not contain a path along
+// with one of its ancestors, so writes to the ancestors of pending paths replace them, and writes
+// to the descendants of pending paths are applied to the pending value of the ancestor.
+func (b *WriteBatcher) merge(segs []string, raw json.RawMessage) error {
+	key := strings.Join(segs, "/")
+	for i := 1; i < len(segs); i++ {
+		ancestor := strings.Join(segs[:i], "/")
+		current, ok := b.pending[ancestor]
+		if !ok {
+			continue
+		}
+
+		// Numbers are kept as json.Number, so that large integers are not rounded to float64.
+		var node, value interface{}
+		if err := NumberDecoder.Unmarshal(current, &node); err != nil {
+			return err
+		}
+		if err := NumberDecoder.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		merged, err := json.Marshal(setDescendant(node, segs[i:], value))
+		if err != nil {
+			return err
+		}
+		b.pending[ancestor] = merged
+		return nil
+	}
+
+	for path := range b.pending {
+		if strings.HasPrefix(path, key+"/") {
+			delete(b.pending, path)
+		}
+	}
+	b.pending[key] = raw
+	return nil
+}
+
+// setDescendant sets the value at the given path under a node of a generic JSON tree, and returns
+// the updated node. Nodes that become empty are removed, as in the database.
+func setDescendant(node interface{}, segs []string, value interface{}) interface{} {
+	if len(segs) == 0 {
+		return value
+	}
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{})
+	}
+	if child := setDescendant(m[segs[0]], segs[1:], value); child != nil {
+		m[segs[0]] = child
+	} else {
+		delete(m, segs[0])
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestWriteBatcher(t *testing.T, config *WriteBatcherConfig) *WriteBatcher {
+	b, err := testref.NewWriteBatcher(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWriteBatcherFlush(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{FlushInterval: time.Hour})
+	writes := []struct {
+		path  string
+		value interface{}
+	}{
+		{"metrics/cpu", 10},
+		{"metrics/cpu", 20},
+		{"/metrics/mem/", 30},
+		{"status", "ok"},
+		{"old", nil},
+	}
+	for _, w := range writes {
+		if err := b.Set(w.path, w.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PATCH",
+		Path:   "/peter.json",
+		Body: serialize(map[string]interface{}{
+			"metrics/cpu": 20,
+			"metrics/mem": 30,
+			"status":      "ok",
+			"old":         nil,
+		}),
+		Query: map[string]string{"print": "silent"},
+	})
+}
+
+func TestWriteBatcherCoalescesAncestors(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{FlushInterval: time.Hour})
+	writes := []struct {
+		path  string
+		value interface{}
+	}{
+		// Replaced by the write to their ancestor.
+		{"devices/d1/temp", 10},
+		{"devices/d1/humidity", 40},
+		{"devices/d1", map[string]interface{}{"temp": 20, "humidity": 50}},
+		// Applied to the pending value of the ancestor.
+		{"devices/d1/temp", 25},
+		{"devices/d1/humidity", nil},
+		{"devices/d1/location/lat", 1.5},
+		// Turns a scalar into an object.
+		{"devices/d2", "offline"},
+		{"devices/d2/status", "online"},
+	}
+	for _, w := range writes {
+		if err := b.Set(w.path, w.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PATCH",
+		Path:   "/peter.json",
+		Body: serialize(map[string]interface{}{
+			"devices/d1": map[string]interface{}{
+				"temp":     25,
+				"location": map[string]interface{}{"lat": 1.5},
+			},
+			"devices/d2": map[string]interface{}{"status": "online"},
+		}),
+		Query: map[string]string{"print": "silent"},
+	})
+}
+
+func TestWriteBatcherMergePreservesLargeIntegers(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{FlushInterval: time.Hour})
+	if err := b.Set("counters", map[string]interface{}{"a": int64(9007199254740993)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Set("counters/b", int64(9007199254740995)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The request body is compared literally, since decoding it would round the integers.
+	want := `{"counters":{"a":9007199254740993,"b":9007199254740995}}`
+	if len(mock.Reqs) != 1 || string(mock.Reqs[0].Body) != want {
+		t.Errorf("Body = %v; want = %s", mock.Reqs, want)
+	}
+}
+
+func TestWriteBatcherDeleteLastChild(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{FlushInterval: time.Hour})
+	if err := b.Set("a", map[string]interface{}{"b": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Delete("a/b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PATCH",
+		Path:   "/peter.json",
+		Body:   serialize(map[string]interface{}{"a": nil}),
+		Query:  map[string]string{"print": "silent"},
+	})
+}
+
+func TestWriteBatcherMaxWrites(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{MaxWrites: 2, FlushInterval: time.Hour})
+	if err := b.Set("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Set("b", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the background flush triggered by the second write.
+	for {
+		b.mu.Lock()
+		n := len(b.pending)
+		b.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	checkOnlyRequest(t, mock.Reqs, &testReq{
+		Method: "PATCH",
+		Path:   "/peter.json",
+		Body:   serialize(map[string]interface{}{"a": 1, "b": 2}),
+		Query:  map[string]string{"print": "silent"},
+	})
+}
+
+func TestWriteBatcherFlushInterval(t *testing.T) {
+	mock := &mockServer{}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{FlushInterval: 10 * time.Millisecond})
+	if err := b.Set("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := b.Set("b", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	checkAllRequests(t, mock.Reqs, []*testReq{
+		{
+			Method: "PATCH",
+			Path:   "/peter.json",
+			Body:   serialize(map[string]interface{}{"a": 1}),
+			Query:  map[string]string{"print": "silent"},
+		},
+		{
+			Method: "PATCH",
+			Path:   "/peter.json",
+			Body:   serialize(map[string]interface{}{"b": 2}),
+			Query:  map[string]string{"print": "silent"},
+		},
+	})
+}
+
+func TestWriteBatcherBackgroundError(t *testing.T) {
+	mock := &mockServer{
+		Resp:   map[string]string{"error": "Permission denied"},
+		Status: http.StatusUnauthorized,
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{FlushInterval: 10 * time.Millisecond})
+	if err := b.Set("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := b.Close(context.Background()); !IsPermissionDenied(err) {
+		t.Errorf("Close() = %v; want = PermissionDenied", err)
+	}
+}
+
+func TestWriteBatcherOnError(t *testing.T) {
+	mock := &mockServer{
+		Resp:   map[string]string{"error": "Permission denied"},
+		Status: http.StatusUnauthorized,
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	var failed map[string]interface{}
+	b := newTestWriteBatcher(t, &WriteBatcherConfig{
+		MaxWrites:     1,
+		FlushInterval: time.Hour,
+		OnError: func(err error, updates map[string]interface{}) {
+			failed = updates
+		},
+	})
+	if err := b.Set("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := b.Close(context.Background()); err != nil {
+		t.Errorf("Close() = %v; want = nil", err)
+	}
+	if _, ok := failed["a"]; !ok || len(failed) != 1 {
+		t.Errorf("OnError() updates = %v; want = {a: 1}", failed)
+	}
+}
+
+func TestWriteBatcherInvalidWrites(t *testing.T) {
+	b := newTestWriteBatcher(t, nil)
+	if err := b.Set("", 1); err == nil {
+		t.Errorf("Set(empty path) = nil; want = error")
+	}
+	if err := b.Set("a/b.c", 1); err == nil {
+		t.Errorf("Set(illegal path) = nil; want = error")
+	}
+	if err := b.Set("a", func() {}); err == nil {
+		t.Errorf("Set(func) = nil; want = error")
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Set("a", 1); err == nil {
+		t.Errorf("Set() after Close() = nil; want = error")
+	}
+}
+
+func TestWriteBatcherInvalidConfig(t *testing.T) {
+	configs := []*WriteBatcherConfig{
+		{MaxWrites: -1},
+		{FlushInterval: -time.Second},
+	}
+	for _, config := range configs {
+		if b, err := testref.NewWriteBatcher(config); b != nil || err == nil {
+			t.Errorf("NewWriteBatcher(%#v) = (%v, %v); want = (nil, error)", config, b, err)
+		}
+	}
+}
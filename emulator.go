@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firebase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// EmulatorConfig configures an App to connect to the Firebase Local Emulator Suite.
+//
+// It is an alternative to the FIREBASE_AUTH_EMULATOR_HOST, FIREBASE_DATABASE_EMULATOR_HOST,
+// FIREBASE_STORAGE_EMULATOR_HOST, FIRESTORE_EMULATOR_HOST and FIREBASE_MESSAGING_EMULATOR_HOST
+// environment variables, which are shared by all the Apps in the process. Hosts set in the
+// EmulatorConfig take precedence over the environment variables. Hosts are specified in the
+// "host:port" format.
+type EmulatorConfig struct {
+	// AuthHost is the host of the Firebase Auth emulator.
+	AuthHost string
+	// DatabaseHost is the host of the Firebase Realtime Database emulator.
+	DatabaseHost string
+	// DatabaseNamespace is the name of the database to connect to in the Realtime Database
+	// emulator. If not specified, the name of the database in Config.DatabaseURL is used.
+	DatabaseNamespace string
+	// StorageHost is the host of the Cloud Storage for Firebase emulator.
+	StorageHost string
+	// FirestoreHost is the host of the Cloud Firestore emulator.
+	FirestoreHost string
+	// MessagingHost is the host of a server that emulates the Firebase Cloud Messaging APIs, such
+	// as the fake server provided by the messagingtest package. It is not discovered via HubHost.
+	MessagingHost string
+	// HubHost is the host of the Emulator Suite hub. When set, the hosts of the running emulators
+	// that are not explicitly specified are discovered from the hub when the App is created.
+	HubHost string
+}
+
+// emulatorInfo is an entry of the list of running emulators returned by the Emulator Suite hub.
+type emulatorInfo struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func (e *emulatorInfo) address() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+// resolve returns a copy of the config where the hosts that are not explicitly specified are
+// discovered from the hub, if a hub is configured.
+func (ec *EmulatorConfig) resolve(ctx context.Context) (*EmulatorConfig, error) {
+	if ec == nil {
+		return &EmulatorConfig{}, nil
+	}
+
+	result := *ec
+	if ec.HubHost == "" {
+		return &result, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/emulators", ec.HubHost), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover emulators from hub: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to discover emulators from hub: unexpected status code: %d", resp.StatusCode)
+	}
+
+	var emulators map[string]*emulatorInfo
+	if err := json.NewDecoder(resp.Body).Decode(&emulators); err != nil {
+		return nil, fmt.Errorf("failed to discover emulators from hub: %v", err)
+	}
+
+	discover := func(host *string, name string) {
+		if info, ok := emulators[name]; ok && info != nil && *host == "" {
+			*host = info.address()
+		}
+	}
+	discover(&result.AuthHost, "auth")
+	discover(&result.DatabaseHost, "database")
+	discover(&result.StorageHost, "storage")
+	discover(&result.FirestoreHost, "firestore")
+	return &result, nil
+}
+
+// databaseHost returns the Realtime Database emulator host in the format accepted by the db
+// package.
+func (ec *EmulatorConfig) databaseHost() string {
+	if ec.DatabaseHost == "" || ec.DatabaseNamespace == "" {
+		return ec.DatabaseHost
+	}
+	return fmt.Sprintf("%s?ns=%s", ec.DatabaseHost, ec.DatabaseNamespace)
+}
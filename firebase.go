@@ -23,6 +23,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"firebase.google.com/go/v4/appcheck"
@@ -31,6 +32,8 @@ import (
 	"firebase.google.com/go/v4/iid"
 	"firebase.google.com/go/v4/internal"
 	"firebase.google.com/go/v4/messaging"
+	"firebase.google.com/go/v4/projectmanagement"
+	"firebase.google.com/go/v4/remoteconfig"
 	"firebase.google.com/go/v4/storage"
 	"google.golang.org/api/option"
 	"google.golang.org/api/transport"
@@ -51,6 +54,11 @@ type App struct {
 	projectID        string
 	serviceAccountID string
 	storageBucket    string
+	jsonCodec        JSONCodec
+	emulators        *EmulatorConfig
+	warmUp           bool
+	endpoints        internal.EndpointOverrides
+	metricsFn        internal.MetricsFn
 	opts             []option.ClientOption
 }
 
@@ -61,6 +69,73 @@ type Config struct {
 	ProjectID        string                  `json:"projectId"`
 	ServiceAccountID string                  `json:"serviceAccountId"`
 	StorageBucket    string                  `json:"storageBucket"`
+
+	// JSONCodec overrides the encoding/json package when serializing FCM messages and other
+	// request payloads, and when decoding ID tokens and session cookies. It can only be set
+	// programmatically.
+	JSONCodec JSONCodec `json:"-"`
+
+	// Emulators configures the App to connect to the Firebase Local Emulator Suite, as an
+	// alternative to the emulator environment variables. It can only be set programmatically.
+	Emulators *EmulatorConfig `json:"-"`
+
+	// WarmUpConnections makes the Auth and Messaging clients connect to their backend services,
+	// and fetch an OAuth2 token, when they are created. This removes the connection setup latency
+	// from the first request made via each client, at the cost of slower client creation. It can
+	// only be set programmatically.
+	WarmUpConnections bool `json:"-"`
+
+	// EndpointOverrides maps service names to base URLs that replace the default endpoints of
+	// those services, for routing requests through proxies or regional gateways. The supported
+	// service names are "identitytoolkit" (Auth), "fcm" (Cloud Messaging, including topic
+	// management), "rtdb" (Realtime Database), "firebasedatabase" (Realtime Database management
+	// API), "appcheck" (App Check) and "remoteconfig" (Remote Config). A base URL replaces the
+	// scheme and host of the default endpoint, such as https://fcm.googleapis.com, and the usual
+	// API paths are appended to it.
+	//
+	// Emulators take precedence over endpoint overrides. It can only be set programmatically.
+	EndpointOverrides map[string]string `json:"-"`
+
+	// MetricsHook is called with the metrics of each HTTP request made by the Auth, Realtime
+	// Database, Cloud Messaging, Instance ID, Project Management, App Check and Remote Config
+	// clients, including the cost center label of the request context. It is called concurrently
+	// by all these clients, and must not block. It can only be set programmatically.
+	MetricsHook func(ctx context.Context, m *RequestMetrics) `json:"-"`
+}
+
+// RequestMetrics describes an HTTP request made by the SDK, as reported to Config.MetricsHook.
+type RequestMetrics struct {
+	// CostCenter is the cost center label the request context is tagged with via WithCostCenter,
+	// or an empty string.
+	CostCenter string
+
+	// Annotation is the annotation of the client that made the request, such as the one set via
+	// auth.Client.SetRequestAnnotation, or an empty string.
+	Annotation string
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// URL is the URL of the request.
+	URL string
+
+	// StatusCode is the HTTP status code of the response, or 0 if no response was received.
+	StatusCode int
+
+	// Latency is the time until the response headers were received, or the request failed.
+	Latency time.Duration
+
+	// Err is the error that prevented a response from being received, if any.
+	Err error
+}
+
+// JSONCodec encodes and decodes JSON payloads.
+//
+// Implementations must be safe for concurrent use, and must honor the json.Marshaler and
+// json.Unmarshaler interfaces as well as the standard struct field tags.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
 }
 
 // Auth returns an instance of auth.Client.
@@ -70,6 +145,11 @@ func (a *App) Auth(ctx context.Context) (*auth.Client, error) {
 		Opts:             a.opts,
 		ServiceAccountID: a.serviceAccountID,
 		Version:          Version,
+		JSONCodec:        a.jsonCodec,
+		EmulatorHost:     a.emulators.AuthHost,
+		WarmUp:           a.warmUp,
+		Endpoint:         a.endpoints.Get(internal.IdentityToolkitService),
+		MetricsFn:        a.metricsFn,
 	}
 	return auth.NewClient(ctx, conf)
 }
@@ -84,19 +164,38 @@ func (a *App) Database(ctx context.Context) (*db.Client, error) {
 // identified by the given URL.
 func (a *App) DatabaseWithURL(ctx context.Context, url string) (*db.Client, error) {
 	conf := &internal.DatabaseConfig{
-		AuthOverride: a.authOverride,
-		URL:          url,
-		Opts:         a.opts,
-		Version:      Version,
+		AuthOverride:       a.authOverride,
+		URL:                url,
+		Opts:               a.opts,
+		Version:            Version,
+		EmulatorHost:       a.emulators.databaseHost(),
+		Endpoint:           a.endpoints.Get(internal.RTDBService),
+		ManagementEndpoint: a.endpoints.Get(internal.RTDBManagementService),
+		MetricsFn:          a.metricsFn,
 	}
 	return db.NewClient(ctx, conf)
 }
 
+// DatabaseShards returns a db.ShardSet that distributes keys across the Firebase Databases
+// identified by the given URLs.
+func (a *App) DatabaseShards(ctx context.Context, urls ...string) (*db.ShardSet, error) {
+	var clients []*db.Client
+	for _, url := range urls {
+		c, err := a.DatabaseWithURL(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	return db.NewShardSet(clients...)
+}
+
 // Storage returns a new instance of storage.Client.
 func (a *App) Storage(ctx context.Context) (*storage.Client, error) {
 	conf := &internal.StorageConfig{
-		Opts:   a.opts,
-		Bucket: a.storageBucket,
+		Opts:         a.opts,
+		Bucket:       a.storageBucket,
+		EmulatorHost: a.emulators.StorageHost,
 	}
 	return storage.NewClient(ctx, conf)
 }
@@ -107,6 +206,10 @@ func (a *App) Firestore(ctx context.Context) (*firestore.Client, error) {
 	if a.projectID == "" {
 		return nil, errors.New("project id is required to access Firestore")
 	}
+	if host := a.emulators.FirestoreHost; host != "" {
+		// The credentials of the App are not used with the emulator.
+		return firestore.NewClient(ctx, a.projectID, internal.FirestoreEmulatorOptions(host)...)
+	}
 	return firestore.NewClient(ctx, a.projectID, a.opts...)
 }
 
@@ -115,6 +218,7 @@ func (a *App) InstanceID(ctx context.Context) (*iid.Client, error) {
 	conf := &internal.InstanceIDConfig{
 		ProjectID: a.projectID,
 		Opts:      a.opts,
+		MetricsFn: a.metricsFn,
 	}
 	return iid.NewClient(ctx, conf)
 }
@@ -122,21 +226,71 @@ func (a *App) InstanceID(ctx context.Context) (*iid.Client, error) {
 // Messaging returns an instance of messaging.Client.
 func (a *App) Messaging(ctx context.Context) (*messaging.Client, error) {
 	conf := &internal.MessagingConfig{
+		ProjectID:    a.projectID,
+		Opts:         a.opts,
+		Version:      Version,
+		JSONCodec:    a.jsonCodec,
+		WarmUp:       a.warmUp,
+		Endpoint:     a.endpoints.Get(internal.FCMService),
+		EmulatorHost: a.emulators.MessagingHost,
+		MetricsFn:    a.metricsFn,
+	}
+	return messaging.NewClient(ctx, conf)
+}
+
+// ProjectManagement returns an instance of projectmanagement.Client.
+func (a *App) ProjectManagement(ctx context.Context) (*projectmanagement.Client, error) {
+	conf := &internal.ProjectManagementConfig{
 		ProjectID: a.projectID,
 		Opts:      a.opts,
 		Version:   Version,
+		MetricsFn: a.metricsFn,
 	}
-	return messaging.NewClient(ctx, conf)
+	return projectmanagement.NewClient(ctx, conf)
 }
 
 // AppCheck returns an instance of appcheck.Client.
 func (a *App) AppCheck(ctx context.Context) (*appcheck.Client, error) {
 	conf := &internal.AppCheckConfig{
 		ProjectID: a.projectID,
+		Opts:      a.opts,
+		Version:   Version,
+		Endpoint:  a.endpoints.Get(internal.AppCheckService),
+		MetricsFn: a.metricsFn,
 	}
 	return appcheck.NewClient(ctx, conf)
 }
 
+// RemoteConfig returns an instance of remoteconfig.Client.
+func (a *App) RemoteConfig(ctx context.Context) (*remoteconfig.Client, error) {
+	conf := &internal.RemoteConfigConfig{
+		ProjectID: a.projectID,
+		Opts:      a.opts,
+		Version:   Version,
+		Endpoint:  a.endpoints.Get(internal.RemoteConfigService),
+		MetricsFn: a.metricsFn,
+	}
+	return remoteconfig.NewClient(ctx, conf)
+}
+
+// WithCostCenter returns a copy of the context tagged with the given cost center label, for
+// attributing API usage to teams or products.
+//
+// Requests made by the SDK with the returned context carry the label in the
+// X-Firebase-Cost-Center header, and the label is reported to Config.MetricsHook. Metrics
+// collected by an HTTP client passed via option.WithHTTPClient can also obtain the label from the
+// request header, or by calling CostCenter on the request context. Requests made via the Firestore
+// and Cloud Storage clients are not tagged.
+func WithCostCenter(ctx context.Context, label string) context.Context {
+	return internal.WithCostCenter(ctx, label)
+}
+
+// CostCenter returns the cost center label the context is tagged with via WithCostCenter, or an
+// empty string if the context is not tagged.
+func CostCenter(ctx context.Context) string {
+	return internal.CostCenter(ctx)
+}
+
 // NewApp creates a new App from the provided config and client options.
 //
 // If the client options contain a valid credential (a service account file, a refresh token
@@ -161,12 +315,34 @@ func NewApp(ctx context.Context, config *Config, opts ...option.ClientOption) (*
 		ao = *config.AuthOverride
 	}
 
+	emulators, err := config.Emulators.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := internal.NewEndpointOverrides(config.EndpointOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	var metricsFn internal.MetricsFn
+	if hook := config.MetricsHook; hook != nil {
+		metricsFn = func(ctx context.Context, m *internal.RequestMetrics) {
+			hook(ctx, (*RequestMetrics)(m))
+		}
+	}
+
 	return &App{
 		authOverride:     ao,
 		dbURL:            config.DatabaseURL,
 		projectID:        pid,
 		serviceAccountID: config.ServiceAccountID,
 		storageBucket:    config.StorageBucket,
+		jsonCodec:        config.JSONCodec,
+		emulators:        emulators,
+		warmUp:           config.WarmUpConnections,
+		endpoints:        endpoints,
+		metricsFn:        metricsFn,
 		opts:             o,
 	}, nil
 }
@@ -235,6 +235,13 @@ func TestDatabase(t *testing.T) {
 	if c, err := app.DatabaseWithURL(ctx, url); c == nil || err != nil {
 		t.Errorf("Database() = (%v, %v); want (db, nil)", c, err)
 	}
+	shards, err := app.DatabaseShards(ctx, conf.DatabaseURL, url)
+	if err != nil || shards.Len() != 2 {
+		t.Errorf("DatabaseShards() = (%v, %v); want (2 shards, nil)", shards, err)
+	}
+	if _, err := app.DatabaseShards(ctx); err == nil {
+		t.Errorf("DatabaseShards() = nil; want error")
+	}
 }
 
 func TestDatabaseAuthOverrides(t *testing.T) {
@@ -362,6 +369,360 @@ func TestMessaging(t *testing.T) {
 	}
 }
 
+func TestCostCenter(t *testing.T) {
+	ctx := context.Background()
+	if label := CostCenter(ctx); label != "" {
+		t.Errorf("CostCenter() = %q; want = %q", label, "")
+	}
+	if label := CostCenter(WithCostCenter(ctx, "team-a")); label != "team-a" {
+		t.Errorf("CostCenter() = %q; want = %q", label, "team-a")
+	}
+}
+
+func TestMetricsHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"value"`))
+	}))
+	defer ts.Close()
+
+	var metrics []*RequestMetrics
+	ctx := context.Background()
+	conf := &Config{
+		ProjectID: "mock-project-id",
+		Emulators: &EmulatorConfig{
+			DatabaseHost:      strings.TrimPrefix(ts.URL, "http://"),
+			DatabaseNamespace: "emulated-db",
+		},
+		MetricsHook: func(ctx context.Context, m *RequestMetrics) {
+			metrics = append(metrics, m)
+		},
+	}
+	app, err := NewApp(ctx, conf, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbClient, err := app.Database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var value string
+	if err := dbClient.NewRef("foo").Get(WithCostCenter(ctx, "team-a"), &value); err != nil {
+		t.Fatal(err)
+	}
+	if len(metrics) != 1 {
+		t.Fatalf("MetricsHook() calls = %d; want = 1", len(metrics))
+	}
+	if m := metrics[0]; m.CostCenter != "team-a" || m.Method != http.MethodGet ||
+		!strings.HasPrefix(m.URL, ts.URL+"/foo.json") || m.StatusCode != http.StatusOK || m.Err != nil {
+		t.Errorf("MetricsHook() = %#v; want = (team-a, GET, %s/foo.json, 200)", m, ts.URL)
+	}
+}
+
+func TestProjectManagement(t *testing.T) {
+	ctx := context.Background()
+	app, err := NewApp(ctx, nil, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := app.ProjectManagement(ctx); c == nil || err != nil {
+		t.Errorf("ProjectManagement() = (%v, %v); want (projectmanagement, nil)", c, err)
+	}
+}
+
+func TestRemoteConfig(t *testing.T) {
+	ctx := context.Background()
+	app, err := NewApp(ctx, nil, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := app.RemoteConfig(ctx); c == nil || err != nil {
+		t.Errorf("RemoteConfig() = (%v, %v); want (remoteconfig, nil)", c, err)
+	}
+}
+
+func TestEmulatorConfig(t *testing.T) {
+	var reqs []*http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "accounts:lookup") {
+			w.Write([]byte(`{"users": [{"localId": "user1"}]}`))
+			return
+		}
+		w.Write([]byte(`"value"`))
+	}))
+	defer ts.Close()
+
+	host := strings.TrimPrefix(ts.URL, "http://")
+	ctx := context.Background()
+	conf := &Config{
+		ProjectID:   "mock-project-id",
+		DatabaseURL: "https://mock-db.firebaseio.com",
+		Emulators: &EmulatorConfig{
+			AuthHost:          host,
+			DatabaseHost:      host,
+			DatabaseNamespace: "emulated-db",
+		},
+	}
+	app, err := NewApp(ctx, conf, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authClient, err := app.Auth(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authClient.GetUser(ctx, "user1"); err != nil {
+		t.Fatal(err)
+	}
+	wantPath := "/identitytoolkit.googleapis.com/v1/projects/mock-project-id/accounts:lookup"
+	if len(reqs) != 1 || reqs[0].URL.Path != wantPath {
+		t.Fatalf("Auth request = %v; want = %q", reqs, wantPath)
+	}
+	if h := reqs[0].Header.Get("Authorization"); h != "Bearer owner" {
+		t.Errorf("Authorization = %q; want = %q", h, "Bearer owner")
+	}
+
+	dbClient, err := app.Database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value string
+	if err := dbClient.NewRef("foo").Get(ctx, &value); err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 || reqs[1].URL.Path != "/foo.json" || reqs[1].URL.Query().Get("ns") != "emulated-db" {
+		t.Errorf("Database request = %v; want = /foo.json?ns=emulated-db", reqs[len(reqs)-1].URL)
+	}
+
+	if c, err := app.Storage(ctx); c == nil || err != nil {
+		t.Errorf("Storage() = (%v, %v); want (storage, nil)", c, err)
+	}
+}
+
+func TestEmulatorConfigDefaultDatabaseNamespace(t *testing.T) {
+	var reqs []*http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"value"`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	conf := &Config{
+		ProjectID:   "mock-project-id",
+		DatabaseURL: "https://mock-db.firebaseio.com",
+		Emulators: &EmulatorConfig{
+			// The host of a test server is an IP address (127.0.0.1:port).
+			DatabaseHost: strings.TrimPrefix(ts.URL, "http://"),
+		},
+	}
+	app, err := NewApp(ctx, conf, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbClient, err := app.Database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value string
+	if err := dbClient.NewRef("foo").Get(ctx, &value); err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 1 || reqs[0].URL.Query().Get("ns") != "mock-db" {
+		t.Errorf("Database request = %v; want = /foo.json?ns=mock-db", reqs)
+	}
+}
+
+func TestEmulatorConfigHub(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emulators" {
+			t.Errorf("Path = %q; want = %q", r.URL.Path, "/emulators")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"hub": {"name": "hub", "host": "127.0.0.1", "port": 4400},
+			"auth": {"name": "auth", "host": "127.0.0.1", "port": 9099},
+			"database": {"name": "database", "host": "127.0.0.1", "port": 9000},
+			"firestore": {"name": "firestore", "host": "::1", "port": 8080}
+		}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	conf := &Config{
+		ProjectID: "mock-project-id",
+		Emulators: &EmulatorConfig{
+			DatabaseHost:      "localhost:9001",
+			DatabaseNamespace: "emulated-db",
+			HubHost:           strings.TrimPrefix(ts.URL, "http://"),
+		},
+	}
+	app, err := NewApp(ctx, conf, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &EmulatorConfig{
+		AuthHost:          "127.0.0.1:9099",
+		DatabaseHost:      "localhost:9001",
+		DatabaseNamespace: "emulated-db",
+		FirestoreHost:     "[::1]:8080",
+		HubHost:           conf.Emulators.HubHost,
+	}
+	if !reflect.DeepEqual(app.emulators, want) {
+		t.Errorf("Emulators = %v; want = %v", app.emulators, want)
+	}
+	c, err := app.Firestore(ctx)
+	if c == nil || err != nil {
+		t.Fatalf("Firestore() = (%v, %v); want (firestore, nil)", c, err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() = %v; want = nil", err)
+	}
+}
+
+func TestEmulatorConfigHubError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	conf := &Config{
+		Emulators: &EmulatorConfig{HubHost: strings.TrimPrefix(ts.URL, "http://")},
+	}
+	app, err := NewApp(context.Background(), conf, option.WithCredentialsFile("testdata/service_account.json"))
+	if app != nil || err == nil {
+		t.Errorf("NewApp() = (%v, %v); want (nil, error)", app, err)
+	}
+}
+
+func TestEndpointOverrides(t *testing.T) {
+	var reqs []*http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "accounts:lookup"):
+			w.Write([]byte(`{"users": [{"localId": "user1"}]}`))
+		case strings.HasSuffix(r.URL.Path, "messages:send"):
+			w.Write([]byte(`{"name": "message-id"}`))
+		case r.URL.Path == "/v1beta/jwks":
+			w.Write([]byte(`{"keys": []}`))
+		case strings.HasSuffix(r.URL.Path, "/remoteConfig"):
+			w.Header().Set("ETag", "etag")
+			w.Write([]byte(`{}`))
+		case strings.Contains(r.URL.Path, "/instances/"):
+			w.Write([]byte(`{"name": "projects/123/locations/-/instances/mock-db"}`))
+		default:
+			w.Write([]byte(`"value"`))
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	conf := &Config{
+		ProjectID:   "mock-project-id",
+		DatabaseURL: "https://mock-db.firebaseio.com",
+		EndpointOverrides: map[string]string{
+			"identitytoolkit":  ts.URL + "/",
+			"fcm":              ts.URL,
+			"rtdb":             ts.URL,
+			"firebasedatabase": ts.URL,
+			"appcheck":         ts.URL,
+			"remoteconfig":     ts.URL,
+		},
+	}
+	app, err := NewApp(ctx, conf, option.WithTokenSource(&testTokenSource{AccessToken: "mock-token"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authClient, err := app.Auth(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authClient.GetUser(ctx, "user1"); err != nil {
+		t.Fatal(err)
+	}
+	wantPath := "/v1/projects/mock-project-id/accounts:lookup"
+	if got := reqs[len(reqs)-1].URL.Path; got != wantPath {
+		t.Errorf("Auth request = %q; want = %q", got, wantPath)
+	}
+
+	dbClient, err := app.Database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value string
+	if err := dbClient.NewRef("foo").Get(ctx, &value); err != nil {
+		t.Fatal(err)
+	}
+	if got := reqs[len(reqs)-1].URL; got.Path != "/foo.json" || got.Query().Get("ns") != "mock-db" {
+		t.Errorf("Database request = %v; want = /foo.json?ns=mock-db", got)
+	}
+	if _, err := dbClient.Instance(ctx); err != nil {
+		t.Fatal(err)
+	}
+	wantPath = "/v1beta/projects/-/locations/-/instances/mock-db"
+	if got := reqs[len(reqs)-1].URL.Path; got != wantPath {
+		t.Errorf("Database instance request = %q; want = %q", got, wantPath)
+	}
+
+	msgClient, err := app.Messaging(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := msgClient.Send(ctx, &messaging.Message{Topic: "topic"}); err != nil {
+		t.Fatal(err)
+	}
+	wantPath = "/v1/projects/mock-project-id/messages:send"
+	if got := reqs[len(reqs)-1].URL.Path; got != wantPath {
+		t.Errorf("Messaging request = %q; want = %q", got, wantPath)
+	}
+
+	if _, err := app.AppCheck(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := reqs[len(reqs)-1].URL.Path; got != "/v1beta/jwks" {
+		t.Errorf("AppCheck request = %q; want = %q", got, "/v1beta/jwks")
+	}
+
+	rcClient, err := app.RemoteConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rcClient.GetTemplate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	wantPath = "/v1/projects/mock-project-id/remoteConfig"
+	if got := reqs[len(reqs)-1].URL.Path; got != wantPath {
+		t.Errorf("RemoteConfig request = %q; want = %q", got, wantPath)
+	}
+}
+
+func TestInvalidEndpointOverrides(t *testing.T) {
+	cases := []map[string]string{
+		{"unknown": "https://example.com"},
+		{"fcm": "example.com"},
+		{"fcm": "ftp://example.com"},
+		{"rtdb": ""},
+	}
+	for _, tc := range cases {
+		app, err := NewApp(context.Background(), &Config{EndpointOverrides: tc})
+		if app != nil || err == nil {
+			t.Errorf("NewApp(%v) = (%v, %v); want = (nil, error)", tc, app, err)
+		}
+	}
+}
+
 func TestMessagingSendWithCustomEndpoint(t *testing.T) {
 	name := "custom-endpoint-ok"
 
@@ -121,6 +121,7 @@ func NewClient(ctx context.Context, c *internal.InstanceIDConfig) (*Client, erro
 	}
 
 	hc.CreateErrFn = createError
+	hc.MetricsFn = c.MetricsFn
 	return &Client{
 		endpoint: iidEndpoint,
 		client:   hc,
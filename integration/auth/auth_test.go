@@ -35,14 +35,14 @@ import (
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
 	"firebase.google.com/go/v4/auth/hash"
+	"firebase.google.com/go/v4/authtest"
 	"firebase.google.com/go/v4/integration/internal"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 )
 
 const (
-	verifyCustomTokenURL = "https://www.googleapis.com/identitytoolkit/v3/relyingparty/verifyCustomToken?key=%s"
-	verifyPasswordURL    = "https://www.googleapis.com/identitytoolkit/v3/relyingparty/verifyPassword?key=%s"
+	verifyPasswordURL = "https://www.googleapis.com/identitytoolkit/v3/relyingparty/verifyPassword?key=%s"
 )
 
 var client *auth.Client
@@ -251,30 +251,7 @@ func signInWithCustomToken(token string) (string, error) {
 }
 
 func signInWithCustomTokenForTenant(token string, tenantID string) (string, error) {
-	payload := map[string]interface{}{
-		"token":             token,
-		"returnSecureToken": true,
-	}
-	if tenantID != "" {
-		payload["tenantId"] = tenantID
-	}
-
-	req, err := json.Marshal(payload)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := postRequest(fmt.Sprintf(verifyCustomTokenURL, apiKey), req)
-	if err != nil {
-		return "", err
-	}
-	var respBody struct {
-		IDToken string `json:"idToken"`
-	}
-	if err := json.Unmarshal(resp, &respBody); err != nil {
-		return "", err
-	}
-	return respBody.IDToken, err
+	return authtest.SignInWithCustomToken(context.Background(), apiKey, token, tenantID)
 }
 
 func signInWithPassword(email, password string) (string, error) {
@@ -0,0 +1,33 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import "context"
+
+// CostCenterHeader is the HTTP header that carries the cost center label of a request.
+const CostCenterHeader = "X-Firebase-Cost-Center"
+
+type costCenterKey struct{}
+
+// WithCostCenter returns a copy of the context tagged with the given cost center label.
+func WithCostCenter(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, costCenterKey{}, label)
+}
+
+// CostCenter returns the cost center label the context is tagged with, or an empty string.
+func CostCenter(ctx context.Context) string {
+	label, _ := ctx.Value(costCenterKey{}).(string)
+	return label
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Names of the services whose base URLs can be overridden.
+const (
+	IdentityToolkitService = "identitytoolkit"
+	FCMService             = "fcm"
+	RTDBService            = "rtdb"
+	RTDBManagementService  = "firebasedatabase"
+	AppCheckService        = "appcheck"
+	RemoteConfigService    = "remoteconfig"
+)
+
+var overridableServices = map[string]bool{
+	IdentityToolkitService: true,
+	FCMService:             true,
+	RTDBService:            true,
+	RTDBManagementService:  true,
+	AppCheckService:        true,
+	RemoteConfigService:    true,
+}
+
+// EndpointOverrides maps service names to the base URLs that replace the default endpoints of
+// those services.
+type EndpointOverrides map[string]string
+
+// NewEndpointOverrides validates the given service name to base URL mappings, and returns them
+// with any trailing slashes removed from the URLs.
+func NewEndpointOverrides(overrides map[string]string) (EndpointOverrides, error) {
+	result := make(EndpointOverrides, len(overrides))
+	for service, baseURL := range overrides {
+		if !overridableServices[service] {
+			return nil, fmt.Errorf("endpoint override for unknown service: %q", service)
+		}
+		u, err := url.Parse(baseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("endpoint override for %q must be an absolute http or https URL: %q", service, baseURL)
+		}
+		result[service] = strings.TrimRight(baseURL, "/")
+	}
+	return result, nil
+}
+
+// Get returns the base URL configured for the given service, or an empty string if the service
+// endpoint is not overridden.
+func (e EndpointOverrides) Get(service string) string {
+	return e[service]
+}
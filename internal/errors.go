@@ -89,12 +89,21 @@ type FirebaseError struct {
 	String    string
 	Response  *http.Response
 	Ext       map[string]interface{}
+
+	// Details is an optional service-specific representation of the error, which can be
+	// obtained from the FirebaseError via errors.As.
+	Details error
 }
 
 func (fe *FirebaseError) Error() string {
 	return fe.String
 }
 
+// Unwrap returns the service-specific details of the error, if any.
+func (fe *FirebaseError) Unwrap() error {
+	return fe.Details
+}
+
 // HasPlatformErrorCode checks if the given error contains a specific error code.
 func HasPlatformErrorCode(err error, code ErrorCode) bool {
 	fe, ok := err.(*FirebaseError)
@@ -335,3 +335,18 @@ func TestErrorHTTPResponse(t *testing.T) {
 		t.Errorf("Unmarshal(Response.Body) = %v; want = {key: value}", m)
 	}
 }
+
+func TestFirebaseErrorDetails(t *testing.T) {
+	details := errors.New("details")
+	fe := &FirebaseError{ErrorCode: NotFound, String: "test error", Details: details}
+
+	if !errors.Is(fe, details) {
+		t.Errorf("errors.Is(FirebaseError, details) = false; want = true")
+	}
+	if got := errors.Unwrap(fe); got != details {
+		t.Errorf("Unwrap() = %v; want = %v", got, details)
+	}
+	if got := errors.Unwrap(&FirebaseError{}); got != nil {
+		t.Errorf("Unwrap() = %v; want = nil", got)
+	}
+}
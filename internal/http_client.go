@@ -22,6 +22,7 @@ import (
 	"io"
 	"io/ioutil"
 	"math"
+	"math/rand"
 	"net/http"
 	"strconv"
 	"time"
@@ -38,14 +39,40 @@ import (
 // Responses returned by HTTPClient can be easily unmarshalled as JSON.
 //
 // HTTPClient also handles automatically retrying failed HTTP requests.
+//
+// JSON entities and responses are serialized using the JSONCodec set on the client. If not set,
+// the encoding/json package is used.
+//
+// If a MetricsFn is set, it is called once the response headers of each HTTP request are received,
+// or the request fails. Retries are reported as separate requests.
 type HTTPClient struct {
 	Client      *http.Client
 	RetryConfig *RetryConfig
 	CreateErrFn CreateErrFn
 	SuccessFn   SuccessFn
 	Opts        []HTTPOption
+	Codec       JSONCodec
+	MetricsFn   MetricsFn
+}
+
+// RequestAnnotationHeader is the HTTP header that carries the annotation of a request, such as the
+// name of the service that made it.
+const RequestAnnotationHeader = "X-Firebase-Request-Annotation"
+
+// RequestMetrics describes an HTTP request sent by an HTTPClient.
+type RequestMetrics struct {
+	CostCenter string
+	Annotation string
+	Method     string
+	URL        string
+	StatusCode int
+	Latency    time.Duration
+	Err        error
 }
 
+// MetricsFn is a function that records the metrics of an HTTP request.
+type MetricsFn func(ctx context.Context, m *RequestMetrics)
+
 // SuccessFn is a function that checks if a Response indicates success.
 type SuccessFn func(r *Response) bool
 
@@ -135,10 +162,13 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 	var result *attemptResult
 
 	for retries := 0; ; retries++ {
-		hr, err := req.buildHTTPRequest(c.Opts)
+		hr, err := req.buildHTTPRequest(c.Opts, c.codec())
 		if err != nil {
 			return nil, err
 		}
+		if label := CostCenter(ctx); label != "" {
+			hr.Header.Set(CostCenterHeader, label)
+		}
 
 		result = c.attempt(ctx, hr, retries)
 		if !result.Retry {
@@ -153,6 +183,36 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 	return c.handleResult(req, result)
 }
 
+// DoStream executes the given Request, and returns the underlying HTTP response without reading
+// its body. The caller must close the body of the returned response.
+//
+// DoStream is meant for long-lived and large responses, and never retries the request. Responses
+// with a non-2xx status are read, and reported as errors using the CreateErrFn on the request or
+// on the client, as in Do. SuccessFn is not consulted.
+func (c *HTTPClient) DoStream(ctx context.Context, req *Request) (*http.Response, error) {
+	hr, err := req.buildHTTPRequest(c.Opts, c.codec())
+	if err != nil {
+		return nil, err
+	}
+	if label := CostCenter(ctx); label != "" {
+		hr.Header.Set(CostCenterHeader, label)
+	}
+
+	resp, err := c.send(ctx, hr)
+	if err != nil {
+		return nil, newFirebaseErrorTransport(err)
+	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+
+	ir, err := newResponse(resp)
+	if err != nil {
+		return nil, newFirebaseErrorTransport(err)
+	}
+	return nil, c.newError(req, ir)
+}
+
 // DoAndUnmarshal behaves similar to Do, but additionally unmarshals the response payload into
 // the given pointer.
 //
@@ -166,7 +226,7 @@ func (c *HTTPClient) DoAndUnmarshal(ctx context.Context, req *Request, v interfa
 	}
 
 	if v != nil {
-		if err := json.Unmarshal(resp.Body, v); err != nil {
+		if err := c.codec().Unmarshal(resp.Body, v); err != nil {
 			return nil, fmt.Errorf("error while parsing response: %v", err)
 		}
 	}
@@ -174,8 +234,15 @@ func (c *HTTPClient) DoAndUnmarshal(ctx context.Context, req *Request, v interfa
 	return resp, nil
 }
 
+func (c *HTTPClient) codec() JSONCodec {
+	if c.Codec != nil {
+		return c.Codec
+	}
+	return StdJSONCodec
+}
+
 func (c *HTTPClient) attempt(ctx context.Context, hr *http.Request, retries int) *attemptResult {
-	resp, err := c.Client.Do(hr.WithContext(ctx))
+	resp, err := c.send(ctx, hr)
 	result := &attemptResult{}
 	if err != nil {
 		result.Err = err
@@ -199,6 +266,27 @@ func (c *HTTPClient) attempt(ctx context.Context, hr *http.Request, retries int)
 	return result
 }
 
+// send sends the HTTP request, and reports its metrics to the MetricsFn of the client.
+func (c *HTTPClient) send(ctx context.Context, hr *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.Client.Do(hr.WithContext(ctx))
+	if c.MetricsFn != nil {
+		m := &RequestMetrics{
+			CostCenter: CostCenter(ctx),
+			Annotation: hr.Header.Get(RequestAnnotationHeader),
+			Method:     hr.Method,
+			URL:        hr.URL.String(),
+			Latency:    time.Since(start),
+			Err:        err,
+		}
+		if resp != nil {
+			m.StatusCode = resp.StatusCode
+		}
+		c.MetricsFn(ctx, m)
+	}
+	return resp, err
+}
+
 func (c *HTTPClient) handleResult(req *Request, result *attemptResult) (*Response, error) {
 	if result.Err != nil {
 		return nil, newFirebaseErrorTransport(result.Err)
@@ -255,10 +343,16 @@ func (r *attemptResult) waitForRetry(ctx context.Context) error {
 	return ctx.Err()
 }
 
-func (r *Request) buildHTTPRequest(opts []HTTPOption) (*http.Request, error) {
+func (r *Request) buildHTTPRequest(opts []HTTPOption, codec JSONCodec) (*http.Request, error) {
 	var data io.Reader
 	if r.Body != nil {
-		b, err := r.Body.Bytes()
+		var b []byte
+		var err error
+		if e, ok := r.Body.(*jsonEntity); ok {
+			b, err = codec.Marshal(e.Val)
+		} else {
+			b, err = r.Body.Bytes()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -362,11 +456,16 @@ func HasSuccessStatus(r *Response) bool {
 //
 // If MaxDelay is set, retries delay gets capped by that value. If the Retry-After header
 // requires a longer delay than MaxDelay, retries are not attempted.
+//
+// If Jitter is set, each backoff delay is randomly adjusted by up to that fraction of its value
+// (e.g. 0.2 for +/-20%), so that clients failing at the same time do not retry in lockstep.
+// Delays required by the Retry-After header are not adjusted.
 type RetryConfig struct {
 	MaxRetries       int
 	CheckForRetry    RetryCondition
 	ExpBackoffFactor float64
 	MaxDelay         *time.Duration
+	Jitter           float64
 }
 
 // RetryCondition determines if an HTTP request should be retried depending on its last outcome.
@@ -403,6 +502,9 @@ func (rc *RetryConfig) estimateDelayBeforeNextRetry(retries int) time.Duration {
 	}
 	delayInSeconds := int64(math.Pow(2, float64(retries)) * rc.ExpBackoffFactor)
 	estimatedDelay := time.Duration(delayInSeconds) * time.Second
+	if rc.Jitter > 0 {
+		estimatedDelay = time.Duration(float64(estimatedDelay) * (1 + rc.Jitter*(2*retryJitter()-1)))
+	}
 	if rc.MaxDelay != nil && estimatedDelay > *rc.MaxDelay {
 		estimatedDelay = *rc.MaxDelay
 	}
@@ -411,6 +513,9 @@ func (rc *RetryConfig) estimateDelayBeforeNextRetry(retries int) time.Duration {
 
 var retryTimeClock Clock = SystemClock
 
+// retryJitter returns a random number in [0, 1) used to jitter retry delays.
+var retryJitter = rand.Float64
+
 func parseRetryAfterHeader(resp *http.Response) time.Duration {
 	if resp == nil {
 		return 0
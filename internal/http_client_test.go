@@ -401,6 +401,246 @@ func TestUnmarshalError(t *testing.T) {
 	}
 }
 
+type countingCodec struct {
+	marshalled   int
+	unmarshalled int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshalled++
+	return StdJSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshalled++
+	return StdJSONCodec.Unmarshal(data, v)
+}
+
+func TestJSONCodec(t *testing.T) {
+	var body []byte
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"foo": "bar"}`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	codec := &countingCodec{}
+	client := &HTTPClient{
+		Client: http.DefaultClient,
+		Codec:  codec,
+	}
+	req := &Request{
+		Method: http.MethodPost,
+		URL:    server.URL,
+		Body:   NewJSONEntity(map[string]string{"key": "value"}),
+	}
+	var got map[string]string
+	if _, err := client.DoAndUnmarshal(context.Background(), req, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != `{"key":"value"}` {
+		t.Errorf("Body = %q; want = %q", string(body), `{"key":"value"}`)
+	}
+	if got["foo"] != "bar" {
+		t.Errorf("DoAndUnmarshal() = %v; want = {foo: bar}", got)
+	}
+	if codec.marshalled != 1 || codec.unmarshalled != 1 {
+		t.Errorf("Codec calls = (%d, %d); want = (1, 1)", codec.marshalled, codec.unmarshalled)
+	}
+}
+
+func TestCostCenter(t *testing.T) {
+	var headers []string
+	var labels []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(CostCenterHeader))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	hc := &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			labels = append(labels, CostCenter(r.Context()))
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}
+	client := &HTTPClient{Client: hc}
+	req := &Request{
+		Method: http.MethodGet,
+		URL:    server.URL,
+	}
+
+	ctx := context.Background()
+	if _, err := client.Do(WithCostCenter(ctx, "team-a"), req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"team-a", ""}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("Headers = %v; want = %v", headers, want)
+	}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("CostCenter() = %v; want = %v", labels, want)
+	}
+}
+
+func TestMetricsFn(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var metrics []*RequestMetrics
+	client := &HTTPClient{
+		Client: http.DefaultClient,
+		MetricsFn: func(ctx context.Context, m *RequestMetrics) {
+			metrics = append(metrics, m)
+		},
+	}
+
+	ctx := WithCostCenter(context.Background(), "team-a")
+	if _, err := client.Do(ctx, &Request{Method: http.MethodGet, URL: server.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(context.Background(), &Request{Method: http.MethodPost, URL: server.URL + "/missing"}); err == nil {
+		t.Fatal("Do() = nil; want = error")
+	}
+	resp, err := client.DoStream(ctx, &Request{Method: http.MethodGet, URL: server.URL + "/stream"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	want := []*RequestMetrics{
+		{CostCenter: "team-a", Method: http.MethodGet, URL: server.URL, StatusCode: http.StatusOK},
+		{CostCenter: "", Method: http.MethodPost, URL: server.URL + "/missing", StatusCode: http.StatusNotFound},
+		{CostCenter: "team-a", Method: http.MethodGet, URL: server.URL + "/stream", StatusCode: http.StatusOK},
+	}
+	if len(metrics) != len(want) {
+		t.Fatalf("MetricsFn() calls = %d; want = %d", len(metrics), len(want))
+	}
+	for i, m := range metrics {
+		if m.Latency <= 0 {
+			t.Errorf("MetricsFn()[%d].Latency = %v; want > 0", i, m.Latency)
+		}
+		m.Latency = 0
+		if !reflect.DeepEqual(m, want[i]) {
+			t.Errorf("MetricsFn()[%d] = %#v; want = %#v", i, m, want[i])
+		}
+	}
+}
+
+func TestMetricsFnTransportError(t *testing.T) {
+	var metrics []*RequestMetrics
+	client := &HTTPClient{
+		Client: &http.Client{
+			Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+		},
+		MetricsFn: func(ctx context.Context, m *RequestMetrics) {
+			metrics = append(metrics, m)
+		},
+	}
+
+	if _, err := client.Do(context.Background(), &Request{Method: http.MethodGet, URL: "https://example.com"}); err == nil {
+		t.Fatal("Do() = nil; want = error")
+	}
+	if len(metrics) != 1 || metrics[0].Err == nil || metrics[0].StatusCode != 0 {
+		t.Errorf("MetricsFn() = %v; want = 1 call with an error", metrics)
+	}
+}
+
+func TestDoStream(t *testing.T) {
+	var header, costCenter string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Test-Header")
+		costCenter = r.Header.Get(CostCenterHeader)
+		w.Write([]byte("streamed"))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := &HTTPClient{
+		Client: http.DefaultClient,
+		Opts: []HTTPOption{
+			WithHeader("Test-Header", "test-value"),
+		},
+	}
+	req := &Request{
+		Method: http.MethodGet,
+		URL:    server.URL,
+	}
+
+	resp, err := client.DoStream(WithCostCenter(context.Background(), "team-a"), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "streamed" {
+		t.Errorf("Body = %q; want = %q", string(b), "streamed")
+	}
+	if header != "test-value" {
+		t.Errorf("Test-Header = %q; want = %q", header, "test-value")
+	}
+	if costCenter != "team-a" {
+		t.Errorf("CostCenter = %q; want = %q", costCenter, "team-a")
+	}
+}
+
+func TestDoStreamError(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("{}"))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := WithDefaultRetryConfig(http.DefaultClient)
+	client.CreateErrFn = func(r *Response) error {
+		return fmt.Errorf("custom error with status: %d", r.Status)
+	}
+	req := &Request{
+		Method: http.MethodGet,
+		URL:    server.URL,
+	}
+	want := "custom error with status: 503"
+
+	resp, err := client.DoStream(context.Background(), req)
+	if resp != nil || err == nil || err.Error() != want {
+		t.Fatalf("DoStream() = (%v, %v); want = (nil, %q)", resp, err, want)
+	}
+	if requests != 1 {
+		t.Errorf("Requests = %d; want = 1", requests)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
 func TestRetryDisabled(t *testing.T) {
 	requests := 0
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -615,6 +855,40 @@ func TestMaxDelayWithExpBackoff(t *testing.T) {
 	}
 }
 
+func TestRetryDelayWithJitter(t *testing.T) {
+	defer func(f func() float64) { retryJitter = f }(retryJitter)
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+	}
+	rc := &RetryConfig{
+		MaxRetries:       4,
+		ExpBackoffFactor: 1,
+		Jitter:           0.5,
+	}
+
+	// Delays are scaled by 1 + Jitter * (2 * r - 1) for a random r in [0, 1).
+	cases := []struct {
+		random float64
+		want   time.Duration
+	}{
+		{0, 1 * time.Second},
+		{0.5, 2 * time.Second},
+		{0.75, 2500 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		retryJitter = func() float64 { return tc.random }
+		delay, ok := rc.retryDelay(1, resp, nil)
+		if !ok || delay != tc.want {
+			t.Errorf("retryDelay(1) with jitter %f = (%v, %v); want = (%v, true)", tc.random, delay, ok, tc.want)
+		}
+	}
+
+	// The initial retry is never delayed.
+	if delay, ok := rc.retryDelay(0, resp, nil); !ok || delay != 0 {
+		t.Errorf("retryDelay(0) = (%v, %v); want = (0, true)", delay, ok)
+	}
+}
+
 func TestRetryDelayDisableExponentialBackoff(t *testing.T) {
 	resp := &http.Response{
 		StatusCode: http.StatusServiceUnavailable,
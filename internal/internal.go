@@ -16,6 +16,7 @@
 package internal
 
 import (
+	"encoding/json"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -41,6 +42,11 @@ type AuthConfig struct {
 	ProjectID        string
 	ServiceAccountID string
 	Version          string
+	JSONCodec        JSONCodec
+	EmulatorHost     string
+	WarmUp           bool
+	Endpoint         string
+	MetricsFn        MetricsFn
 }
 
 // HashConfig represents a hash algorithm configuration used to generate password hashes.
@@ -50,32 +56,64 @@ type HashConfig map[string]interface{}
 type InstanceIDConfig struct {
 	Opts      []option.ClientOption
 	ProjectID string
+	MetricsFn MetricsFn
 }
 
 // DatabaseConfig represents the configuration of Firebase Database service.
 type DatabaseConfig struct {
-	Opts         []option.ClientOption
-	URL          string
-	Version      string
-	AuthOverride map[string]interface{}
+	Opts               []option.ClientOption
+	URL                string
+	Version            string
+	AuthOverride       map[string]interface{}
+	EmulatorHost       string
+	Endpoint           string
+	ManagementEndpoint string
+	MetricsFn          MetricsFn
 }
 
 // StorageConfig represents the configuration of Google Cloud Storage service.
 type StorageConfig struct {
-	Opts   []option.ClientOption
-	Bucket string
+	Opts         []option.ClientOption
+	Bucket       string
+	EmulatorHost string
 }
 
 // MessagingConfig represents the configuration of Firebase Cloud Messaging service.
 type MessagingConfig struct {
+	Opts         []option.ClientOption
+	ProjectID    string
+	Version      string
+	JSONCodec    JSONCodec
+	WarmUp       bool
+	Endpoint     string
+	EmulatorHost string
+	MetricsFn    MetricsFn
+}
+
+// ProjectManagementConfig represents the configuration of Firebase Project Management service.
+type ProjectManagementConfig struct {
 	Opts      []option.ClientOption
 	ProjectID string
 	Version   string
+	MetricsFn MetricsFn
 }
 
 // AppCheckConfig represents the configuration of App Check service.
 type AppCheckConfig struct {
 	ProjectID string
+	Opts      []option.ClientOption
+	Version   string
+	Endpoint  string
+	MetricsFn MetricsFn
+}
+
+// RemoteConfigConfig represents the configuration of Firebase Remote Config service.
+type RemoteConfigConfig struct {
+	ProjectID string
+	Opts      []option.ClientOption
+	Version   string
+	Endpoint  string
+	MetricsFn MetricsFn
 }
 
 // MockTokenSource is a TokenSource implementation that can be used for testing.
@@ -88,6 +126,25 @@ func (ts *MockTokenSource) Token() (*oauth2.Token, error) {
 	return &oauth2.Token{AccessToken: ts.AccessToken}, nil
 }
 
+// JSONCodec encodes and decodes JSON payloads.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// StdJSONCodec is a JSONCodec backed by the encoding/json package.
+var StdJSONCodec JSONCodec = stdJSONCodec{}
+
+type stdJSONCodec struct{}
+
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
 // Clock is used to query the current local time.
 type Clock interface {
 	Now() time.Time
@@ -0,0 +1,51 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// warmUpTimeout is the maximum time a warm-up request may delay the creation of a client.
+var warmUpTimeout = 5 * time.Second
+
+// WarmUp makes a lightweight request to the given endpoint using the provided client, so that
+// the connection to the endpoint is established, and the OAuth2 token of the client is fetched,
+// before the client is first used.
+//
+// The response to the request is discarded, and the connection is kept in the connection pool of
+// the client for subsequent requests. An error is returned only if the request could not be
+// made. The request is abandoned after a few seconds, even if the given context has no deadline.
+func WarmUp(ctx context.Context, hc *http.Client, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, warmUpTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	// The body must be read to completion for the connection to be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.Body.Close()
+}
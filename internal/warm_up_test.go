@@ -0,0 +1,86 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWarmUp(t *testing.T) {
+	var methods []string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`{}`))
+	}))
+	var conns int32
+	ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	ctx := context.Background()
+	client := &HTTPClient{Client: http.DefaultClient}
+	if err := WarmUp(ctx, client.Client, ts.URL); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(ctx, &Request{Method: http.MethodGet, URL: ts.URL}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(methods) != 2 || methods[0] != http.MethodHead {
+		t.Errorf("Methods = %v; want = [HEAD GET]", methods)
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Errorf("Connections = %d; want = 1", n)
+	}
+}
+
+func TestWarmUpError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	if err := WarmUp(context.Background(), http.DefaultClient, ts.URL); err == nil {
+		t.Errorf("WarmUp() = nil; want = error")
+	}
+}
+
+func TestWarmUpTimeout(t *testing.T) {
+	defer func(d time.Duration) { warmUpTimeout = d }(warmUpTimeout)
+	warmUpTimeout = 50 * time.Millisecond
+
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	start := time.Now()
+	if err := WarmUp(context.Background(), http.DefaultClient, ts.URL); err == nil {
+		t.Errorf("WarmUp() = nil; want = error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("WarmUp() took %v; want < 5s", elapsed)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	iidImport           = "batchImport"
+	maxAPNSTokensImport = 100
+	apnsImportStatusOK  = "OK"
+)
+
+// APNSTokenImportResponse is the result produced by ImportAPNSTokens.
+//
+// Results contains an entry for each input APNs token, in the same order as the input.
+type APNSTokenImportResponse struct {
+	SuccessCount int
+	FailureCount int
+	Results      []*APNSTokenImportResult
+}
+
+// APNSTokenImportResult represents the outcome of importing a single APNs token.
+//
+// RegistrationToken is only set when the import was successful. Otherwise Status contains the
+// reason for the failure.
+type APNSTokenImportResult struct {
+	APNSToken         string `json:"apns_token"`
+	RegistrationToken string `json:"registration_token"`
+	Status            string `json:"status"`
+}
+
+// Success indicates whether the APNs token was imported successfully.
+func (r *APNSTokenImportResult) Success() bool {
+	return r.Status == apnsImportStatusOK
+}
+
+type apnsImportRequest struct {
+	Application string   `json:"application"`
+	Sandbox     bool     `json:"sandbox"`
+	APNSTokens  []string `json:"apns_tokens"`
+}
+
+// ImportAPNSTokens maps a list of raw APNs device tokens to FCM registration tokens.
+//
+// The bundleID identifies the iOS app the tokens were issued to. FCM delivers messages sent to
+// the resulting registration tokens via the APNs sandbox environment when sandbox is true, and via
+// the production environment otherwise. This allows development and staging builds, which
+// receive their device tokens from the APNs sandbox, to be targeted from the same Firebase
+// project as production builds.
+//
+// The tokens list must not be empty, and have at most 100 tokens.
+func (c *iidClient) ImportAPNSTokens(ctx context.Context, bundleID string, sandbox bool, tokens []string) (*APNSTokenImportResponse, error) {
+	if bundleID == "" {
+		return nil, fmt.Errorf("bundle id not specified")
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens specified")
+	}
+	if len(tokens) > maxAPNSTokensImport {
+		return nil, fmt.Errorf("tokens list must not contain more than %d items", maxAPNSTokensImport)
+	}
+	for _, token := range tokens {
+		if token == "" {
+			return nil, fmt.Errorf("tokens list must not contain empty strings")
+		}
+	}
+
+	request := &internal.Request{
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s:%s", c.iidEndpoint, iidImport),
+		Body: internal.NewJSONEntity(&apnsImportRequest{
+			Application: bundleID,
+			Sandbox:     sandbox,
+			APNSTokens:  tokens,
+		}),
+	}
+	var result struct {
+		Results []*APNSTokenImportResult `json:"results"`
+	}
+	if _, err := c.httpClient.DoAndUnmarshal(ctx, request, &result); err != nil {
+		return nil, err
+	}
+
+	resp := &APNSTokenImportResponse{
+		Results: result.Results,
+	}
+	for _, r := range result.Results {
+		if r.Success() {
+			resp.SuccessCount++
+		} else {
+			resp.FailureCount++
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportAPNSTokens(t *testing.T) {
+	var tr *http.Request
+	var b []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr = r
+		b, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": [
+			{"apns_token": "apns1", "status": "OK", "registration_token": "reg1"},
+			{"apns_token": "apns2", "status": "Internal Server Error"}
+		]}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.iidEndpoint = ts.URL + "/v1"
+
+	resp, err := client.ImportAPNSTokens(ctx, "com.example.app", true, []string{"apns1", "apns2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"application": "com.example.app",
+		"sandbox":     true,
+		"apns_tokens": []interface{}{"apns1", "apns2"},
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Errorf("Body = %#v; want = %#v", parsed, want)
+	}
+	if tr.URL.Path != "/v1:batchImport" {
+		t.Errorf("Path = %q; want = %q", tr.URL.Path, "/v1:batchImport")
+	}
+
+	if resp.SuccessCount != 1 || resp.FailureCount != 1 {
+		t.Errorf("ImportAPNSTokens() = (%d, %d); want = (1, 1)", resp.SuccessCount, resp.FailureCount)
+	}
+	wantResults := []*APNSTokenImportResult{
+		{APNSToken: "apns1", RegistrationToken: "reg1", Status: "OK"},
+		{APNSToken: "apns2", Status: "Internal Server Error"},
+	}
+	if !reflect.DeepEqual(resp.Results, wantResults) {
+		t.Errorf("Results = %v; want = %v", resp.Results, wantResults)
+	}
+}
+
+func TestInvalidImportAPNSTokens(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tooMany []string
+	for i := 0; i < 101; i++ {
+		tooMany = append(tooMany, "token")
+	}
+	cases := []struct {
+		name     string
+		bundleID string
+		tokens   []string
+		want     string
+	}{
+		{"NoBundleID", "", []string{"token"}, "bundle id not specified"},
+		{"NoTokens", "com.example.app", nil, "no tokens specified"},
+		{"TooManyTokens", "com.example.app", tooMany, "tokens list must not contain more than 100 items"},
+		{"EmptyToken", "com.example.app", []string{"token", ""}, "tokens list must not contain empty strings"},
+	}
+	for _, tc := range cases {
+		resp, err := client.ImportAPNSTokens(ctx, tc.bundleID, false, tc.tokens)
+		if resp != nil || err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ImportAPNSTokens(%s) = (%v, %v); want = (nil, %q)", tc.name, resp, err, tc.want)
+		}
+	}
+}
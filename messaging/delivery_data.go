@@ -0,0 +1,253 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeliveryEvent is the type of a message delivery event reported by FCM.
+type DeliveryEvent string
+
+const (
+	// DeliveryEventMessageAccepted indicates that the message was received by FCM.
+	DeliveryEventMessageAccepted DeliveryEvent = "MESSAGE_ACCEPTED"
+
+	// DeliveryEventMessageDelivered indicates that the message was delivered to the device.
+	DeliveryEventMessageDelivered DeliveryEvent = "MESSAGE_DELIVERED"
+
+	// DeliveryEventMissedTTL indicates that the message expired before the device came online.
+	DeliveryEventMissedTTL DeliveryEvent = "MISSED_TTL"
+
+	// DeliveryEventDroppedTooManyPendingMessages indicates that the message was dropped because
+	// too many messages were pending for the device.
+	DeliveryEventDroppedTooManyPendingMessages DeliveryEvent = "DROPPED_TOO_MANY_PENDING_MESSAGES"
+
+	// DeliveryEventDroppedAppForceStopped indicates that the message was dropped because the app
+	// was force stopped on the device.
+	DeliveryEventDroppedAppForceStopped DeliveryEvent = "DROPPED_APP_FORCE_STOPPED"
+
+	// DeliveryEventDroppedDeviceInactive indicates that the message was dropped because the
+	// device had been inactive for too long.
+	DeliveryEventDroppedDeviceInactive DeliveryEvent = "DROPPED_DEVICE_INACTIVE"
+
+	// DeliveryEventDroppedTTLExpired indicates that the message was dropped because its TTL
+	// expired while it was pending.
+	DeliveryEventDroppedTTLExpired DeliveryEvent = "DROPPED_TTL_EXPIRED"
+)
+
+// DeliveryRecord is a message delivery event, in the format FCM exports delivery data to
+// BigQuery, and in which such rows are typically forwarded to webhooks.
+//
+// Both the BigQuery JSON export format, where integers and timestamps are encoded as strings, and
+// plain JSON numbers are accepted. Fields that are absent from a record are left at their zero
+// values.
+type DeliveryRecord struct {
+	EventTimestamp       time.Time
+	ProjectNumber        int64
+	MessageID            string
+	InstanceID           string
+	MessageType          string // "DISPLAY_NOTIFICATION" or "DATA_MESSAGE"
+	SDKPlatform          string // "ANDROID", "IOS" or "WEB"
+	AppName              string
+	CollapseKey          string
+	Priority             int
+	TTL                  time.Duration
+	Topic                string
+	BulkID               int64
+	DeviceRecentlyActive bool
+	Event                DeliveryEvent
+	AnalyticsLabel       string
+}
+
+// UnmarshalJSON unmarshals a JSON row into a DeliveryRecord.
+func (r *DeliveryRecord) UnmarshalJSON(b []byte) error {
+	var temp struct {
+		EventTimestamp       deliveryValue `json:"event_timestamp"`
+		ProjectNumber        deliveryValue `json:"project_number"`
+		MessageID            string        `json:"message_id"`
+		InstanceID           string        `json:"instance_id"`
+		MessageType          string        `json:"message_type"`
+		SDKPlatform          string        `json:"sdk_platform"`
+		AppName              string        `json:"app_name"`
+		CollapseKey          string        `json:"collapse_key"`
+		Priority             deliveryValue `json:"priority"`
+		TTL                  deliveryValue `json:"ttl"`
+		Topic                string        `json:"topic"`
+		BulkID               deliveryValue `json:"bulk_id"`
+		DeviceRecentlyActive deliveryValue `json:"device_recently_active"`
+		Event                DeliveryEvent `json:"event"`
+		AnalyticsLabel       string        `json:"analytics_label"`
+	}
+	if err := json.Unmarshal(b, &temp); err != nil {
+		return err
+	}
+
+	ts, err := temp.EventTimestamp.timestamp()
+	if err != nil {
+		return fmt.Errorf("invalid event_timestamp: %v", err)
+	}
+	projectNumber, err := temp.ProjectNumber.int()
+	if err != nil {
+		return fmt.Errorf("invalid project_number: %v", err)
+	}
+	priority, err := temp.Priority.int()
+	if err != nil {
+		return fmt.Errorf("invalid priority: %v", err)
+	}
+	ttl, err := temp.TTL.int()
+	if err != nil {
+		return fmt.Errorf("invalid ttl: %v", err)
+	}
+	bulkID, err := temp.BulkID.int()
+	if err != nil {
+		return fmt.Errorf("invalid bulk_id: %v", err)
+	}
+	recentlyActive, err := temp.DeviceRecentlyActive.bool()
+	if err != nil {
+		return fmt.Errorf("invalid device_recently_active: %v", err)
+	}
+
+	*r = DeliveryRecord{
+		EventTimestamp:       ts,
+		ProjectNumber:        projectNumber,
+		MessageID:            temp.MessageID,
+		InstanceID:           temp.InstanceID,
+		MessageType:          temp.MessageType,
+		SDKPlatform:          temp.SDKPlatform,
+		AppName:              temp.AppName,
+		CollapseKey:          temp.CollapseKey,
+		Priority:             int(priority),
+		TTL:                  time.Duration(ttl) * time.Second,
+		Topic:                temp.Topic,
+		BulkID:               bulkID,
+		DeviceRecentlyActive: recentlyActive,
+		Event:                temp.Event,
+		AnalyticsLabel:       temp.AnalyticsLabel,
+	}
+	return nil
+}
+
+// DecodeDeliveryRecords decodes delivery records from newline-delimited JSON, as produced by
+// BigQuery exports, or from a JSON array of records. Empty lines are skipped.
+func DecodeDeliveryRecords(r io.Reader) ([]*DeliveryRecord, error) {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if first == '[' {
+		var records []*DeliveryRecord
+		if err := json.NewDecoder(br).Decode(&records); err != nil {
+			return nil, err
+		}
+		return records, nil
+	}
+
+	var records []*DeliveryRecord
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		record := &DeliveryRecord{}
+		if err := json.Unmarshal(b, record); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b[0])) {
+			return b[0], nil
+		}
+		br.ReadByte()
+	}
+}
+
+// bigQueryTimestampLayouts are the layouts in which BigQuery renders TIMESTAMP values.
+var bigQueryTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 UTC",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// deliveryValue is a scalar JSON value that may be encoded either natively or as a string.
+type deliveryValue struct {
+	raw string
+}
+
+func (v *deliveryValue) UnmarshalJSON(b []byte) error {
+	if s := string(b); s != "null" {
+		if strings.HasPrefix(s, `"`) {
+			return json.Unmarshal(b, &v.raw)
+		}
+		v.raw = s
+	}
+	return nil
+}
+
+func (v deliveryValue) int() (int64, error) {
+	if v.raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v.raw, 10, 64)
+}
+
+func (v deliveryValue) bool() (bool, error) {
+	if v.raw == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v.raw)
+}
+
+// timestamp parses a timestamp rendered by BigQuery, or a number of microseconds since the epoch.
+func (v deliveryValue) timestamp() (time.Time, error) {
+	if v.raw == "" {
+		return time.Time{}, nil
+	}
+	if micros, err := strconv.ParseInt(v.raw, 10, 64); err == nil {
+		return time.UnixMicro(micros).UTC(), nil
+	}
+	for _, layout := range bigQueryTimestampLayouts {
+		if t, err := time.Parse(layout, v.raw); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp format: %q", v.raw)
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testDeliveryRecord = &DeliveryRecord{
+	EventTimestamp:       time.Date(2026, 3, 1, 12, 30, 45, 123456000, time.UTC),
+	ProjectNumber:        1234567890,
+	MessageID:            "0:1234%abcd",
+	InstanceID:           "instance-id",
+	MessageType:          "DISPLAY_NOTIFICATION",
+	SDKPlatform:          "ANDROID",
+	AppName:              "com.example.app",
+	CollapseKey:          "collapse",
+	Priority:             10,
+	TTL:                  time.Hour,
+	Topic:                "news",
+	BulkID:               42,
+	DeviceRecentlyActive: true,
+	Event:                DeliveryEventMessageDelivered,
+	AnalyticsLabel:       "campaign",
+}
+
+func TestDeliveryRecordBigQueryFormat(t *testing.T) {
+	row := `{
+		"event_timestamp": "2026-03-01 12:30:45.123456 UTC",
+		"project_number": "1234567890",
+		"message_id": "0:1234%abcd",
+		"instance_id": "instance-id",
+		"message_type": "DISPLAY_NOTIFICATION",
+		"sdk_platform": "ANDROID",
+		"app_name": "com.example.app",
+		"collapse_key": "collapse",
+		"priority": "10",
+		"ttl": "3600",
+		"topic": "news",
+		"bulk_id": "42",
+		"device_recently_active": "true",
+		"event": "MESSAGE_DELIVERED",
+		"analytics_label": "campaign"
+	}`
+	var record DeliveryRecord
+	if err := json.Unmarshal([]byte(row), &record); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&record, testDeliveryRecord) {
+		t.Errorf("Unmarshal() = %#v; want = %#v", record, testDeliveryRecord)
+	}
+}
+
+func TestDeliveryRecordNativeFormat(t *testing.T) {
+	row := `{
+		"event_timestamp": "2026-03-01T12:30:45.123456Z",
+		"project_number": 1234567890,
+		"message_id": "0:1234%abcd",
+		"instance_id": "instance-id",
+		"message_type": "DISPLAY_NOTIFICATION",
+		"sdk_platform": "ANDROID",
+		"app_name": "com.example.app",
+		"collapse_key": "collapse",
+		"priority": 10,
+		"ttl": 3600,
+		"topic": "news",
+		"bulk_id": 42,
+		"device_recently_active": true,
+		"event": "MESSAGE_DELIVERED",
+		"analytics_label": "campaign",
+		"unknown_field": "ignored"
+	}`
+	var record DeliveryRecord
+	if err := json.Unmarshal([]byte(row), &record); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&record, testDeliveryRecord) {
+		t.Errorf("Unmarshal() = %#v; want = %#v", record, testDeliveryRecord)
+	}
+}
+
+func TestDeliveryRecordMicrosTimestamp(t *testing.T) {
+	var record DeliveryRecord
+	if err := json.Unmarshal([]byte(`{"event_timestamp": 1772368245123456, "ttl": null}`), &record); err != nil {
+		t.Fatal(err)
+	}
+	if !record.EventTimestamp.Equal(testDeliveryRecord.EventTimestamp) {
+		t.Errorf("EventTimestamp = %v; want = %v", record.EventTimestamp, testDeliveryRecord.EventTimestamp)
+	}
+	if record.TTL != 0 {
+		t.Errorf("TTL = %v; want = 0", record.TTL)
+	}
+}
+
+func TestDeliveryRecordInvalid(t *testing.T) {
+	rows := []string{
+		`{"event_timestamp": "yesterday"}`,
+		`{"project_number": "abc"}`,
+		`{"priority": "high"}`,
+		`{"ttl": 1.5}`,
+		`{"bulk_id": true}`,
+		`{"device_recently_active": "maybe"}`,
+		`[]`,
+	}
+	for _, row := range rows {
+		var record DeliveryRecord
+		if err := json.Unmarshal([]byte(row), &record); err == nil {
+			t.Errorf("Unmarshal(%s) = nil; want = error", row)
+		}
+	}
+}
+
+func TestDecodeDeliveryRecords(t *testing.T) {
+	ndjson := `{"message_id": "m1", "event": "MESSAGE_ACCEPTED"}
+
+{"message_id": "m2", "event": "MISSED_TTL"}
+`
+	array := `  [{"message_id": "m1", "event": "MESSAGE_ACCEPTED"}, {"message_id": "m2", "event": "MISSED_TTL"}]`
+	want := []*DeliveryRecord{
+		{MessageID: "m1", Event: DeliveryEventMessageAccepted},
+		{MessageID: "m2", Event: DeliveryEventMissedTTL},
+	}
+
+	for _, input := range []string{ndjson, array} {
+		records, err := DecodeDeliveryRecords(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(records, want) {
+			t.Errorf("DecodeDeliveryRecords(%q) = %v; want = %v", input, records, want)
+		}
+	}
+
+	records, err := DecodeDeliveryRecords(strings.NewReader(" \n"))
+	if records != nil || err != nil {
+		t.Errorf("DecodeDeliveryRecords(empty) = (%v, %v); want = (nil, nil)", records, err)
+	}
+
+	_, err = DecodeDeliveryRecords(strings.NewReader("{\"message_id\": \"m1\"}\n{\"ttl\": \"x\"}\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("DecodeDeliveryRecords() = %v; want = line 2 error", err)
+	}
+}
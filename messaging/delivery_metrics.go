@@ -0,0 +1,230 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	fcmDataEndpoint = "https://fcmdata.googleapis.com/v1beta1"
+
+	// maxDeliveryDataPageSize is the maximum number of records the FCM Data API returns per page.
+	maxDeliveryDataPageSize = 100
+)
+
+// AndroidDeliveryData contains the delivery metrics of the messages sent to an Android app on a
+// given day, as reported by the FCM Data API.
+//
+// See https://firebase.google.com/docs/cloud-messaging/understand-delivery for details.
+type AndroidDeliveryData struct {
+	// AppID is the ID of the Android app the messages were sent to.
+	AppID string
+
+	// Date is the day the metrics apply to, at midnight UTC.
+	Date time.Time
+
+	// AnalyticsLabel is the analytics label of the messages, if the metrics are broken down by
+	// label.
+	AnalyticsLabel string
+
+	// Data contains the metrics.
+	Data *DeliveryData
+}
+
+// DeliveryData contains the delivery metrics of a set of messages.
+type DeliveryData struct {
+	CountMessagesAccepted       int64
+	CountNotificationsAccepted  int64
+	MessageOutcomePercents      *MessageOutcomePercents
+	DeliveryPerformancePercents *DeliveryPerformancePercents
+	MessageInsightPercents      *MessageInsightPercents
+}
+
+// MessageOutcomePercents contains the percentages of the accepted messages that had each outcome.
+type MessageOutcomePercents struct {
+	Delivered                     float64 `json:"delivered"`
+	Pending                       float64 `json:"pending"`
+	Collapsed                     float64 `json:"collapsed"`
+	DroppedTooManyPendingMessages float64 `json:"droppedTooManyPendingMessages"`
+	DroppedAppForceStopped        float64 `json:"droppedAppForceStopped"`
+	DroppedDeviceInactive         float64 `json:"droppedDeviceInactive"`
+	DroppedTTLExpired             float64 `json:"droppedTtlExpired"`
+}
+
+// DeliveryPerformancePercents contains the percentages of the delivered messages that were
+// delivered without delay, or delayed for each reason.
+type DeliveryPerformancePercents struct {
+	DeliveredNoDelay        float64 `json:"deliveredNoDelay"`
+	DelayedDeviceOffline    float64 `json:"delayedDeviceOffline"`
+	DelayedDeviceDoze       float64 `json:"delayedDeviceDoze"`
+	DelayedMessageThrottled float64 `json:"delayedMessageThrottled"`
+	DelayedUserStopped      float64 `json:"delayedUserStopped"`
+}
+
+// MessageInsightPercents contains additional insights into the delivery of the messages.
+type MessageInsightPercents struct {
+	// PriorityLowered is the percentage of the high priority messages that were delivered with
+	// normal priority.
+	PriorityLowered float64 `json:"priorityLowered"`
+}
+
+type androidDeliveryDataResponse struct {
+	AppID string `json:"appId"`
+	Date  struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"date"`
+	AnalyticsLabel string `json:"analyticsLabel"`
+	Data           struct {
+		CountMessagesAccepted       string                       `json:"countMessagesAccepted"`
+		CountNotificationsAccepted  string                       `json:"countNotificationsAccepted"`
+		MessageOutcomePercents      *MessageOutcomePercents      `json:"messageOutcomePercents"`
+		DeliveryPerformancePercents *DeliveryPerformancePercents `json:"deliveryPerformancePercents"`
+		MessageInsightPercents      *MessageInsightPercents      `json:"messageInsightPercents"`
+	} `json:"data"`
+}
+
+func (r *androidDeliveryDataResponse) toAndroidDeliveryData() (*AndroidDeliveryData, error) {
+	accepted, err := parseCount(r.Data.CountMessagesAccepted)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := parseCount(r.Data.CountNotificationsAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AndroidDeliveryData{
+		AppID:          r.AppID,
+		Date:           time.Date(r.Date.Year, time.Month(r.Date.Month), r.Date.Day, 0, 0, 0, 0, time.UTC),
+		AnalyticsLabel: r.AnalyticsLabel,
+		Data: &DeliveryData{
+			CountMessagesAccepted:       accepted,
+			CountNotificationsAccepted:  notifications,
+			MessageOutcomePercents:      r.Data.MessageOutcomePercents,
+			DeliveryPerformancePercents: r.Data.DeliveryPerformancePercents,
+			MessageInsightPercents:      r.Data.MessageInsightPercents,
+		},
+	}, nil
+}
+
+// parseCount parses an int64 count, which the FCM Data API encodes as a string.
+func parseCount(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid count in delivery data: %q", s)
+	}
+	return n, nil
+}
+
+// AndroidDeliveryData returns an iterator over the daily delivery metrics of the messages sent to
+// the Android app with the given ID, as reported by the FCM Data API.
+//
+// Metrics are only available for days on which the app received enough messages, and are
+// typically available with a delay of a few days.
+func (c *fcmClient) AndroidDeliveryData(ctx context.Context, appID string) *DeliveryDataIterator {
+	it := &DeliveryDataIterator{
+		ctx:    ctx,
+		client: c,
+		appID:  appID,
+	}
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(
+		it.fetch,
+		func() int { return len(it.records) },
+		func() interface{} { b := it.records; it.records = nil; return b })
+	it.pageInfo.MaxSize = maxDeliveryDataPageSize
+	return it
+}
+
+// DeliveryDataIterator is an iterator over the delivery metrics of an Android app.
+type DeliveryDataIterator struct {
+	client   *fcmClient
+	ctx      context.Context
+	appID    string
+	nextFunc func() error
+	pageInfo *iterator.PageInfo
+	records  []*AndroidDeliveryData
+}
+
+// PageInfo supports pagination.
+func (it *DeliveryDataIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next AndroidDeliveryData. The error value of [iterator.Done] is
+// returned if there are no more results. Once Next returns [iterator.Done], all
+// subsequent calls will return [iterator.Done].
+func (it *DeliveryDataIterator) Next() (*AndroidDeliveryData, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+
+	record := it.records[0]
+	it.records = it.records[1:]
+	return record, nil
+}
+
+func (it *DeliveryDataIterator) fetch(pageSize int, pageToken string) (string, error) {
+	if it.appID == "" {
+		return "", errors.New("app ID must not be empty")
+	}
+
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+	}
+	if pageToken != "" {
+		params["pageToken"] = pageToken
+	}
+
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL: fmt.Sprintf("%s/projects/%s/androidApps/%s/deliveryData",
+			it.client.fcmDataEndpoint, it.client.project, url.PathEscape(it.appID)),
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParams(params),
+		},
+	}
+
+	var result struct {
+		AndroidDeliveryData []*androidDeliveryDataResponse `json:"androidDeliveryData"`
+		NextPageToken       string                         `json:"nextPageToken"`
+	}
+	if _, err := it.client.httpClient.DoAndUnmarshal(it.ctx, req, &result); err != nil {
+		return "", err
+	}
+
+	for _, r := range result.AndroidDeliveryData {
+		record, err := r.toAndroidDeliveryData()
+		if err != nil {
+			return "", err
+		}
+		it.records = append(it.records, record)
+	}
+	return result.NextPageToken, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+	"google.golang.org/api/iterator"
+)
+
+const testDeliveryDataPage1 = `{
+  "androidDeliveryData": [{
+    "appId": "1:123:android:abc",
+    "date": {"year": 2026, "month": 3, "day": 14},
+    "analyticsLabel": "campaign",
+    "data": {
+      "countMessagesAccepted": "1000",
+      "countNotificationsAccepted": "800",
+      "messageOutcomePercents": {"delivered": 90.5, "pending": 4.5, "droppedTtlExpired": 5},
+      "deliveryPerformancePercents": {"deliveredNoDelay": 80, "delayedDeviceOffline": 20},
+      "messageInsightPercents": {"priorityLowered": 1.5}
+    }
+  }],
+  "nextPageToken": "token"
+}`
+
+const testDeliveryDataPage2 = `{
+  "androidDeliveryData": [{
+    "appId": "1:123:android:abc",
+    "date": {"year": 2026, "month": 3, "day": 15},
+    "data": {"countMessagesAccepted": "5"}
+  }]
+}`
+
+func TestAndroidDeliveryData(t *testing.T) {
+	var requests []*http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			w.Write([]byte(testDeliveryDataPage1))
+		} else {
+			w.Write([]byte(testDeliveryDataPage2))
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmDataEndpoint = ts.URL + "/v1beta1"
+
+	var got []*AndroidDeliveryData
+	it := client.AndroidDeliveryData(ctx, "1:123:android:abc")
+	for {
+		record, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, record)
+	}
+
+	want := []*AndroidDeliveryData{
+		{
+			AppID:          "1:123:android:abc",
+			Date:           time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC),
+			AnalyticsLabel: "campaign",
+			Data: &DeliveryData{
+				CountMessagesAccepted:      1000,
+				CountNotificationsAccepted: 800,
+				MessageOutcomePercents: &MessageOutcomePercents{
+					Delivered:         90.5,
+					Pending:           4.5,
+					DroppedTTLExpired: 5,
+				},
+				DeliveryPerformancePercents: &DeliveryPerformancePercents{
+					DeliveredNoDelay:     80,
+					DelayedDeviceOffline: 20,
+				},
+				MessageInsightPercents: &MessageInsightPercents{PriorityLowered: 1.5},
+			},
+		},
+		{
+			AppID: "1:123:android:abc",
+			Date:  time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+			Data:  &DeliveryData{CountMessagesAccepted: 5},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AndroidDeliveryData() = %#v; want = %#v", got, want)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("AndroidDeliveryData() = %d requests; want = 2", len(requests))
+	}
+	wantPath := "/v1beta1/projects/test-project/androidApps/1:123:android:abc/deliveryData"
+	for _, r := range requests {
+		if r.Method != http.MethodGet || r.URL.Path != wantPath {
+			t.Errorf("Request = %s %s; want = GET %s", r.Method, r.URL.Path, wantPath)
+		}
+		if r.URL.Query().Get("pageSize") != "100" {
+			t.Errorf("pageSize = %q; want = %q", r.URL.Query().Get("pageSize"), "100")
+		}
+	}
+	if token := requests[1].URL.Query().Get("pageToken"); token != "token" {
+		t.Errorf("pageToken = %q; want = %q", token, "token")
+	}
+}
+
+func TestAndroidDeliveryDataError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"status": "PERMISSION_DENIED", "message": "test error"}}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmDataEndpoint = ts.URL
+
+	record, err := client.AndroidDeliveryData(ctx, "app").Next()
+	if record != nil || err == nil || err.Error() != "test error" || !errorutils.IsPermissionDenied(err) {
+		t.Errorf("Next() = (%v, %v); want = (nil, %q)", record, err, "test error")
+	}
+}
+
+func TestAndroidDeliveryDataInvalid(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"androidDeliveryData": [{"data": {"countMessagesAccepted": "many"}}]}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmDataEndpoint = ts.URL
+
+	want := "app ID must not be empty"
+	if _, err := client.AndroidDeliveryData(ctx, "").Next(); err == nil || err.Error() != want {
+		t.Errorf("Next() = %v; want = %q", err, want)
+	}
+
+	want = `invalid count in delivery data: "many"`
+	if _, err := client.AndroidDeliveryData(ctx, "app").Next(); err == nil || err.Error() != want {
+		t.Errorf("Next() = %v; want = %q", err, want)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	deviceGroupEndpoint = "https://fcm.googleapis.com/fcm/notification"
+
+	// maxDeviceGroupTokens is the maximum number of members of a device group.
+	maxDeviceGroupTokens = 20
+)
+
+type deviceGroupClient struct {
+	deviceGroupEndpoint string
+	httpClient          *internal.HTTPClient
+}
+
+func newDeviceGroupClient(hc *http.Client, endpoint string) *deviceGroupClient {
+	client := internal.WithDefaultRetryConfig(hc)
+	client.CreateErrFn = handleDeviceGroupError
+	client.Opts = []internal.HTTPOption{internal.WithHeader("access_token_auth", "true")}
+	return &deviceGroupClient{
+		deviceGroupEndpoint: endpoint,
+		httpClient:          client,
+	}
+}
+
+// CreateDeviceGroup creates a device group with the given name and registration tokens, and
+// returns its notification key. Messages sent to the notification key (as the Token of a Message)
+// are delivered to all the devices in the group.
+//
+// The senderID is the sender ID (project number) of the Firebase project. The tokens list must not
+// be empty, and have at most 20 tokens.
+func (c *deviceGroupClient) CreateDeviceGroup(ctx context.Context, senderID, name string, tokens []string) (string, error) {
+	req := &deviceGroupRequest{
+		Operation: "create",
+		Name:      name,
+		Tokens:    tokens,
+	}
+	return c.makeDeviceGroupRequest(ctx, senderID, req)
+}
+
+// AddToDeviceGroup adds the given registration tokens to an existing device group, and returns
+// the notification key of the group.
+//
+// The tokens list must not be empty, and have at most 20 tokens.
+func (c *deviceGroupClient) AddToDeviceGroup(ctx context.Context, senderID, name, key string, tokens []string) (string, error) {
+	if key == "" {
+		return "", errors.New("notification key not specified")
+	}
+	req := &deviceGroupRequest{
+		Operation: "add",
+		Name:      name,
+		Key:       key,
+		Tokens:    tokens,
+	}
+	return c.makeDeviceGroupRequest(ctx, senderID, req)
+}
+
+// RemoveFromDeviceGroup removes the given registration tokens from an existing device group, and
+// returns the notification key of the group. The group is deleted once all of its tokens are
+// removed.
+//
+// The tokens list must not be empty, and have at most 20 tokens.
+func (c *deviceGroupClient) RemoveFromDeviceGroup(ctx context.Context, senderID, name, key string, tokens []string) (string, error) {
+	if key == "" {
+		return "", errors.New("notification key not specified")
+	}
+	req := &deviceGroupRequest{
+		Operation: "remove",
+		Name:      name,
+		Key:       key,
+		Tokens:    tokens,
+	}
+	return c.makeDeviceGroupRequest(ctx, senderID, req)
+}
+
+// DeviceGroupKey returns the notification key of the device group with the given name.
+func (c *deviceGroupClient) DeviceGroupKey(ctx context.Context, senderID, name string) (string, error) {
+	if err := validateDeviceGroup(senderID, name); err != nil {
+		return "", err
+	}
+
+	request := &internal.Request{
+		Method: http.MethodGet,
+		URL:    c.deviceGroupEndpoint,
+		Opts: []internal.HTTPOption{
+			internal.WithHeader("project_id", senderID),
+			internal.WithQueryParam("notification_key_name", name),
+		},
+	}
+	return c.doDeviceGroupRequest(ctx, request)
+}
+
+type deviceGroupRequest struct {
+	Operation string   `json:"operation"`
+	Name      string   `json:"notification_key_name"`
+	Key       string   `json:"notification_key,omitempty"`
+	Tokens    []string `json:"registration_ids"`
+}
+
+type deviceGroupResponse struct {
+	Key string `json:"notification_key"`
+}
+
+func (c *deviceGroupClient) makeDeviceGroupRequest(ctx context.Context, senderID string, req *deviceGroupRequest) (string, error) {
+	if err := validateDeviceGroup(senderID, req.Name); err != nil {
+		return "", err
+	}
+	if len(req.Tokens) == 0 {
+		return "", errors.New("no tokens specified")
+	}
+	if len(req.Tokens) > maxDeviceGroupTokens {
+		return "", fmt.Errorf("tokens list must not contain more than %d items", maxDeviceGroupTokens)
+	}
+	for _, token := range req.Tokens {
+		if token == "" {
+			return "", errors.New("tokens list must not contain empty strings")
+		}
+	}
+
+	request := &internal.Request{
+		Method: http.MethodPost,
+		URL:    c.deviceGroupEndpoint,
+		Body:   internal.NewJSONEntity(req),
+		Opts:   []internal.HTTPOption{internal.WithHeader("project_id", senderID)},
+	}
+	return c.doDeviceGroupRequest(ctx, request)
+}
+
+func (c *deviceGroupClient) doDeviceGroupRequest(ctx context.Context, request *internal.Request) (string, error) {
+	var result deviceGroupResponse
+	if _, err := c.httpClient.DoAndUnmarshal(ctx, request, &result); err != nil {
+		return "", err
+	}
+	return result.Key, nil
+}
+
+func validateDeviceGroup(senderID, name string) error {
+	if senderID == "" {
+		return errors.New("sender ID not specified")
+	}
+	if name == "" {
+		return errors.New("device group name not specified")
+	}
+	return nil
+}
+
+func handleDeviceGroupError(resp *internal.Response) error {
+	base := internal.NewFirebaseError(resp)
+	var ie iidErrorResponse
+	json.Unmarshal(resp.Body, &ie) // ignore any json parse errors at this level
+	if ie.Error != "" {
+		base.String = fmt.Sprintf("error while calling the device group service: %s", ie.Error)
+	}
+
+	return base
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"firebase.google.com/go/v4/errorutils"
+)
+
+const testNotificationKey = "test-notification-key"
+
+func TestDeviceGroupOperations(t *testing.T) {
+	var tr *http.Request
+	var b []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr = r
+		b, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{\"notification_key\": \"" + testNotificationKey + "\"}"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.deviceGroupEndpoint = ts.URL + "/fcm/notification"
+
+	cases := []struct {
+		name string
+		call func() (string, error)
+		want map[string]interface{}
+	}{
+		{
+			name: "Create",
+			call: func() (string, error) {
+				return client.CreateDeviceGroup(ctx, "123", "group", []string{"id1", "id2"})
+			},
+			want: map[string]interface{}{
+				"operation":             "create",
+				"notification_key_name": "group",
+				"registration_ids":      []interface{}{"id1", "id2"},
+			},
+		},
+		{
+			name: "Add",
+			call: func() (string, error) {
+				return client.AddToDeviceGroup(ctx, "123", "group", testNotificationKey, []string{"id3"})
+			},
+			want: map[string]interface{}{
+				"operation":             "add",
+				"notification_key_name": "group",
+				"notification_key":      testNotificationKey,
+				"registration_ids":      []interface{}{"id3"},
+			},
+		},
+		{
+			name: "Remove",
+			call: func() (string, error) {
+				return client.RemoveFromDeviceGroup(ctx, "123", "group", testNotificationKey, []string{"id1"})
+			},
+			want: map[string]interface{}{
+				"operation":             "remove",
+				"notification_key_name": "group",
+				"notification_key":      testNotificationKey,
+				"registration_ids":      []interface{}{"id1"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := tc.call()
+		if err != nil || key != testNotificationKey {
+			t.Errorf("%s() = (%q, %v); want = (%q, nil)", tc.name, key, err, testNotificationKey)
+			continue
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(b, &parsed); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(parsed, tc.want) {
+			t.Errorf("%s() Body = %#v; want = %#v", tc.name, parsed, tc.want)
+		}
+		checkDeviceGroupRequest(t, tr, http.MethodPost)
+	}
+}
+
+func TestDeviceGroupKey(t *testing.T) {
+	var tr *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{\"notification_key\": \"" + testNotificationKey + "\"}"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.deviceGroupEndpoint = ts.URL + "/fcm/notification"
+
+	key, err := client.DeviceGroupKey(ctx, "123", "group")
+	if err != nil || key != testNotificationKey {
+		t.Errorf("DeviceGroupKey() = (%q, %v); want = (%q, nil)", key, err, testNotificationKey)
+	}
+	checkDeviceGroupRequest(t, tr, http.MethodGet)
+	if name := tr.URL.Query().Get("notification_key_name"); name != "group" {
+		t.Errorf("notification_key_name = %q; want = %q", name, "group")
+	}
+}
+
+func TestInvalidDeviceGroupArgs(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tooMany []string
+	for i := 0; i < 21; i++ {
+		tooMany = append(tooMany, "token")
+	}
+	cases := []struct {
+		name     string
+		senderID string
+		group    string
+		key      string
+		tokens   []string
+		want     string
+	}{
+		{"NoSenderID", "", "group", "key", []string{"id1"}, "sender ID not specified"},
+		{"NoName", "123", "", "key", []string{"id1"}, "device group name not specified"},
+		{"NoTokens", "123", "group", "key", nil, "no tokens specified"},
+		{"TooManyTokens", "123", "group", "key", tooMany, "tokens list must not contain more than 20 items"},
+		{"EmptyToken", "123", "group", "key", []string{"id1", ""}, "tokens list must not contain empty strings"},
+	}
+	for _, tc := range cases {
+		if _, err := client.CreateDeviceGroup(ctx, tc.senderID, tc.group, tc.tokens); err == nil || err.Error() != tc.want {
+			t.Errorf("CreateDeviceGroup(%s) = %v; want = %q", tc.name, err, tc.want)
+		}
+		if _, err := client.AddToDeviceGroup(ctx, tc.senderID, tc.group, tc.key, tc.tokens); err == nil || err.Error() != tc.want {
+			t.Errorf("AddToDeviceGroup(%s) = %v; want = %q", tc.name, err, tc.want)
+		}
+		if _, err := client.RemoveFromDeviceGroup(ctx, tc.senderID, tc.group, tc.key, tc.tokens); err == nil || err.Error() != tc.want {
+			t.Errorf("RemoveFromDeviceGroup(%s) = %v; want = %q", tc.name, err, tc.want)
+		}
+	}
+
+	want := "notification key not specified"
+	if _, err := client.AddToDeviceGroup(ctx, "123", "group", "", []string{"id1"}); err == nil || err.Error() != want {
+		t.Errorf("AddToDeviceGroup(NoKey) = %v; want = %q", err, want)
+	}
+	if _, err := client.RemoveFromDeviceGroup(ctx, "123", "group", "", []string{"id1"}); err == nil || err.Error() != want {
+		t.Errorf("RemoveFromDeviceGroup(NoKey) = %v; want = %q", err, want)
+	}
+}
+
+func TestDeviceGroupError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{\"error\": \"notification_key already exists\"}"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.deviceGroupEndpoint = ts.URL + "/fcm/notification"
+
+	want := "error while calling the device group service: notification_key already exists"
+	key, err := client.CreateDeviceGroup(ctx, "123", "group", []string{"id1"})
+	if err == nil || err.Error() != want || !errorutils.IsInvalidArgument(err) {
+		t.Errorf("CreateDeviceGroup() = (%q, %v); want = (\"\", %q)", key, err, want)
+	}
+}
+
+func checkDeviceGroupRequest(t *testing.T, tr *http.Request, method string) {
+	if tr.Method != method {
+		t.Errorf("Method = %q; want = %q", tr.Method, method)
+	}
+	if tr.URL.Path != "/fcm/notification" {
+		t.Errorf("Path = %q; want = %q", tr.URL.Path, "/fcm/notification")
+	}
+	if h := tr.Header.Get("project_id"); h != "123" {
+		t.Errorf("project_id = %q; want = %q", h, "123")
+	}
+	if h := tr.Header.Get("access_token_auth"); h != "true" {
+		t.Errorf("access_token_auth = %q; want = %q", h, "true")
+	}
+	if h := tr.Header.Get("Authorization"); h != "Bearer test-token" {
+		t.Errorf("Authorization = %q; want = %q", h, "Bearer test-token")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is an error code reported by the FCM v1 API in the details of an error response.
+//
+// See https://firebase.google.com/docs/reference/fcm/rest/v1/ErrorCode for details.
+type ErrorCode string
+
+const (
+	// ErrorCodeUnspecified is used when the error response does not contain an FCM error code.
+	ErrorCodeUnspecified ErrorCode = ""
+
+	// ErrorCodeInvalidArgument indicates that the request parameters were invalid.
+	ErrorCodeInvalidArgument ErrorCode = invalidArgument
+
+	// ErrorCodeUnregistered indicates that the registration token is no longer valid.
+	ErrorCodeUnregistered ErrorCode = unregistered
+
+	// ErrorCodeSenderIDMismatch indicates that the registration token belongs to a different
+	// sender.
+	ErrorCodeSenderIDMismatch ErrorCode = senderIDMismatch
+
+	// ErrorCodeQuotaExceeded indicates that a sending limit was exceeded.
+	ErrorCodeQuotaExceeded ErrorCode = quotaExceeded
+
+	// ErrorCodeUnavailable indicates that the backend was temporarily unavailable.
+	ErrorCodeUnavailable ErrorCode = unavailable
+
+	// ErrorCodeInternal indicates that an unknown internal error occurred in the backend.
+	ErrorCodeInternal ErrorCode = internalError
+
+	// ErrorCodeThirdPartyAuthError indicates that the APNs certificate or web push auth key was
+	// invalid or missing.
+	ErrorCodeThirdPartyAuthError ErrorCode = thirdPartyAuthError
+
+	// ErrorCodeAPNSAuthError is the legacy form of ErrorCodeThirdPartyAuthError.
+	ErrorCodeAPNSAuthError ErrorCode = apnsAuthError
+)
+
+// Error contains the details of an error response returned by the FCM backend.
+//
+// Errors returned by the send functions carry an Error when they were caused by an error
+// response, which can be obtained via errors.As:
+//
+//	var fcmErr *messaging.Error
+//	if errors.As(err, &fcmErr) && fcmErr.Code == messaging.ErrorCodeUnregistered {
+//		// Remove the registration token.
+//	}
+type Error struct {
+	// Code is the FCM error code, or ErrorCodeUnspecified if the response did not contain one.
+	Code ErrorCode
+
+	// Status is the canonical status of the response, such as "NOT_FOUND".
+	Status string
+
+	// Message is the error message of the response.
+	Message string
+
+	// HTTPStatus is the HTTP status code of the response.
+	HTTPStatus int
+}
+
+func (e *Error) Error() string {
+	if e.Code == ErrorCodeUnspecified {
+		return fmt.Sprintf("fcm error: %s", e.Message)
+	}
+	return fmt.Sprintf("fcm error %s: %s", e.Code, e.Message)
+}
+
+// IsTokenInvalid checks if the given error indicates that the registration token the message was
+// sent to can no longer be used by this project, because it was unregistered or belongs to a
+// different sender. Such tokens should be removed from storage.
+func IsTokenInvalid(err error) bool {
+	return IsUnregistered(err) || IsSenderIDMismatch(err)
+}
+
+func hasMessagingErrorCode(err error, code string) bool {
+	var fcmErr *Error
+	return errors.As(err, &fcmErr) && fcmErr.Code == ErrorCode(code)
+}
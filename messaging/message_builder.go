@@ -0,0 +1,151 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"strconv"
+	"time"
+)
+
+// MessageOption configures a Message built by NewMessage.
+type MessageOption func(*Message)
+
+// NewMessage builds a Message from the given options, which are applied in order.
+//
+// The resulting message is validated as described in Message.Validate(), and an error is returned
+// if it is invalid. NewMessage is an alternative to initializing a Message struct directly.
+func NewMessage(opts ...MessageOption) (*Message, error) {
+	m := &Message{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WithToken sets the registration token of the device to which the message is sent.
+func WithToken(token string) MessageOption {
+	return func(m *Message) {
+		m.Token = token
+	}
+}
+
+// WithTopic sets the topic to which the message is sent.
+func WithTopic(topic string) MessageOption {
+	return func(m *Message) {
+		m.Topic = topic
+	}
+}
+
+// WithCondition sets the condition expression that determines to which topics the message is
+// sent. See TopicCondition() for building conditions.
+func WithCondition(condition string) MessageOption {
+	return func(m *Message) {
+		m.Condition = condition
+	}
+}
+
+// WithData adds the given key-value pairs to the data payload of the message. The map is copied,
+// and entries from later options override earlier ones.
+func WithData(data map[string]string) MessageOption {
+	return func(m *Message) {
+		if m.Data == nil {
+			m.Data = make(map[string]string, len(data))
+		}
+		for k, v := range data {
+			m.Data[k] = v
+		}
+	}
+}
+
+// WithNotification sets the title and body of the notification shown on all platforms.
+func WithNotification(title, body string) MessageOption {
+	return func(m *Message) {
+		if m.Notification == nil {
+			m.Notification = &Notification{}
+		}
+		m.Notification.Title = title
+		m.Notification.Body = body
+	}
+}
+
+// WithImageURL sets the URL of the image shown in the notification on all platforms.
+func WithImageURL(url string) MessageOption {
+	return func(m *Message) {
+		if m.Notification == nil {
+			m.Notification = &Notification{}
+		}
+		m.Notification.ImageURL = url
+	}
+}
+
+// WithTTL sets how long the message is kept in FCM storage if the target device is offline. It
+// sets the TTL of the Android message, and the TTL header of the Webpush message, in whole
+// seconds.
+func WithTTL(ttl time.Duration) MessageOption {
+	return func(m *Message) {
+		// Copy the configs, which may have been passed in by the caller.
+		var android AndroidConfig
+		if m.Android != nil {
+			android = *m.Android
+		}
+		android.TTL = &ttl
+		m.Android = &android
+
+		var webpush WebpushConfig
+		if m.Webpush != nil {
+			webpush = *m.Webpush
+		}
+		headers := make(map[string]string, len(webpush.Headers)+1)
+		for k, v := range webpush.Headers {
+			headers[k] = v
+		}
+		headers["TTL"] = strconv.FormatInt(int64(ttl/time.Second), 10)
+		webpush.Headers = headers
+		m.Webpush = &webpush
+	}
+}
+
+// WithAndroidConfig sets the Android-specific options of the message, replacing any options set
+// by earlier options such as WithTTL.
+func WithAndroidConfig(config *AndroidConfig) MessageOption {
+	return func(m *Message) {
+		m.Android = config
+	}
+}
+
+// WithAPNSConfig sets the APNs-specific options of the message.
+func WithAPNSConfig(config *APNSConfig) MessageOption {
+	return func(m *Message) {
+		m.APNS = config
+	}
+}
+
+// WithWebpushConfig sets the Webpush-specific options of the message, replacing any options set
+// by earlier options such as WithTTL.
+func WithWebpushConfig(config *WebpushConfig) MessageOption {
+	return func(m *Message) {
+		m.Webpush = config
+	}
+}
+
+// WithFCMOptions sets the platform-independent FCM options of the message.
+func WithFCMOptions(options *FCMOptions) MessageOption {
+	return func(m *Message) {
+		m.FCMOptions = options
+	}
+}
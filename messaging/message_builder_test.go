@@ -0,0 +1,136 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewMessage(t *testing.T) {
+	data := map[string]string{"k1": "v1"}
+	m, err := NewMessage(
+		WithToken("token"),
+		WithNotification("title", "body"),
+		WithImageURL("https://example.com/image.png"),
+		WithData(data),
+		WithData(map[string]string{"k2": "v2"}),
+		WithTTL(90*time.Second),
+		WithAPNSConfig(&APNSConfig{Headers: map[string]string{"apns-priority": "10"}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ttl := 90 * time.Second
+	want := &Message{
+		Token: "token",
+		Notification: &Notification{
+			Title:    "title",
+			Body:     "body",
+			ImageURL: "https://example.com/image.png",
+		},
+		Data:    map[string]string{"k1": "v1", "k2": "v2"},
+		Android: &AndroidConfig{TTL: &ttl},
+		Webpush: &WebpushConfig{Headers: map[string]string{"TTL": "90"}},
+		APNS:    &APNSConfig{Headers: map[string]string{"apns-priority": "10"}},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("NewMessage() = %#v; want = %#v", m, want)
+	}
+	if len(data) != 1 {
+		t.Errorf("NewMessage() modified the data map: %v", data)
+	}
+}
+
+func TestNewMessageTTLKeepsWebpushHeaders(t *testing.T) {
+	headers := map[string]string{"Urgency": "high"}
+	m, err := NewMessage(
+		WithTopic("topic"),
+		WithWebpushConfig(&WebpushConfig{Headers: headers}),
+		WithTTL(time.Minute),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"Urgency": "high", "TTL": "60"}
+	if !reflect.DeepEqual(m.Webpush.Headers, want) {
+		t.Errorf("NewMessage() Webpush.Headers = %v; want = %v", m.Webpush.Headers, want)
+	}
+	if len(headers) != 1 {
+		t.Errorf("NewMessage() modified the headers map: %v", headers)
+	}
+}
+
+func TestNewMessageTTLKeepsConfigs(t *testing.T) {
+	android := &AndroidConfig{Priority: "high"}
+	webpush := &WebpushConfig{Headers: map[string]string{"Urgency": "high"}}
+	m, err := NewMessage(
+		WithTopic("topic"),
+		WithAndroidConfig(android),
+		WithWebpushConfig(webpush),
+		WithTTL(time.Minute),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Android.Priority != "high" || m.Android.TTL == nil || *m.Android.TTL != time.Minute {
+		t.Errorf("NewMessage() Android = %#v; want = {Priority: high, TTL: 1m}", m.Android)
+	}
+	if android.TTL != nil {
+		t.Errorf("NewMessage() modified the Android config: %#v", android)
+	}
+	if m.Webpush == webpush || len(webpush.Headers) != 1 {
+		t.Errorf("NewMessage() modified the Webpush config: %#v", webpush)
+	}
+}
+
+func TestNewMessageInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []MessageOption
+		want string
+	}{
+		{
+			name: "NoTarget",
+			opts: []MessageOption{WithNotification("title", "body")},
+			want: "exactly one of token, topic or condition must be specified",
+		},
+		{
+			name: "MultipleTargets",
+			opts: []MessageOption{WithToken("token"), WithTopic("topic")},
+			want: "exactly one of token, topic or condition must be specified",
+		},
+		{
+			name: "NegativeTTL",
+			opts: []MessageOption{WithToken("token"), WithTTL(-time.Second)},
+			want: "ttl duration must not be negative",
+		},
+		{
+			name: "InvalidTopic",
+			opts: []MessageOption{WithTopic("foo bar")},
+			want: "malformed topic name",
+		},
+	}
+	for _, tc := range cases {
+		m, err := NewMessage(tc.opts...)
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("NewMessage(%s) = (%v, %v); want = (nil, %q)", tc.name, m, err, tc.want)
+		}
+	}
+}
@@ -22,18 +22,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"firebase.google.com/go/v4/internal"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
 	"google.golang.org/api/transport"
 )
 
 const (
 	defaultMessagingEndpoint = "https://fcm.googleapis.com/v1"
 	defaultBatchEndpoint     = "https://fcm.googleapis.com/batch"
+	emulatorHostEnvVar       = "FIREBASE_MESSAGING_EMULATOR_HOST"
 
 	firebaseClientHeader   = "X-Firebase-Client"
 	apiFormatVersionHeader = "X-GOOG-API-FORMAT-VERSION"
@@ -83,12 +87,46 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		BareTopic string `json:"topic,omitempty"`
 		*messageInternal
 	}{
-		BareTopic:       strings.TrimPrefix(m.Topic, "/topics/"),
+		BareTopic:       bareTopic(m.Topic),
 		messageInternal: (*messageInternal)(m),
 	}
 	return json.Marshal(temp)
 }
 
+// SetImageURL sets the image displayed in the notification on all platforms.
+//
+// The image is set on the Notification, on the Android and Webpush notifications if they are
+// specified and do not already have an image, and in the APNS FCMOptions. It also sets
+// mutable-content in the APNS payload, without which Apple devices do not display the image.
+func (m *Message) SetImageURL(imageURL string) {
+	if m.Notification == nil {
+		m.Notification = &Notification{}
+	}
+	m.Notification.ImageURL = imageURL
+
+	if m.Android != nil && m.Android.Notification != nil && m.Android.Notification.ImageURL == "" {
+		m.Android.Notification.ImageURL = imageURL
+	}
+	if m.Webpush != nil && m.Webpush.Notification != nil && m.Webpush.Notification.Image == "" {
+		m.Webpush.Notification.Image = imageURL
+	}
+
+	if m.APNS == nil {
+		m.APNS = &APNSConfig{}
+	}
+	if m.APNS.FCMOptions == nil {
+		m.APNS.FCMOptions = &APNSFCMOptions{}
+	}
+	m.APNS.FCMOptions.ImageURL = imageURL
+	if m.APNS.Payload == nil {
+		m.APNS.Payload = &APNSPayload{}
+	}
+	if m.APNS.Payload.Aps == nil {
+		m.APNS.Payload.Aps = &Aps{}
+	}
+	m.APNS.Payload.Aps.MutableContent = true
+}
+
 // UnmarshalJSON unmarshals a JSON string into a Message (for internal use only).
 func (m *Message) UnmarshalJSON(b []byte) error {
 	type messageInternal Message
@@ -106,6 +144,10 @@ func (m *Message) UnmarshalJSON(b []byte) error {
 }
 
 // Notification is the basic notification template to use across all platforms.
+//
+// Images are only displayed on Apple devices when the APNS payload sets mutable-content, which
+// SetImageURL takes care of. Message.ValidateImages checks that the image URLs are suitable for
+// all platforms.
 type Notification struct {
 	Title    string `json:"title,omitempty"`
 	Body     string `json:"body,omitempty"`
@@ -121,6 +163,7 @@ type AndroidConfig struct {
 	Data                  map[string]string    `json:"data,omitempty"` // if specified, overrides the Data field on Message type
 	Notification          *AndroidNotification `json:"notification,omitempty"`
 	FCMOptions            *AndroidFCMOptions   `json:"fcm_options,omitempty"`
+	DirectBootOK          bool                 `json:"direct_boot_ok,omitempty"` // deliver to the app while the device is in direct boot mode
 }
 
 // MarshalJSON marshals an AndroidConfig into JSON (for internal use only).
@@ -190,6 +233,7 @@ type AndroidNotification struct {
 	DefaultLightSettings  bool                          `json:"default_light_settings,omitempty"`
 	Visibility            AndroidNotificationVisibility `json:"-"`
 	NotificationCount     *int                          `json:"notification_count,omitempty"`
+	Proxy                 AndroidNotificationProxy      `json:"-"`
 }
 
 // MarshalJSON marshals an AndroidNotification into JSON (for internal use only).
@@ -216,6 +260,16 @@ func (a *AndroidNotification) MarshalJSON() ([]byte, error) {
 		visibility, _ = visibilities[a.Visibility]
 	}
 
+	var proxy string
+	if a.Proxy != proxyUnspecified {
+		proxies := map[AndroidNotificationProxy]string{
+			ProxyAllow:             "ALLOW",
+			ProxyDeny:              "DENY",
+			ProxyIfPriorityLowered: "IF_PRIORITY_LOWERED",
+		}
+		proxy, _ = proxies[a.Proxy]
+	}
+
 	var timestamp string
 	if a.EventTimestamp != nil {
 		timestamp = a.EventTimestamp.UTC().Format(rfc3339Zulu)
@@ -231,12 +285,14 @@ func (a *AndroidNotification) MarshalJSON() ([]byte, error) {
 		EventTimestamp string   `json:"event_time,omitempty"`
 		Priority       string   `json:"notification_priority,omitempty"`
 		Visibility     string   `json:"visibility,omitempty"`
+		Proxy          string   `json:"proxy,omitempty"`
 		VibrateTimings []string `json:"vibrate_timings,omitempty"`
 		*androidInternal
 	}{
 		EventTimestamp:  timestamp,
 		Priority:        priority,
 		Visibility:      visibility,
+		Proxy:           proxy,
 		VibrateTimings:  vibTimings,
 		androidInternal: (*androidInternal)(a),
 	}
@@ -250,6 +306,7 @@ func (a *AndroidNotification) UnmarshalJSON(b []byte) error {
 		EventTimestamp string   `json:"event_time,omitempty"`
 		Priority       string   `json:"notification_priority,omitempty"`
 		Visibility     string   `json:"visibility,omitempty"`
+		Proxy          string   `json:"proxy,omitempty"`
 		VibrateTimings []string `json:"vibrate_timings,omitempty"`
 		*androidInternal
 	}{
@@ -287,6 +344,19 @@ func (a *AndroidNotification) UnmarshalJSON(b []byte) error {
 		}
 	}
 
+	if temp.Proxy != "" {
+		proxies := map[string]AndroidNotificationProxy{
+			"ALLOW":               ProxyAllow,
+			"DENY":                ProxyDeny,
+			"IF_PRIORITY_LOWERED": ProxyIfPriorityLowered,
+		}
+		if proxy, ok := proxies[temp.Proxy]; ok {
+			a.Proxy = proxy
+		} else {
+			return fmt.Errorf("unknown proxy value: %q", temp.Proxy)
+		}
+	}
+
 	if temp.EventTimestamp != "" {
 		ts, err := time.Parse(rfc3339Zulu, temp.EventTimestamp)
 		if err != nil {
@@ -355,6 +425,24 @@ const (
 	VisibilitySecret
 )
 
+// AndroidNotificationProxy controls whether a notification can be proxied to the device when
+// the app is not available to display it.
+type AndroidNotificationProxy int
+
+const (
+	proxyUnspecified AndroidNotificationProxy = iota
+
+	// ProxyAllow tries to proxy this notification.
+	ProxyAllow
+
+	// ProxyDeny does not proxy this notification.
+	ProxyDeny
+
+	// ProxyIfPriorityLowered only tries to proxy this notification if its AndroidConfig priority
+	// was lowered from high to normal on the device.
+	ProxyIfPriorityLowered
+)
+
 // LightSettings to control notification LED.
 type LightSettings struct {
 	Color                  string
@@ -611,19 +699,31 @@ func (n *WebpushNotification) UnmarshalJSON(b []byte) error {
 
 // WebpushFCMOptions contains additional options for features provided by the FCM web SDK.
 type WebpushFCMOptions struct {
-	Link string `json:"link,omitempty"`
+	Link           string `json:"link,omitempty"`
+	AnalyticsLabel string `json:"analytics_label,omitempty"`
 }
 
 // APNSConfig contains messaging options specific to the Apple Push Notification Service (APNS).
 //
 // See https://developer.apple.com/library/content/documentation/NetworkingInternet/Conceptual/RemoteNotificationsPG/CommunicatingwithAPNs.html
 // for more details on supported headers and payload keys.
+//
+// Live Activity updates are sent with the apns-push-type header set to "liveactivity", the
+// LiveActivityToken of the activity, and the Live Activity fields of Aps.
 type APNSConfig struct {
-	Headers    map[string]string `json:"headers,omitempty"`
-	Payload    *APNSPayload      `json:"payload,omitempty"`
-	FCMOptions *APNSFCMOptions   `json:"fcm_options,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	Payload           *APNSPayload      `json:"payload,omitempty"`
+	FCMOptions        *APNSFCMOptions   `json:"fcm_options,omitempty"`
+	LiveActivityToken string            `json:"live_activity_token,omitempty"`
 }
 
+// Live Activity events that can be specified in Aps.Event.
+const (
+	LiveActivityEventStart  = "start"
+	LiveActivityEventUpdate = "update"
+	LiveActivityEventEnd    = "end"
+)
+
 // APNSPayload is the payload that can be included in an APNS message.
 //
 // The payload mainly consists of the aps dictionary. Additionally it may contain arbitrary
@@ -685,6 +785,15 @@ type Aps struct {
 	Category         string                 `json:"category,omitempty"`
 	ThreadID         string                 `json:"thread-id,omitempty"`
 	CustomData       map[string]interface{} `json:"-"`
+
+	// Live Activity fields. Timestamps are in seconds since the epoch.
+	Event          string                 `json:"event,omitempty"`
+	Timestamp      int64                  `json:"timestamp,omitempty"`
+	ContentState   map[string]interface{} `json:"content-state,omitempty"`
+	StaleDate      int64                  `json:"stale-date,omitempty"`
+	DismissalDate  int64                  `json:"dismissal-date,omitempty"`
+	AttributesType string                 `json:"attributes-type,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // standardFields creates a map containing all the fields except the custom data.
@@ -715,6 +824,27 @@ func (a *Aps) standardFields() map[string]interface{} {
 	if a.ThreadID != "" {
 		m["thread-id"] = a.ThreadID
 	}
+	if a.Event != "" {
+		m["event"] = a.Event
+	}
+	if a.Timestamp != 0 {
+		m["timestamp"] = a.Timestamp
+	}
+	if a.ContentState != nil {
+		m["content-state"] = a.ContentState
+	}
+	if a.StaleDate != 0 {
+		m["stale-date"] = a.StaleDate
+	}
+	if a.DismissalDate != 0 {
+		m["dismissal-date"] = a.DismissalDate
+	}
+	if a.AttributesType != "" {
+		m["attributes-type"] = a.AttributesType
+	}
+	if a.Attributes != nil {
+		m["attributes"] = a.Attributes
+	}
 	return m
 }
 
@@ -851,6 +981,7 @@ type ErrorInfo struct {
 type Client struct {
 	*fcmClient
 	*iidClient
+	*deviceGroupClient
 }
 
 // NewClient creates a new instance of the Firebase Cloud Messaging Client.
@@ -862,35 +993,75 @@ func NewClient(ctx context.Context, c *internal.MessagingConfig) (*Client, error
 		return nil, errors.New("project ID is required to access Firebase Cloud Messaging client")
 	}
 
-	hc, messagingEndpoint, err := transport.NewHTTPClient(ctx, c.Opts...)
+	emulatorHost := c.EmulatorHost
+	if emulatorHost == "" {
+		emulatorHost = os.Getenv(emulatorHostEnvVar)
+	}
+
+	opts := c.Opts
+	if emulatorHost != "" {
+		// Emulators and fake servers do not verify credentials, so don't require any.
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "owner"})
+		opts = []option.ClientOption{option.WithTokenSource(ts)}
+	}
+
+	hc, messagingEndpoint, err := transport.NewHTTPClient(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	batchEndpoint := messagingEndpoint
-
-	if messagingEndpoint == "" {
+	groupEndpoint := deviceGroupEndpoint
+	topicEndpoint := iidEndpoint
+
+	if emulatorHost != "" {
+		baseURL := fmt.Sprintf("http://%s", emulatorHost)
+		messagingEndpoint = baseURL + "/v1"
+		batchEndpoint = baseURL + "/batch"
+		groupEndpoint = baseURL + "/fcm/notification"
+		topicEndpoint = baseURL + "/iid/v1"
+	} else if c.Endpoint != "" {
+		messagingEndpoint = c.Endpoint + "/v1"
+		batchEndpoint = c.Endpoint + "/batch"
+		groupEndpoint = c.Endpoint + "/fcm/notification"
+		topicEndpoint = c.Endpoint + "/iid/v1"
+	} else if messagingEndpoint == "" {
 		messagingEndpoint = defaultMessagingEndpoint
 		batchEndpoint = defaultBatchEndpoint
 	}
 
+	if c.WarmUp && emulatorHost == "" {
+		// Warm-up is best effort. Any connection or credential errors resurface on first use.
+		internal.WarmUp(ctx, hc, messagingEndpoint)
+	}
+
+	iidClient := newIIDClient(hc, topicEndpoint)
+	iidClient.httpClient.MetricsFn = c.MetricsFn
+	deviceGroupClient := newDeviceGroupClient(hc, groupEndpoint)
+	deviceGroupClient.httpClient.MetricsFn = c.MetricsFn
 	return &Client{
-		fcmClient: newFCMClient(hc, c, messagingEndpoint, batchEndpoint),
-		iidClient: newIIDClient(hc),
+		fcmClient:         newFCMClient(hc, c, messagingEndpoint, batchEndpoint),
+		iidClient:         iidClient,
+		deviceGroupClient: deviceGroupClient,
 	}, nil
 }
 
 type fcmClient struct {
-	fcmEndpoint   string
-	batchEndpoint string
-	project       string
-	version       string
-	httpClient    *internal.HTTPClient
+	fcmEndpoint     string
+	batchEndpoint   string
+	fcmDataEndpoint string
+	project         string
+	version         string
+	httpClient      *internal.HTTPClient
+	maxConcurrency  int
+	messageTimeout  time.Duration
 }
 
 func newFCMClient(hc *http.Client, conf *internal.MessagingConfig, messagingEndpoint string, batchEndpoint string) *fcmClient {
 	client := internal.WithDefaultRetryConfig(hc)
 	client.CreateErrFn = handleFCMError
+	client.Codec = conf.JSONCodec
+	client.MetricsFn = conf.MetricsFn
 
 	version := fmt.Sprintf("fire-admin-go/%s", conf.Version)
 	client.Opts = []internal.HTTPOption{
@@ -899,11 +1070,12 @@ func newFCMClient(hc *http.Client, conf *internal.MessagingConfig, messagingEndp
 	}
 
 	return &fcmClient{
-		fcmEndpoint:   messagingEndpoint,
-		batchEndpoint: batchEndpoint,
-		project:       conf.ProjectID,
-		version:       version,
-		httpClient:    client,
+		fcmEndpoint:     messagingEndpoint,
+		batchEndpoint:   batchEndpoint,
+		fcmDataEndpoint: fcmDataEndpoint,
+		project:         conf.ProjectID,
+		version:         version,
+		httpClient:      client,
 	}
 }
 
@@ -1051,6 +1223,8 @@ type fcmResponse struct {
 
 type fcmErrorResponse struct {
 	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
 		Details []struct {
 			Type      string `json:"@type"`
 			ErrorCode string `json:"errorCode"`
@@ -1062,22 +1236,22 @@ func handleFCMError(resp *internal.Response) error {
 	base := internal.NewFirebaseErrorOnePlatform(resp)
 	var fe fcmErrorResponse
 	json.Unmarshal(resp.Body, &fe) // ignore any json parse errors at this level
+	details := &Error{
+		Status:     fe.Error.Status,
+		Message:    fe.Error.Message,
+		HTTPStatus: resp.Status,
+	}
 	for _, d := range fe.Error.Details {
 		if d.Type == "type.googleapis.com/google.firebase.fcm.v1.FcmError" {
 			base.Ext["messagingErrorCode"] = d.ErrorCode
+			details.Code = ErrorCode(d.ErrorCode)
 			break
 		}
 	}
-
-	return base
-}
-
-func hasMessagingErrorCode(err error, code string) bool {
-	fe, ok := err.(*internal.FirebaseError)
-	if !ok {
-		return false
+	if details.Message == "" {
+		details.Message = base.String
 	}
 
-	got, ok := fe.Ext["messagingErrorCode"]
-	return ok && got == code
+	base.Details = details
+	return base
 }
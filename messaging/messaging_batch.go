@@ -28,11 +28,13 @@ import (
 	"net/http"
 	"net/textproto"
 	"sync"
+	"time"
 
 	"firebase.google.com/go/v4/internal"
 )
 
 const maxMessages = 500
+const defaultMaxConcurrency = 500
 const multipartBoundary = "__END_OF_PART__"
 
 // MulticastMessage represents a message that can be sent to multiple devices via Firebase Cloud
@@ -98,6 +100,13 @@ type BatchResponse struct {
 // from SendEach or a BatchResponse with all failures indicates a total failure, meaning that
 // none of the messages in the list could be sent. Partial failures or no failures are only
 // indicated by a BatchResponse return value.
+//
+// If the context is canceled or its deadline expires while the messages are being sent, SendEach
+// stops sending and aborts the in-flight requests. Messages sent before that are reported as
+// usual, and the rest are reported as failures whose errors wrap the context error. For these
+// failures, errorutils.IsCancelled returns true if the context was canceled, and
+// errorutils.IsDeadlineExceeded returns true if its deadline expired. SetMessageTimeout() limits
+// the time spent on each message.
 func (c *fcmClient) SendEach(ctx context.Context, messages []*Message) (*BatchResponse, error) {
 	return c.sendEachInBatch(ctx, messages, false)
 }
@@ -165,38 +174,14 @@ func (c *fcmClient) sendEachInBatch(ctx context.Context, messages []*Message, dr
 		return nil, fmt.Errorf("messages must not contain more than %d elements", maxMessages)
 	}
 
-	var responses []*SendResponse = make([]*SendResponse, len(messages))
-	var wg sync.WaitGroup
-
-	for idx, m := range messages {
-		if err := validateMessage(m); err != nil {
-			return nil, fmt.Errorf("invalid message at index %d: %v", idx, err)
-		}
-		wg.Add(1)
-		go func(idx int, m *Message, dryRun bool, responses []*SendResponse) {
-			defer wg.Done()
-			var resp string
-			var err error
-			if dryRun {
-				resp, err = c.SendDryRun(ctx, m)
-			} else {
-				resp, err = c.Send(ctx, m)
-			}
-			if err == nil {
-				responses[idx] = &SendResponse{
-					Success:   true,
-					MessageID: resp,
-				}
-			} else {
-				responses[idx] = &SendResponse{
-					Success: false,
-					Error:   err,
-				}
-			}
-		}(idx, m, dryRun, responses)
+	if err := validateMessages(messages); err != nil {
+		return nil, err
 	}
-	// Wait for all SendDryRun/Send calls to finish
-	wg.Wait()
+
+	var responses []*SendResponse = make([]*SendResponse, len(messages))
+	c.sendEach(ctx, messages, dryRun, func(idx int, resp *SendResponse) {
+		responses[idx] = resp
+	})
 
 	successCount := 0
 	for _, r := range responses {
@@ -212,6 +197,187 @@ func (c *fcmClient) sendEachInBatch(ctx context.Context, messages []*Message, dr
 	}, nil
 }
 
+// IndexedSendResponse represents the status of an individual message sent by SendEachAsync() or
+// SendEachAsyncDryRun(). Index is the position of the message in the input array.
+type IndexedSendResponse struct {
+	Index int
+	SendResponse
+}
+
+// SendEachAsync sends the messages in the given array via Firebase Cloud Messaging, and returns
+// a channel on which the status of each message is delivered as soon as it is known.
+//
+// Like SendEach(), SendEachAsync makes a single HTTP call for each message, but it does not limit
+// the number of messages, and it does not wait for the messages to be sent. At most
+// MaxConcurrency requests are in flight at any time (see SetMaxConcurrency()). The channel
+// receives exactly one response per message, in no particular order, and is closed once all the
+// messages have been processed. Callers must receive from the channel until it is closed.
+//
+// If the context is canceled or its deadline expires, SendEachAsync stops sending and aborts the
+// in-flight requests. The remaining messages are reported as failures for which
+// errorutils.IsCancelled returns true. An error is returned only if the array is empty or
+// contains an invalid message, in which case no messages are sent.
+func (c *fcmClient) SendEachAsync(ctx context.Context, messages []*Message) (<-chan *IndexedSendResponse, error) {
+	return c.sendEachAsync(ctx, messages, false)
+}
+
+// SendEachAsyncDryRun sends the messages in the given array via Firebase Cloud Messaging in the
+// dry run (validation only) mode, and returns a channel on which the status of each message is
+// delivered as soon as it is known.
+//
+// This function does not actually deliver any messages to target devices. Instead, it performs all
+// the SDK-level and backend validations on the messages, and emulates the send operation. See
+// SendEachAsync() for details on how the messages are sent and reported.
+func (c *fcmClient) SendEachAsyncDryRun(ctx context.Context, messages []*Message) (<-chan *IndexedSendResponse, error) {
+	return c.sendEachAsync(ctx, messages, true)
+}
+
+func (c *fcmClient) sendEachAsync(ctx context.Context, messages []*Message, dryRun bool) (<-chan *IndexedSendResponse, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("messages must not be nil or empty")
+	}
+
+	if err := validateMessages(messages); err != nil {
+		return nil, err
+	}
+
+	responses := make(chan *IndexedSendResponse, c.workers(len(messages)))
+	go func() {
+		defer close(responses)
+		c.sendEach(ctx, messages, dryRun, func(idx int, resp *SendResponse) {
+			responses <- &IndexedSendResponse{Index: idx, SendResponse: *resp}
+		})
+	}()
+	return responses, nil
+}
+
+// SetMaxConcurrency sets the maximum number of send requests that a single SendEach() or
+// SendEachAsync() call, or one of their variants, keeps in flight. Zero restores the default
+// limit of 500 requests.
+//
+// Requests share the connections of the underlying HTTP client. With the default transport,
+// requests to FCM are multiplexed over HTTP/2 connections, so a higher limit does not require
+// additional connections. This method should be called before the client is used concurrently.
+func (c *fcmClient) SetMaxConcurrency(n int) error {
+	if n < 0 {
+		return errors.New("max concurrency must not be negative")
+	}
+	c.maxConcurrency = n
+	return nil
+}
+
+// SetMessageTimeout sets a timeout for sending each individual message in a SendEach() or
+// SendEachAsync() call, or one of their variants, including any retries. A message that is not sent
+// in time is reported as a failure for which errorutils.IsDeadlineExceeded returns true, and does
+// not affect the other messages. Zero, the default, removes the timeout.
+//
+// This method should be called before the client is used concurrently.
+func (c *fcmClient) SetMessageTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.New("message timeout must not be negative")
+	}
+	c.messageTimeout = timeout
+	return nil
+}
+
+func (c *fcmClient) workers(count int) int {
+	n := c.maxConcurrency
+	if n == 0 {
+		n = defaultMaxConcurrency
+	}
+	if n > count {
+		n = count
+	}
+	return n
+}
+
+func validateMessages(messages []*Message) error {
+	for idx, m := range messages {
+		if err := validateMessage(m); err != nil {
+			return fmt.Errorf("invalid message at index %d: %v", idx, err)
+		}
+	}
+	return nil
+}
+
+// sendEach sends the given messages from a pool of workers, and reports the status of each
+// message to the report function, which may be called concurrently.
+func (c *fcmClient) sendEach(ctx context.Context, messages []*Message, dryRun bool, report func(int, *SendResponse)) {
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < c.workers(len(messages)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				report(idx, c.sendOne(ctx, messages[idx], dryRun))
+			}
+		}()
+	}
+
+	for idx := range messages {
+		indices <- idx
+	}
+	close(indices)
+	wg.Wait()
+}
+
+func (c *fcmClient) sendOne(ctx context.Context, m *Message, dryRun bool) *SendResponse {
+	// Once the context is done, stop sending and mark the remaining messages as canceled. Sends
+	// already in flight are aborted through the same context.
+	if ctx.Err() != nil {
+		return newCanceledResponse(ctx)
+	}
+
+	sendCtx := ctx
+	if c.messageTimeout > 0 {
+		var cancel context.CancelFunc
+		sendCtx, cancel = context.WithTimeout(ctx, c.messageTimeout)
+		defer cancel()
+	}
+
+	var resp string
+	var err error
+	if dryRun {
+		resp, err = c.SendDryRun(sendCtx, m)
+	} else {
+		resp, err = c.Send(sendCtx, m)
+	}
+	if err == nil {
+		return &SendResponse{
+			Success:   true,
+			MessageID: resp,
+		}
+	}
+	if ctx.Err() != nil {
+		return newCanceledResponse(ctx)
+	}
+	return &SendResponse{
+		Success: false,
+		Error:   err,
+	}
+}
+
+// newCanceledResponse returns the response reported for a message that was not sent, or whose
+// send was aborted, because the context of a SendEach call was done. The error wraps the context
+// error, and has the DeadlineExceeded code if the deadline of the context expired, or the
+// Cancelled code otherwise.
+func newCanceledResponse(ctx context.Context) *SendResponse {
+	err := ctx.Err()
+	code := internal.Cancelled
+	if errors.Is(err, context.DeadlineExceeded) {
+		code = internal.DeadlineExceeded
+	}
+	return &SendResponse{
+		Success: false,
+		Error: &internal.FirebaseError{
+			ErrorCode: code,
+			String:    fmt.Sprintf("message not sent: %v", err),
+			Details:   err,
+		},
+	}
+}
+
 // SendAll sends the messages in the given array via Firebase Cloud Messaging.
 //
 // The messages array may contain up to 500 messages. SendAll employs batching to send the entire
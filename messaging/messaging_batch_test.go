@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -27,8 +28,11 @@ import (
 	"net/http/httptest"
 	"net/textproto"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"firebase.google.com/go/v4/errorutils"
 	"google.golang.org/api/option"
 )
 
@@ -351,6 +355,325 @@ func TestSendEachTotalFailure(t *testing.T) {
 	}
 }
 
+func TestSendEachCanceledContext(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	br, err := client.SendEach(ctx, testMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if br.SuccessCount != 0 || br.FailureCount != len(testMessages) {
+		t.Errorf("SendEach() = (%d, %d); want = (0, %d)", br.SuccessCount, br.FailureCount, len(testMessages))
+	}
+	for idx, r := range br.Responses {
+		if r.Success || !errorutils.IsCancelled(r.Error) || !errors.Is(r.Error, context.Canceled) {
+			t.Errorf("SendEach() Responses[%d] = %v; want = canceled error", idx, r.Error)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("SendEach() requests = %d; want = 0", requests)
+	}
+}
+
+func TestSendEachContextDeadline(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	br, err := client.SendEach(ctx, testMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if br.SuccessCount != 0 || br.FailureCount != len(testMessages) {
+		t.Errorf("SendEach() = (%d, %d); want = (0, %d)", br.SuccessCount, br.FailureCount, len(testMessages))
+	}
+	for idx, r := range br.Responses {
+		if r.Success || !errorutils.IsDeadlineExceeded(r.Error) || errorutils.IsCancelled(r.Error) ||
+			!errors.Is(r.Error, context.DeadlineExceeded) {
+			t.Errorf("SendEach() Responses[%d] = %v; want = deadline exceeded error", idx, r.Error)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("SendEach() requests = %d; want = 0", requests)
+	}
+}
+
+func TestSendEachCancelInFlight(t *testing.T) {
+	started := make(chan struct{})
+	aborted := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(req), testMessages[1].Topic) {
+			close(started)
+			<-r.Context().Done()
+			close(aborted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-started
+		cancel()
+	}()
+	br, err := client.SendEach(ctx, testMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if br.FailureCount == 0 || br.SuccessCount+br.FailureCount != len(testMessages) {
+		t.Errorf("SendEach() = (%d, %d); want at least one failure", br.SuccessCount, br.FailureCount)
+	}
+	if r := br.Responses[1]; r.Success || !errorutils.IsCancelled(r.Error) || !errors.Is(r.Error, context.Canceled) {
+		t.Errorf("SendEach() Responses[1] = %v; want = canceled error", r.Error)
+	}
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Errorf("SendEach() did not abort the in-flight request")
+	}
+}
+
+func TestSendEachMessageTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(req), testMessages[1].Topic) {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+	client.SetRetryConfig(nil)
+	if err := client.SetMessageTimeout(50 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	br, err := client.SendEach(ctx, testMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if br.SuccessCount != 1 || br.FailureCount != 1 {
+		t.Errorf("SendEach() = (%d, %d); want = (1, 1)", br.SuccessCount, br.FailureCount)
+	}
+	if r := br.Responses[0]; !r.Success {
+		t.Errorf("SendEach() Responses[0] = %v; want = success", r.Error)
+	}
+	if r := br.Responses[1]; r.Success || !errorutils.IsDeadlineExceeded(r.Error) {
+		t.Errorf("SendEach() Responses[1] = %v; want = deadline exceeded error", r.Error)
+	}
+
+	want := "message timeout must not be negative"
+	if err := client.SetMessageTimeout(-1); err == nil || err.Error() != want {
+		t.Errorf("SetMessageTimeout(-1) = %v; want = %q", err, want)
+	}
+}
+
+func TestSendEachMaxConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, peak int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+	if err := client.SetMaxConcurrency(2); err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []*Message
+	for i := 0; i < 10; i++ {
+		messages = append(messages, &Message{Topic: fmt.Sprintf("topic%d", i)})
+	}
+	br, err := client.SendEach(ctx, messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if br.SuccessCount != len(messages) {
+		t.Errorf("SendEach() SuccessCount = %d; want = %d", br.SuccessCount, len(messages))
+	}
+	if peak > 2 {
+		t.Errorf("SendEach() in-flight requests = %d; want <= 2", peak)
+	}
+}
+
+func TestSetMaxConcurrencyInvalid(t *testing.T) {
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "max concurrency must not be negative"
+	if err := client.SetMaxConcurrency(-1); err == nil || err.Error() != want {
+		t.Errorf("SetMaxConcurrency(-1) = %v; want = %q", err, want)
+	}
+}
+
+func TestSendEachAsync(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(req), testMessages[1].Topic) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("{}"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+	client.SetRetryConfig(nil)
+
+	responses, err := client.SendEachAsync(ctx, testMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[int]*IndexedSendResponse)
+	for r := range responses {
+		if _, ok := got[r.Index]; ok {
+			t.Errorf("SendEachAsync() reported index %d twice", r.Index)
+		}
+		got[r.Index] = r
+	}
+	if len(got) != len(testMessages) {
+		t.Fatalf("SendEachAsync() = %d responses; want = %d", len(got), len(testMessages))
+	}
+	if r := got[0]; !r.Success || r.MessageID != testSuccessResponse[0].Name || r.Error != nil {
+		t.Errorf("SendEachAsync() Responses[0] = %#v; want success", r.SendResponse)
+	}
+	if r := got[1]; r.Success || !errorutils.IsInternal(r.Error) {
+		t.Errorf("SendEachAsync() Responses[1] = %#v; want internal error", r.SendResponse)
+	}
+}
+
+func TestSendEachAsyncDryRun(t *testing.T) {
+	var dryRun int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fcmRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ValidateOnly {
+			dryRun++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{ \"name\":\"" + testSuccessResponse[0].Name + "\" }"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+	client.SetMaxConcurrency(1)
+
+	responses, err := client.SendEachAsyncDryRun(ctx, testMessages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for r := range responses {
+		if !r.Success {
+			t.Errorf("SendEachAsyncDryRun() Responses[%d] = %v; want success", r.Index, r.Error)
+		}
+		count++
+	}
+
+	if count != len(testMessages) || dryRun != len(testMessages) {
+		t.Errorf("SendEachAsyncDryRun() = (%d, %d dry run); want = %d", count, dryRun, len(testMessages))
+	}
+}
+
+func TestSendEachAsyncInvalid(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "messages must not be nil or empty"
+	if _, err := client.SendEachAsync(ctx, nil); err == nil || err.Error() != want {
+		t.Errorf("SendEachAsync(nil) = %v; want = %q", err, want)
+	}
+
+	want = "invalid message at index 1: message must not be nil"
+	if _, err := client.SendEachAsync(ctx, []*Message{{Topic: "topic"}, nil}); err == nil || err.Error() != want {
+		t.Errorf("SendEachAsync() = %v; want = %q", err, want)
+	}
+}
+
 func TestSendEachForMulticastNil(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClient(ctx, testMessagingConfig)
@@ -17,10 +17,13 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -175,11 +178,13 @@ var validMessages = []struct {
 					},
 					Visibility:           VisibilityPrivate,
 					DefaultLightSettings: true,
+					Proxy:                ProxyIfPriorityLowered,
 				},
 				TTL: &ttlWithNanos,
 				FCMOptions: &AndroidFCMOptions{
 					AnalyticsLabel: "Analytics",
 				},
+				DirectBootOK: true,
 			},
 			Topic: "test-topic",
 		},
@@ -217,11 +222,13 @@ var validMessages = []struct {
 					},
 					"visibility":             "PRIVATE",
 					"default_light_settings": true,
+					"proxy":                  "IF_PRIORITY_LOWERED",
 				},
 				"ttl": "1.500000000s",
 				"fcm_options": map[string]interface{}{
 					"analytics_label": "Analytics",
 				},
+				"direct_boot_ok": true,
 			},
 			"topic": "test-topic",
 		},
@@ -313,7 +320,8 @@ var validMessages = []struct {
 					CustomData:         map[string]interface{}{"k1": "v1", "k2": "v2"},
 				},
 				FCMOptions: &WebpushFCMOptions{
-					Link: "https://link.com",
+					Link:           "https://link.com",
+					AnalyticsLabel: "Analytics",
 				},
 			},
 			Topic: "test-topic",
@@ -344,12 +352,73 @@ var validMessages = []struct {
 					"k2":                 "v2",
 				},
 				"fcm_options": map[string]interface{}{
-					"link": "https://link.com",
+					"link":            "https://link.com",
+					"analytics_label": "Analytics",
 				},
 			},
 			"topic": "test-topic",
 		},
 	},
+	{
+		name: "WebpushAnalyticsLabelOnly",
+		req: &Message{
+			Webpush: &WebpushConfig{
+				FCMOptions: &WebpushFCMOptions{
+					AnalyticsLabel: "Analytics",
+				},
+			},
+			Topic: "test-topic",
+		},
+		want: map[string]interface{}{
+			"webpush": map[string]interface{}{
+				"fcm_options": map[string]interface{}{
+					"analytics_label": "Analytics",
+				},
+			},
+			"topic": "test-topic",
+		},
+	},
+	{
+		name: "APNSLiveActivity",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{
+					"apns-push-type": "liveactivity",
+					"apns-priority":  "10",
+				},
+				Payload: &APNSPayload{
+					Aps: &Aps{
+						Event:         LiveActivityEventUpdate,
+						Timestamp:     1700000000,
+						ContentState:  map[string]interface{}{"score": "2-1"},
+						StaleDate:     1700003600,
+						DismissalDate: 1700007200,
+					},
+				},
+				LiveActivityToken: "live-activity-token",
+			},
+			Token: "test-token",
+		},
+		want: map[string]interface{}{
+			"apns": map[string]interface{}{
+				"headers": map[string]interface{}{
+					"apns-push-type": "liveactivity",
+					"apns-priority":  "10",
+				},
+				"payload": map[string]interface{}{
+					"aps": map[string]interface{}{
+						"event":          "update",
+						"timestamp":      float64(1700000000),
+						"content-state":  map[string]interface{}{"score": "2-1"},
+						"stale-date":     float64(1700003600),
+						"dismissal-date": float64(1700007200),
+					},
+				},
+				"live_activity_token": "live-activity-token",
+			},
+			"token": "test-token",
+		},
+	},
 	{
 		name: "APNSHeadersOnly",
 		req: &Message{
@@ -693,6 +762,86 @@ var invalidMessages = []struct {
 		},
 		want: "malformed topic name",
 	},
+	{
+		name: "ReservedDataKey",
+		req: &Message{
+			Data:  map[string]string{"from": "value"},
+			Topic: "topic",
+		},
+		want: `data key "from" is reserved`,
+	},
+	{
+		name: "ReservedDataKeyPrefix",
+		req: &Message{
+			Data:  map[string]string{"google.key": "value"},
+			Topic: "topic",
+		},
+		want: `data key "google.key" is reserved`,
+	},
+	{
+		name: "DataPayloadTooLarge",
+		req: &Message{
+			Data:  map[string]string{"key": strings.Repeat("a", 4094)},
+			Topic: "topic",
+		},
+		want: "data payload must not exceed 4096 bytes; got 4097 bytes",
+	},
+	{
+		name: "InvalidAnalyticsLabel",
+		req: &Message{
+			FCMOptions: &FCMOptions{AnalyticsLabel: "label with spaces"},
+			Topic:      "topic",
+		},
+		want: `malformed analytics label: "label with spaces"`,
+	},
+	{
+		name: "AnalyticsLabelTooLong",
+		req: &Message{
+			FCMOptions: &FCMOptions{AnalyticsLabel: strings.Repeat("a", 51)},
+			Topic:      "topic",
+		},
+		want: fmt.Sprintf("malformed analytics label: %q", strings.Repeat("a", 51)),
+	},
+	{
+		name: "InvalidAndroidAnalyticsLabel",
+		req: &Message{
+			Android: &AndroidConfig{
+				FCMOptions: &AndroidFCMOptions{AnalyticsLabel: "label/1"},
+			},
+			Topic: "topic",
+		},
+		want: `android malformed analytics label: "label/1"`,
+	},
+	{
+		name: "InvalidAPNSAnalyticsLabel",
+		req: &Message{
+			APNS: &APNSConfig{
+				FCMOptions: &APNSFCMOptions{AnalyticsLabel: "label#1"},
+			},
+			Topic: "topic",
+		},
+		want: `apns malformed analytics label: "label#1"`,
+	},
+	{
+		name: "ReservedAndroidDataKey",
+		req: &Message{
+			Android: &AndroidConfig{
+				Data: map[string]string{"gcm.key": "value"},
+			},
+			Topic: "topic",
+		},
+		want: `android data key "gcm.key" is reserved`,
+	},
+	{
+		name: "InvalidWebpushTTL",
+		req: &Message{
+			Webpush: &WebpushConfig{
+				Headers: map[string]string{"TTL": "1h"},
+			},
+			Topic: "topic",
+		},
+		want: `webpush TTL header must be a non-negative number of seconds: "1h"`,
+	},
 	{
 		name: "InvalidNotificationImage",
 		req: &Message{
@@ -867,6 +1016,110 @@ var invalidMessages = []struct {
 		},
 		want: "vibrateTimingMillis must not be negative",
 	},
+	{
+		name: "LiveActivityWithoutPushType",
+		req: &Message{
+			APNS: &APNSConfig{
+				Payload: &APNSPayload{
+					Aps: &Aps{Event: LiveActivityEventEnd, Timestamp: 1700000000},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "live activity fields require the apns-push-type header to be 'liveactivity'",
+	},
+	{
+		name: "LiveActivityContentStateOutsideAps",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{"apns-push-type": "liveactivity"},
+				Payload: &APNSPayload{
+					Aps:        &Aps{Event: LiveActivityEventEnd, Timestamp: 1700000000},
+					CustomData: map[string]interface{}{"content-state": map[string]interface{}{}},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "content-state must be specified in the aps dictionary",
+	},
+	{
+		name: "LiveActivityInvalidPriority",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{"apns-push-type": "liveactivity", "apns-priority": "1"},
+				Payload: &APNSPayload{
+					Aps: &Aps{Event: LiveActivityEventEnd, Timestamp: 1700000000},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "live activity apns-priority must be '5' or '10'",
+	},
+	{
+		name: "LiveActivityNoAps",
+		req: &Message{
+			APNS:  &APNSConfig{Headers: map[string]string{"apns-push-type": "liveactivity"}},
+			Topic: "topic",
+		},
+		want: "live activity payload must contain an aps dictionary",
+	},
+	{
+		name: "LiveActivityInvalidEvent",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{"apns-push-type": "liveactivity"},
+				Payload: &APNSPayload{
+					Aps: &Aps{Event: "pause", Timestamp: 1700000000},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "live activity event must be 'start', 'update' or 'end'",
+	},
+	{
+		name: "LiveActivityUpdateWithoutContentState",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{"apns-push-type": "liveactivity"},
+				Payload: &APNSPayload{
+					Aps: &Aps{Event: LiveActivityEventUpdate, Timestamp: 1700000000},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "live activity update event must specify content-state",
+	},
+	{
+		name: "LiveActivityNoTimestamp",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{"apns-push-type": "liveactivity"},
+				Payload: &APNSPayload{
+					Aps: &Aps{Event: LiveActivityEventEnd},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "live activity timestamp must be positive",
+	},
+	{
+		name: "LiveActivityAttributesOnUpdate",
+		req: &Message{
+			APNS: &APNSConfig{
+				Headers: map[string]string{"apns-push-type": "liveactivity"},
+				Payload: &APNSPayload{
+					Aps: &Aps{
+						Event:          LiveActivityEventUpdate,
+						Timestamp:      1700000000,
+						ContentState:   map[string]interface{}{},
+						AttributesType: "GameAttributes",
+					},
+				},
+			},
+			Topic: "topic",
+		},
+		want: "live activity attributes can only be specified for the start event",
+	},
 	{
 		name: "APNSMultipleAps",
 		req: &Message{
@@ -1050,6 +1303,18 @@ var invalidMessages = []struct {
 		},
 		want: `multiple specifications for the key "dir"`,
 	},
+	{
+		name: "InvalidWebpushAnalyticsLabel",
+		req: &Message{
+			Webpush: &WebpushConfig{
+				FCMOptions: &WebpushFCMOptions{
+					AnalyticsLabel: "label with spaces",
+				},
+			},
+			Topic: "topic",
+		},
+		want: `webpush malformed analytics label: "label with spaces"`,
+	},
 	{
 		name: "InvalidWebpushFcmOptionsLink",
 		req: &Message{
@@ -1085,6 +1350,62 @@ func TestNoProjectID(t *testing.T) {
 	}
 }
 
+func TestEmulatorHost(t *testing.T) {
+	t.Setenv(emulatorHostEnvVar, "localhost:9000")
+	cases := []struct {
+		name string
+		conf *internal.MessagingConfig
+		host string
+	}{
+		{
+			name: "EnvVar",
+			conf: &internal.MessagingConfig{ProjectID: "test-project"},
+			host: "localhost:9000",
+		},
+		{
+			name: "Config",
+			conf: &internal.MessagingConfig{
+				ProjectID:    "test-project",
+				EmulatorHost: "localhost:9001",
+				Endpoint:     "https://fcm.example.com",
+			},
+			host: "localhost:9001",
+		},
+	}
+
+	for _, tc := range cases {
+		client, err := NewClient(context.Background(), tc.conf)
+		if err != nil {
+			t.Fatalf("NewClient(%s) = %v", tc.name, err)
+		}
+
+		base := "http://" + tc.host
+		if client.fcmEndpoint != base+"/v1" || client.batchEndpoint != base+"/batch" {
+			t.Errorf("NewClient(%s) endpoints = (%q, %q); want = %q", tc.name, client.fcmEndpoint, client.batchEndpoint, base)
+		}
+		if client.iidEndpoint != base+"/iid/v1" || client.deviceGroupEndpoint != base+"/fcm/notification" {
+			t.Errorf("NewClient(%s) endpoints = (%q, %q); want = %q", tc.name, client.iidEndpoint, client.deviceGroupEndpoint, base)
+		}
+	}
+}
+
+func TestEndpointOverride(t *testing.T) {
+	conf := *testMessagingConfig
+	conf.Endpoint = "https://fcm.example.com"
+	client, err := NewClient(context.Background(), &conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := "https://fcm.example.com"
+	if client.fcmEndpoint != base+"/v1" || client.batchEndpoint != base+"/batch" {
+		t.Errorf("NewClient() endpoints = (%q, %q); want = %q", client.fcmEndpoint, client.batchEndpoint, base)
+	}
+	if client.iidEndpoint != base+"/iid/v1" || client.deviceGroupEndpoint != base+"/fcm/notification" {
+		t.Errorf("NewClient() endpoints = (%q, %q); want = %q", client.iidEndpoint, client.deviceGroupEndpoint, base)
+	}
+}
+
 func TestJSONUnmarshal(t *testing.T) {
 	for _, tc := range validMessages {
 		if tc.name == "PrefixedTopicOnly" {
@@ -1159,6 +1480,13 @@ func TestInvalidJSONUnmarshal(t *testing.T) {
 			},
 			target: &AndroidNotification{},
 		},
+		{
+			name: "InvalidProxy",
+			req: map[string]interface{}{
+				"proxy": "invalid",
+			},
+			target: &AndroidNotification{},
+		},
 		{
 			name: "InvalidEventTimestamp",
 			req: map[string]interface{}{
@@ -1193,6 +1521,126 @@ func TestInvalidJSONUnmarshal(t *testing.T) {
 	}
 }
 
+func TestValidateImages(t *testing.T) {
+	const image = "https://example.com/image.jpg"
+	cases := []struct {
+		name string
+		req  *Message
+		want string
+	}{
+		{
+			name: "NonHTTPSNotificationImage",
+			req: &Message{
+				Notification: &Notification{ImageURL: "http://example.com/image.jpg"},
+			},
+			want: `notification image URL must be an HTTPS URL: "http://example.com/image.jpg"`,
+		},
+		{
+			name: "NonHTTPSWebpushImage",
+			req: &Message{
+				Webpush: &WebpushConfig{
+					Notification: &WebpushNotification{Image: "http://example.com/image.jpg"},
+				},
+			},
+			want: `webpush notification image URL must be an HTTPS URL: "http://example.com/image.jpg"`,
+		},
+		{
+			name: "MismatchedAndroidImage",
+			req: &Message{
+				Notification: &Notification{ImageURL: image},
+				Android: &AndroidConfig{
+					Notification: &AndroidNotification{ImageURL: "https://example.com/other.jpg"},
+				},
+			},
+			want: `android notification image URL "https://example.com/other.jpg" does not match the notification image URL "https://example.com/image.jpg"`,
+		},
+		{
+			name: "MismatchedAPNSImage",
+			req: &Message{
+				Notification: &Notification{ImageURL: image},
+				APNS: &APNSConfig{
+					FCMOptions: &APNSFCMOptions{ImageURL: "https://example.com/other.jpg"},
+				},
+			},
+			want: `apns image URL "https://example.com/other.jpg" does not match the notification image URL "https://example.com/image.jpg"`,
+		},
+		{
+			name: "MismatchedWebpushImage",
+			req: &Message{
+				Notification: &Notification{ImageURL: image},
+				Webpush: &WebpushConfig{
+					Notification: &WebpushNotification{Image: "https://example.com/other.jpg"},
+				},
+			},
+			want: `webpush notification image URL "https://example.com/other.jpg" does not match the notification image URL "https://example.com/image.jpg"`,
+		},
+	}
+	for _, tc := range cases {
+		err := tc.req.ValidateImages()
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("ValidateImages(%s) = %v; want = %q", tc.name, err, tc.want)
+		}
+		// The image checks are not performed by Validate.
+		tc.req.Topic = "topic"
+		if err := tc.req.Validate(); err != nil {
+			t.Errorf("Validate(%s) = %v; want = nil", tc.name, err)
+		}
+	}
+
+	msg := &Message{Topic: "topic"}
+	msg.SetImageURL(image)
+	if err := msg.ValidateImages(); err != nil {
+		t.Errorf("ValidateImages() = %v; want = nil", err)
+	}
+}
+
+func TestSetImageURL(t *testing.T) {
+	const image = "https://example.com/image.jpg"
+	msg := &Message{
+		Android: &AndroidConfig{
+			Notification: &AndroidNotification{},
+		},
+		Webpush: &WebpushConfig{
+			Notification: &WebpushNotification{
+				Image: "https://example.com/web.jpg",
+			},
+		},
+		Topic: "topic",
+	}
+	msg.SetImageURL(image)
+
+	if err := validateMessage(msg); err != nil {
+		t.Fatalf("validateMessage() = %v; want = nil", err)
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"topic":        "topic",
+		"notification": map[string]interface{}{"image": image},
+		"android": map[string]interface{}{
+			"notification": map[string]interface{}{"image": image},
+		},
+		"webpush": map[string]interface{}{
+			"notification": map[string]interface{}{"image": "https://example.com/web.jpg"},
+		},
+		"apns": map[string]interface{}{
+			"fcm_options": map[string]interface{}{"image": image},
+			"payload": map[string]interface{}{
+				"aps": map[string]interface{}{"mutable-content": float64(1)},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SetImageURL() = %v; want = %v", got, want)
+	}
+}
+
 func TestSend(t *testing.T) {
 	var tr *http.Request
 	var b []byte
@@ -1259,6 +1707,29 @@ func TestSendWithCustomEndpoint(t *testing.T) {
 	}
 }
 
+func TestNewClientWarmUp(t *testing.T) {
+	var reqs []*http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	conf := *testMessagingConfig
+	conf.Opts = append(conf.Opts, option.WithEndpoint(ts.URL))
+	conf.WarmUp = true
+	if _, err := NewClient(context.Background(), &conf); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reqs) != 1 || reqs[0].Method != http.MethodHead {
+		t.Fatalf("WarmUp requests = %v; want = [HEAD]", reqs)
+	}
+	if h := reqs[0].Header.Get("Authorization"); h != "Bearer test-token" {
+		t.Errorf("Authorization = %q; want = %q", h, "Bearer test-token")
+	}
+}
+
 func TestSendDryRun(t *testing.T) {
 	var tr *http.Request
 	var b []byte
@@ -1314,6 +1785,68 @@ func TestSendError(t *testing.T) {
 	}
 }
 
+func TestSendErrorDetails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"status": "NOT_FOUND", "message": "Requested entity was not found.", "details": [` +
+			`{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"}]}}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	_, err = client.Send(ctx, &Message{Token: "token"})
+	wrapped := fmt.Errorf("sending to token: %w", err)
+	var fcmErr *Error
+	if !errors.As(wrapped, &fcmErr) {
+		t.Fatalf("Send() = %v; want = *Error", err)
+	}
+	want := &Error{
+		Code:       ErrorCodeUnregistered,
+		Status:     "NOT_FOUND",
+		Message:    "Requested entity was not found.",
+		HTTPStatus: http.StatusNotFound,
+	}
+	if !reflect.DeepEqual(fcmErr, want) {
+		t.Errorf("Send() Error = %#v; want = %#v", fcmErr, want)
+	}
+	if fcmErr.Error() != "fcm error UNREGISTERED: Requested entity was not found." {
+		t.Errorf("Error() = %q", fcmErr.Error())
+	}
+	if !IsUnregistered(wrapped) || !IsTokenInvalid(wrapped) || !errorutils.IsNotFound(err) {
+		t.Errorf("IsUnregistered() = %v; IsTokenInvalid() = %v; want = true", IsUnregistered(wrapped), IsTokenInvalid(wrapped))
+	}
+	if IsSenderIDMismatch(wrapped) || IsQuotaExceeded(wrapped) {
+		t.Errorf("IsSenderIDMismatch() = %v; IsQuotaExceeded() = %v; want = false",
+			IsSenderIDMismatch(wrapped), IsQuotaExceeded(wrapped))
+	}
+}
+
+func TestIsTokenInvalid(t *testing.T) {
+	cases := map[ErrorCode]bool{
+		ErrorCodeUnregistered:     true,
+		ErrorCodeSenderIDMismatch: true,
+		ErrorCodeInvalidArgument:  false,
+		ErrorCodeQuotaExceeded:    false,
+		ErrorCodeUnspecified:      false,
+	}
+	for code, want := range cases {
+		err := &internal.FirebaseError{Details: &Error{Code: code}}
+		if got := IsTokenInvalid(err); got != want {
+			t.Errorf("IsTokenInvalid(%q) = %v; want = %v", code, got, want)
+		}
+	}
+	if IsTokenInvalid(errors.New("other error")) {
+		t.Errorf("IsTokenInvalid(other) = true; want = false")
+	}
+}
+
 func TestInvalidMessage(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClient(ctx, testMessagingConfig)
@@ -1326,6 +1859,9 @@ func TestInvalidMessage(t *testing.T) {
 			if err == nil || err.Error() != tc.want {
 				t.Errorf("Send(%s) = (%q, %v); want = (%q, %q)", tc.name, name, err, "", tc.want)
 			}
+			if err := tc.req.Validate(); err == nil || err.Error() != tc.want {
+				t.Errorf("Validate(%s) = %v; want = %q", tc.name, err, tc.want)
+			}
 		})
 	}
 }
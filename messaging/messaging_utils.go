@@ -19,15 +19,39 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 var (
 	bareTopicNamePattern  = regexp.MustCompile("^[a-zA-Z0-9-_.~%]+$")
+	analyticsLabelPattern = regexp.MustCompile("^[a-zA-Z0-9-_.~%]{1,50}$")
 	colorPattern          = regexp.MustCompile("^#[0-9a-fA-F]{6}$")
 	colorWithAlphaPattern = regexp.MustCompile("^#[0-9a-fA-F]{6}([0-9a-fA-F]{2})?$")
 )
 
+// maxDataPayloadSize is the maximum total size in bytes of the keys and values of a data payload.
+const maxDataPayloadSize = 4096
+
+// reservedDataKeys are the data payload keys reserved by FCM. Keys starting with "google" or
+// "gcm" are reserved as well.
+var reservedDataKeys = map[string]bool{
+	"from":         true,
+	"notification": true,
+	"message_type": true,
+}
+
+// Validate checks the message for the errors the FCM backend would reject it with, so that they
+// can be reported before sending it. Send and the other send functions perform the same checks.
+//
+// Validate checks that exactly one target is specified, the topic name, analytics labels, colors,
+// TTLs, data payload keys and data payload size, and the consistency of the platform-specific
+// settings. Passing validation does not guarantee that the message is accepted, as some checks
+// (such as whether a registration token is valid) can only be performed by the backend.
+func (m *Message) Validate() error {
+	return validateMessage(m)
+}
+
 func validateMessage(message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message must not be nil")
@@ -40,12 +64,23 @@ func validateMessage(message *Message) error {
 
 	// validate topic
 	if message.Topic != "" {
-		bt := strings.TrimPrefix(message.Topic, "/topics/")
+		bt := bareTopic(message.Topic)
 		if !bareTopicNamePattern.MatchString(bt) {
 			return fmt.Errorf("malformed topic name")
 		}
 	}
 
+	if err := validateData(message.Data); err != nil {
+		return err
+	}
+
+	// validate FCMOptions
+	if message.FCMOptions != nil {
+		if err := validateAnalyticsLabel(message.FCMOptions.AnalyticsLabel); err != nil {
+			return err
+		}
+	}
+
 	// validate Notification
 	if err := validateNotification(message.Notification); err != nil {
 		return err
@@ -70,10 +105,75 @@ func validateNotification(notification *Notification) error {
 		return nil
 	}
 
-	image := notification.ImageURL
-	if image != "" {
-		if _, err := url.ParseRequestURI(image); err != nil {
-			return fmt.Errorf("invalid image URL: %q", image)
+	return validateImageURL(notification.ImageURL)
+}
+
+// bareTopic returns the topic name without the optional "/topics/" prefix. Topic names are
+// accepted with or without the prefix, and normalized with this function before use.
+func bareTopic(topic string) string {
+	return strings.TrimPrefix(topic, "/topics/")
+}
+
+// validateAnalyticsLabel checks that the label consists of at most 50 letters, digits and the
+// characters "-_.~%". An empty label is not sent.
+func validateAnalyticsLabel(label string) error {
+	if label != "" && !analyticsLabelPattern.MatchString(label) {
+		return fmt.Errorf("malformed analytics label: %q", label)
+	}
+	return nil
+}
+
+func validateImageURL(image string) error {
+	if image == "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(image); err != nil {
+		return fmt.Errorf("invalid image URL: %q", image)
+	}
+	return nil
+}
+
+// ValidateImages performs stricter checks on the images of the message than Validate and the
+// send functions do.
+//
+// It checks that all the image URLs of the message are HTTPS URLs, and that the Android, APNS and
+// Webpush image URLs, when specified along with the notification image URL, refer to the same
+// image. Some platforms silently drop images that do not meet these requirements.
+func (m *Message) ValidateImages() error {
+	type platformImage struct {
+		platform string
+		image    string
+	}
+	var images []platformImage
+	var notificationImage string
+	if m.Notification != nil {
+		notificationImage = m.Notification.ImageURL
+		images = append(images, platformImage{"notification", notificationImage})
+	}
+	if m.Android != nil && m.Android.Notification != nil {
+		images = append(images, platformImage{"android notification", m.Android.Notification.ImageURL})
+	}
+	if m.APNS != nil && m.APNS.FCMOptions != nil {
+		images = append(images, platformImage{"apns", m.APNS.FCMOptions.ImageURL})
+	}
+	if m.Webpush != nil && m.Webpush.Notification != nil {
+		images = append(images, platformImage{"webpush notification", m.Webpush.Notification.Image})
+	}
+
+	for _, i := range images {
+		if i.image == "" {
+			continue
+		}
+		u, err := url.ParseRequestURI(i.image)
+		if err != nil {
+			return fmt.Errorf("invalid %s image URL: %q", i.platform, i.image)
+		}
+		if u.Scheme != "https" {
+			return fmt.Errorf("%s image URL must be an HTTPS URL: %q", i.platform, i.image)
+		}
+		if notificationImage != "" && i.image != notificationImage {
+			return fmt.Errorf("%s image URL %q does not match the notification image URL %q",
+				i.platform, i.image, notificationImage)
 		}
 	}
 	return nil
@@ -90,6 +190,14 @@ func validateAndroidConfig(config *AndroidConfig) error {
 	if config.Priority != "" && config.Priority != "normal" && config.Priority != "high" {
 		return fmt.Errorf("priority must be 'normal' or 'high'")
 	}
+	if err := validateData(config.Data); err != nil {
+		return fmt.Errorf("android %v", err)
+	}
+	if config.FCMOptions != nil {
+		if err := validateAnalyticsLabel(config.FCMOptions.AnalyticsLabel); err != nil {
+			return fmt.Errorf("android %v", err)
+		}
+	}
 
 	// validate AndroidNotification
 	return validateAndroidNotification(config.Notification)
@@ -108,11 +216,8 @@ func validateAndroidNotification(notification *AndroidNotification) error {
 	if len(notification.BodyLocArgs) > 0 && notification.BodyLocKey == "" {
 		return fmt.Errorf("bodyLocKey is required when specifying bodyLocArgs")
 	}
-	image := notification.ImageURL
-	if image != "" {
-		if _, err := url.ParseRequestURI(image); err != nil {
-			return fmt.Errorf("invalid image URL: %q", image)
-		}
+	if err := validateImageURL(notification.ImageURL); err != nil {
+		return err
 	}
 	for _, timing := range notification.VibrateTimingMillis {
 		if timing < 0 {
@@ -143,18 +248,63 @@ func validateAPNSConfig(config *APNSConfig) error {
 	if config != nil {
 		// validate FCMOptions
 		if config.FCMOptions != nil {
-			image := config.FCMOptions.ImageURL
-			if image != "" {
-				if _, err := url.ParseRequestURI(image); err != nil {
-					return fmt.Errorf("invalid image URL: %q", image)
-				}
+			if err := validateImageURL(config.FCMOptions.ImageURL); err != nil {
+				return err
 			}
+			if err := validateAnalyticsLabel(config.FCMOptions.AnalyticsLabel); err != nil {
+				return fmt.Errorf("apns %v", err)
+			}
+		}
+		if err := validateLiveActivity(config); err != nil {
+			return err
 		}
 		return validateAPNSPayload(config.Payload)
 	}
 	return nil
 }
 
+// validateLiveActivity checks that Live Activity updates specify the push type, priority and aps
+// fields APNs requires, and that the Live Activity fields are not used in other notifications.
+func validateLiveActivity(config *APNSConfig) error {
+	var aps *Aps
+	if config.Payload != nil {
+		if _, ok := config.Payload.CustomData["content-state"]; ok {
+			return fmt.Errorf("content-state must be specified in the aps dictionary")
+		}
+		aps = config.Payload.Aps
+	}
+
+	if config.Headers["apns-push-type"] != "liveactivity" {
+		if config.LiveActivityToken != "" || (aps != nil && (aps.Event != "" || aps.ContentState != nil)) {
+			return fmt.Errorf("live activity fields require the apns-push-type header to be 'liveactivity'")
+		}
+		return nil
+	}
+
+	if p, ok := config.Headers["apns-priority"]; ok && p != "5" && p != "10" {
+		return fmt.Errorf("live activity apns-priority must be '5' or '10'")
+	}
+	if aps == nil {
+		return fmt.Errorf("live activity payload must contain an aps dictionary")
+	}
+	switch aps.Event {
+	case LiveActivityEventStart, LiveActivityEventUpdate:
+		if aps.ContentState == nil {
+			return fmt.Errorf("live activity %s event must specify content-state", aps.Event)
+		}
+	case LiveActivityEventEnd:
+	default:
+		return fmt.Errorf("live activity event must be 'start', 'update' or 'end'")
+	}
+	if aps.Timestamp <= 0 {
+		return fmt.Errorf("live activity timestamp must be positive")
+	}
+	if aps.Event != LiveActivityEventStart && (aps.AttributesType != "" || aps.Attributes != nil) {
+		return fmt.Errorf("live activity attributes can only be specified for the start event")
+	}
+	return nil
+}
+
 func validateAPNSPayload(payload *APNSPayload) error {
 	if payload != nil {
 		m := payload.standardFields()
@@ -209,7 +359,20 @@ func validateApsAlert(alert *ApsAlert) error {
 }
 
 func validateWebpushConfig(webpush *WebpushConfig) error {
-	if webpush == nil || webpush.Notification == nil {
+	if webpush == nil {
+		return nil
+	}
+	if ttl, ok := webpush.Headers["TTL"]; ok {
+		if v, err := strconv.ParseInt(ttl, 10, 64); err != nil || v < 0 {
+			return fmt.Errorf("webpush TTL header must be a non-negative number of seconds: %q", ttl)
+		}
+	}
+	if webpush.FCMOptions != nil {
+		if err := validateAnalyticsLabel(webpush.FCMOptions.AnalyticsLabel); err != nil {
+			return fmt.Errorf("webpush %v", err)
+		}
+	}
+	if webpush.Notification == nil {
 		return nil
 	}
 	dir := webpush.Notification.Direction
@@ -222,7 +385,7 @@ func validateWebpushConfig(webpush *WebpushConfig) error {
 			return fmt.Errorf("multiple specifications for the key %q", k)
 		}
 	}
-	if webpush.FCMOptions != nil {
+	if webpush.FCMOptions != nil && (webpush.FCMOptions.Link != "" || webpush.FCMOptions.AnalyticsLabel == "") {
 		link := webpush.FCMOptions.Link
 		p, err := url.ParseRequestURI(link)
 		if err != nil {
@@ -234,6 +397,20 @@ func validateWebpushConfig(webpush *WebpushConfig) error {
 	return nil
 }
 
+func validateData(data map[string]string) error {
+	size := 0
+	for k, v := range data {
+		if reservedDataKeys[k] || strings.HasPrefix(k, "google") || strings.HasPrefix(k, "gcm") {
+			return fmt.Errorf("data key %q is reserved", k)
+		}
+		size += len(k) + len(v)
+	}
+	if size > maxDataPayloadSize {
+		return fmt.Errorf("data payload must not exceed %d bytes; got %d bytes", maxDataPayloadSize, size)
+	}
+	return nil
+}
+
 func countNonEmpty(strings ...string) int {
 	count := 0
 	for _, s := range strings {
@@ -0,0 +1,184 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"encoding/json"
+)
+
+// ResolvedPayloads contains the payload of a Message as it applies to each platform, after the
+// platform-specific overrides are applied to the common fields of the Message.
+//
+// Each payload is rendered as indented JSON, in the format of the corresponding platform config
+// of the FCM v1 API.
+type ResolvedPayloads struct {
+	Android json.RawMessage `json:"android"`
+	APNS    json.RawMessage `json:"apns"`
+	Webpush json.RawMessage `json:"webpush"`
+}
+
+// ResolvePayloads renders the payload that each platform would receive for the given message,
+// which is useful for debugging how the platform-specific configs override the common fields.
+//
+// The common fields are resolved as follows:
+//   - Android and Webpush: the Data of the Message is used unless the platform config specifies
+//     its own data. The Notification title, body and image are used for the fields that are not
+//     set on the platform notification.
+//   - APNS: the Notification title and body are used for the fields that are not set on the aps
+//     alert, unless the alert is specified as a string. The Notification image is used unless the
+//     APNS FCMOptions specify one. The Data of the Message is added to the payload as custom keys
+//     that are not already set.
+//
+// This mirrors how the FCM backend builds the platform messages, but the backend remains the
+// authority on the messages delivered to devices. The message is validated in the same way as in
+// Send, and nothing is sent.
+func ResolvePayloads(message *Message) (*ResolvedPayloads, error) {
+	if err := validateMessage(message); err != nil {
+		return nil, err
+	}
+
+	common, err := toJSONMap(message.Notification)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]interface{}, len(message.Data))
+	for k, v := range message.Data {
+		data[k] = v
+	}
+
+	android, err := resolveDataAndNotification(message.Android, data, common)
+	if err != nil {
+		return nil, err
+	}
+	webpush, err := resolveDataAndNotification(message.Webpush, data, common)
+	if err != nil {
+		return nil, err
+	}
+	apns, err := resolveAPNS(message.APNS, data, common)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResolvedPayloads{}
+	for _, p := range []struct {
+		dst *json.RawMessage
+		src map[string]interface{}
+	}{
+		{&result.Android, android},
+		{&result.APNS, apns},
+		{&result.Webpush, webpush},
+	} {
+		b, err := json.MarshalIndent(p.src, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		*p.dst = b
+	}
+	return result, nil
+}
+
+// resolveDataAndNotification applies the common data and notification fields to an Android or
+// Webpush config, which share the same override semantics.
+func resolveDataAndNotification(config interface{}, data, common map[string]interface{}) (map[string]interface{}, error) {
+	resolved, err := toJSONMap(config)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := resolved["data"]; !ok && len(data) > 0 {
+		resolved["data"] = data
+	}
+
+	notification, _ := resolved["notification"].(map[string]interface{})
+	if merged := mergeDefaults(notification, common); len(merged) > 0 {
+		resolved["notification"] = merged
+	}
+	return resolved, nil
+}
+
+func resolveAPNS(config *APNSConfig, data, common map[string]interface{}) (map[string]interface{}, error) {
+	resolved, err := toJSONMap(config)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, _ := resolved["payload"].(map[string]interface{})
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+	aps, _ := payload["aps"].(map[string]interface{})
+	if aps == nil {
+		aps = make(map[string]interface{})
+	}
+
+	if _, isString := aps["alert"].(string); !isString {
+		alert, _ := aps["alert"].(map[string]interface{})
+		alertDefaults := make(map[string]interface{})
+		for _, key := range []string{"title", "body"} {
+			if v, ok := common[key]; ok {
+				alertDefaults[key] = v
+			}
+		}
+		if merged := mergeDefaults(alert, alertDefaults); len(merged) > 0 {
+			aps["alert"] = merged
+		}
+	}
+	if len(aps) > 0 {
+		payload["aps"] = aps
+	}
+
+	for k, v := range data {
+		if _, ok := payload[k]; !ok {
+			payload[k] = v
+		}
+	}
+	if len(payload) > 0 {
+		resolved["payload"] = payload
+	}
+
+	if image, ok := common["image"]; ok {
+		fcmOptions, _ := resolved["fcm_options"].(map[string]interface{})
+		resolved["fcm_options"] = mergeDefaults(fcmOptions, map[string]interface{}{"image": image})
+	}
+	return resolved, nil
+}
+
+// mergeDefaults returns a copy of m, where the keys that are not set in m are taken from defaults.
+func mergeDefaults(m, defaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(m)+len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range m {
+		merged[k] = v
+	}
+	return merged
+}
+
+// toJSONMap converts a value into its generic JSON representation. A nil value is converted into
+// an empty map.
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+	return m, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestResolvePayloads(t *testing.T) {
+	message := &Message{
+		Token: "token",
+		Data:  map[string]string{"k1": "v1"},
+		Notification: &Notification{
+			Title:    "title",
+			Body:     "body",
+			ImageURL: "https://example.com/image.png",
+		},
+		Android: &AndroidConfig{
+			Priority: "high",
+			Data:     map[string]string{"k2": "v2"},
+			Notification: &AndroidNotification{
+				Title: "android title",
+				Color: "#112233",
+			},
+		},
+		Webpush: &WebpushConfig{
+			Notification: &WebpushNotification{
+				Body: "webpush body",
+			},
+		},
+		APNS: &APNSConfig{
+			Payload: &APNSPayload{
+				Aps: &Aps{
+					Alert: &ApsAlert{Body: "apns body"},
+				},
+				CustomData: map[string]interface{}{"k1": "custom"},
+			},
+		},
+	}
+
+	payloads, err := ResolvePayloads(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkResolvedPayload(t, "Android", payloads.Android, map[string]interface{}{
+		"priority": "high",
+		"data":     map[string]interface{}{"k2": "v2"},
+		"notification": map[string]interface{}{
+			"title": "android title",
+			"body":  "body",
+			"image": "https://example.com/image.png",
+			"color": "#112233",
+		},
+	})
+	checkResolvedPayload(t, "Webpush", payloads.Webpush, map[string]interface{}{
+		"data": map[string]interface{}{"k1": "v1"},
+		"notification": map[string]interface{}{
+			"title": "title",
+			"body":  "webpush body",
+			"image": "https://example.com/image.png",
+		},
+	})
+	checkResolvedPayload(t, "APNS", payloads.APNS, map[string]interface{}{
+		"payload": map[string]interface{}{
+			"aps": map[string]interface{}{
+				"alert": map[string]interface{}{
+					"title": "title",
+					"body":  "apns body",
+				},
+			},
+			"k1": "custom",
+		},
+		"fcm_options": map[string]interface{}{"image": "https://example.com/image.png"},
+	})
+}
+
+func TestResolvePayloadsCommonFieldsOnly(t *testing.T) {
+	message := &Message{
+		Topic:        "news",
+		Data:         map[string]string{"k1": "v1"},
+		Notification: &Notification{Title: "title"},
+	}
+
+	payloads, err := ResolvePayloads(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"data":         map[string]interface{}{"k1": "v1"},
+		"notification": map[string]interface{}{"title": "title"},
+	}
+	checkResolvedPayload(t, "Android", payloads.Android, want)
+	checkResolvedPayload(t, "Webpush", payloads.Webpush, want)
+	checkResolvedPayload(t, "APNS", payloads.APNS, map[string]interface{}{
+		"payload": map[string]interface{}{
+			"aps": map[string]interface{}{
+				"alert": map[string]interface{}{"title": "title"},
+			},
+			"k1": "v1",
+		},
+	})
+}
+
+func TestResolvePayloadsAlertString(t *testing.T) {
+	message := &Message{
+		Token:        "token",
+		Notification: &Notification{Title: "title", Body: "body"},
+		APNS: &APNSConfig{
+			Payload: &APNSPayload{
+				Aps: &Aps{AlertString: "alert"},
+			},
+		},
+	}
+
+	payloads, err := ResolvePayloads(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkResolvedPayload(t, "APNS", payloads.APNS, map[string]interface{}{
+		"payload": map[string]interface{}{
+			"aps": map[string]interface{}{"alert": "alert"},
+		},
+	})
+}
+
+func TestResolvePayloadsEmpty(t *testing.T) {
+	payloads, err := ResolvePayloads(&Message{Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, p := range map[string]json.RawMessage{
+		"Android": payloads.Android, "APNS": payloads.APNS, "Webpush": payloads.Webpush,
+	} {
+		checkResolvedPayload(t, name, p, map[string]interface{}{})
+	}
+}
+
+func TestResolvePayloadsInvalidMessage(t *testing.T) {
+	for _, m := range []*Message{nil, {}, {Token: "token", Topic: "topic"}} {
+		if payloads, err := ResolvePayloads(m); payloads != nil || err == nil {
+			t.Errorf("ResolvePayloads(%v) = (%v, %v); want = (nil, error)", m, payloads, err)
+		}
+	}
+}
+
+func checkResolvedPayload(t *testing.T, name string, got json.RawMessage, want map[string]interface{}) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(got, &parsed); err != nil {
+		t.Fatalf("%s payload = %s; want = JSON object: %v", name, got, err)
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Errorf("%s payload = %s; want = %v", name, got, want)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const unknownResultErrorCode = "UNKNOWN"
+
+// ResultRow is a flat representation of the outcome of sending a single message, suitable for
+// logging to BigQuery and similar columnar stores.
+//
+// Registration tokens are not included in rows. Instead, rows carry the hex-encoded SHA-256 hash
+// of the token, which can be joined against hashes computed by the same means elsewhere.
+type ResultRow struct {
+	MessageID    string    `json:"message_id"`
+	TokenHash    string    `json:"token_hash"`
+	Topic        string    `json:"topic"`
+	Condition    string    `json:"condition"`
+	Success      bool      `json:"success"`
+	ErrorCode    string    `json:"error_code"`
+	ErrorMessage string    `json:"error_message"`
+	SentAt       time.Time `json:"sent_at"`
+}
+
+// NewResultRows converts the response of SendEach or SendAll into result rows.
+//
+// The messages must be the ones passed to the send call that produced the response, in the same
+// order. Every row is stamped with sentAt, which is typically the time the send call was made.
+func NewResultRows(messages []*Message, br *BatchResponse, sentAt time.Time) ([]*ResultRow, error) {
+	if br == nil {
+		return nil, errors.New("batch response must not be nil")
+	}
+	if len(messages) != len(br.Responses) {
+		return nil, fmt.Errorf("messages and responses must be of the same length: %d != %d",
+			len(messages), len(br.Responses))
+	}
+
+	rows := make([]*ResultRow, len(messages))
+	for i, message := range messages {
+		row := newResultRow(br.Responses[i], sentAt)
+		if message != nil {
+			row.TokenHash = hashToken(message.Token)
+			row.Topic = message.Topic
+			row.Condition = message.Condition
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// NewMulticastResultRows converts the response of SendEachForMulticast or SendMulticast into
+// result rows, one per token of the multicast message.
+//
+// Every row is stamped with sentAt, which is typically the time the send call was made.
+func NewMulticastResultRows(message *MulticastMessage, br *BatchResponse, sentAt time.Time) ([]*ResultRow, error) {
+	if message == nil {
+		return nil, errors.New("message must not be nil")
+	}
+	if br == nil {
+		return nil, errors.New("batch response must not be nil")
+	}
+	if len(message.Tokens) != len(br.Responses) {
+		return nil, fmt.Errorf("tokens and responses must be of the same length: %d != %d",
+			len(message.Tokens), len(br.Responses))
+	}
+
+	rows := make([]*ResultRow, len(message.Tokens))
+	for i, token := range message.Tokens {
+		row := newResultRow(br.Responses[i], sentAt)
+		row.TokenHash = hashToken(token)
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func newResultRow(resp *SendResponse, sentAt time.Time) *ResultRow {
+	row := &ResultRow{
+		SentAt: sentAt.UTC(),
+	}
+	if resp == nil {
+		row.ErrorCode = unknownResultErrorCode
+		return row
+	}
+
+	row.Success = resp.Success
+	row.MessageID = resp.MessageID
+	if resp.Error != nil {
+		row.ErrorCode = resultErrorCode(resp.Error)
+		row.ErrorMessage = resp.Error.Error()
+	}
+	return row
+}
+
+// resultErrorCode returns the FCM error code of the error, falling back to the platform error code
+// when the FCM error code is not available.
+func resultErrorCode(err error) string {
+	fe, ok := err.(*internal.FirebaseError)
+	if !ok {
+		return unknownResultErrorCode
+	}
+	if code, ok := fe.Ext["messagingErrorCode"].(string); ok && code != "" {
+		return code
+	}
+	return string(fe.ErrorCode)
+}
+
+func hashToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// sha256("token1")
+const token1Hash = "df3e6b0bb66ceaadca4f84cbc371fd66e04d20fe51fc414da8d1b84d31d178de"
+
+var testSentAt = time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+var testBatchResponse = &BatchResponse{
+	SuccessCount: 1,
+	FailureCount: 2,
+	Responses: []*SendResponse{
+		{Success: true, MessageID: "projects/test-project/messages/1"},
+		{
+			Error: &internal.FirebaseError{
+				ErrorCode: internal.NotFound,
+				String:    "Requested entity was not found.",
+				Ext:       map[string]interface{}{"messagingErrorCode": "UNREGISTERED"},
+			},
+		},
+		{Error: errors.New("network error")},
+	},
+}
+
+func TestNewResultRows(t *testing.T) {
+	messages := []*Message{
+		{Token: "token1"},
+		{Topic: "news"},
+		{Condition: "'a' in topics"},
+	}
+	rows, err := NewResultRows(messages, testBatchResponse, testSentAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*ResultRow{
+		{
+			MessageID: "projects/test-project/messages/1",
+			TokenHash: token1Hash,
+			Success:   true,
+			SentAt:    testSentAt,
+		},
+		{
+			Topic:        "news",
+			ErrorCode:    "UNREGISTERED",
+			ErrorMessage: "Requested entity was not found.",
+			SentAt:       testSentAt,
+		},
+		{
+			Condition:    "'a' in topics",
+			ErrorCode:    "UNKNOWN",
+			ErrorMessage: "network error",
+			SentAt:       testSentAt,
+		},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("NewResultRows() = %v; want = %v", rows, want)
+	}
+}
+
+func TestNewMulticastResultRows(t *testing.T) {
+	br := &BatchResponse{
+		Responses: []*SendResponse{
+			{Success: true, MessageID: "projects/test-project/messages/1"},
+			{Error: &internal.FirebaseError{ErrorCode: internal.InvalidArgument, String: "invalid"}},
+		},
+	}
+	message := &MulticastMessage{Tokens: []string{"token1", "token2"}}
+	rows, err := NewMulticastResultRows(message, br, testSentAt.In(time.FixedZone("PST", -8*3600)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("NewMulticastResultRows() = %d rows; want = 2", len(rows))
+	}
+	if rows[0].TokenHash != token1Hash || !rows[0].Success {
+		t.Errorf("NewMulticastResultRows()[0] = %v; want = {TokenHash: %q, Success: true}", rows[0], token1Hash)
+	}
+	if rows[1].ErrorCode != "INVALID_ARGUMENT" || rows[1].TokenHash == "" || rows[1].TokenHash == rows[0].TokenHash {
+		t.Errorf("NewMulticastResultRows()[1] = %v; want = {ErrorCode: INVALID_ARGUMENT}", rows[1])
+	}
+	for _, row := range rows {
+		if row.SentAt.Location() != time.UTC || !row.SentAt.Equal(testSentAt) {
+			t.Errorf("SentAt = %v; want = %v", row.SentAt, testSentAt)
+		}
+	}
+}
+
+func TestResultRowJSON(t *testing.T) {
+	row := &ResultRow{
+		MessageID: "projects/test-project/messages/1",
+		TokenHash: token1Hash,
+		Success:   true,
+		SentAt:    testSentAt,
+	}
+	b, err := json.Marshal(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"message_id":"projects/test-project/messages/1","token_hash":"` + token1Hash +
+		`","topic":"","condition":"","success":true,"error_code":"","error_message":"",` +
+		`"sent_at":"2026-01-02T03:04:05Z"}`
+	if string(b) != want {
+		t.Errorf("json.Marshal(ResultRow) = %s; want = %s", string(b), want)
+	}
+}
+
+func TestInvalidResultRows(t *testing.T) {
+	if rows, err := NewResultRows([]*Message{{Token: "token1"}}, testBatchResponse, testSentAt); rows != nil || err == nil {
+		t.Errorf("NewResultRows(mismatched) = (%v, %v); want = (nil, error)", rows, err)
+	}
+	if rows, err := NewResultRows(nil, nil, testSentAt); rows != nil || err == nil {
+		t.Errorf("NewResultRows(nil) = (%v, %v); want = (nil, error)", rows, err)
+	}
+	if rows, err := NewMulticastResultRows(nil, testBatchResponse, testSentAt); rows != nil || err == nil {
+		t.Errorf("NewMulticastResultRows(nil) = (%v, %v); want = (nil, error)", rows, err)
+	}
+	message := &MulticastMessage{Tokens: []string{"token1"}}
+	if rows, err := NewMulticastResultRows(message, testBatchResponse, testSentAt); rows != nil || err == nil {
+		t.Errorf("NewMulticastResultRows(mismatched) = (%v, %v); want = (nil, error)", rows, err)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// RetryConfig specifies how the messaging client retries failed send requests.
+//
+// Requests that fail with a network error, or with one of the configured HTTP status codes, are
+// retried up to MaxRetries times. The first retry is made immediately, and subsequent retries
+// are delayed with exponential backoff: the n-th retry waits 2^(n-1) * BackoffFactor seconds,
+// adjusted by Jitter and capped by MaxDelay. If the response contains a Retry-After header, the
+// retry waits at least as long as the header requires. If the header requires a longer delay than
+// MaxDelay, the request is not retried.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of times a request is retried. Zero disables retries.
+	MaxRetries int
+
+	// StatusCodes are the HTTP status codes on which requests are retried. If empty, requests are
+	// retried on 503 responses, and on 429 responses that contain a Retry-After header. Since a
+	// send request is not idempotent, retrying on other errors such as 500 may deliver a message
+	// more than once.
+	StatusCodes []int
+
+	// BackoffFactor scales the exponential backoff delays, in seconds. Zero retries without
+	// delay, unless the response requires one via the Retry-After header.
+	BackoffFactor float64
+
+	// MaxDelay caps the delay before each retry. Zero means no limit.
+	MaxDelay time.Duration
+
+	// Jitter randomly adjusts each backoff delay by up to this fraction of its value, in the
+	// range [0, 1].
+	Jitter float64
+}
+
+// SetRetryConfig configures how send requests are retried, including the requests made by
+// SendEach, SendAll and their multicast and dry run variants. Passing nil disables retries.
+//
+// By default, requests are retried up to 4 times on network errors and 503 responses, with a
+// backoff factor of 0.5 seconds and a maximum delay of 2 minutes. This method should be called
+// before the client is used concurrently.
+func (c *fcmClient) SetRetryConfig(config *RetryConfig) error {
+	if config == nil {
+		c.httpClient.RetryConfig = nil
+		return nil
+	}
+	if config.MaxRetries < 0 {
+		return errors.New("max retries must not be negative")
+	}
+	if config.BackoffFactor < 0 {
+		return errors.New("backoff factor must not be negative")
+	}
+	if config.MaxDelay < 0 {
+		return errors.New("max delay must not be negative")
+	}
+	if config.Jitter < 0 || config.Jitter > 1 {
+		return errors.New("jitter must be in the range [0, 1]")
+	}
+
+	rc := &internal.RetryConfig{
+		MaxRetries:       config.MaxRetries,
+		CheckForRetry:    retryCondition(config.StatusCodes),
+		ExpBackoffFactor: config.BackoffFactor,
+		Jitter:           config.Jitter,
+	}
+	if config.MaxDelay > 0 {
+		maxDelay := config.MaxDelay
+		rc.MaxDelay = &maxDelay
+	}
+	c.httpClient.RetryConfig = rc
+	return nil
+}
+
+// retryCondition returns a RetryCondition that retries network errors, and responses with one
+// of the given status codes. If no status codes are given, 503 responses are retried, along
+// with 429 responses that specify when to retry via the Retry-After header.
+func retryCondition(codes []int) internal.RetryCondition {
+	statusCodes := make(map[int]bool)
+	for _, code := range codes {
+		statusCodes[code] = true
+	}
+	return func(resp *http.Response, networkErr error) bool {
+		if networkErr != nil {
+			return true
+		}
+		if len(statusCodes) > 0 {
+			return statusCodes[resp.StatusCode]
+		}
+		switch resp.StatusCode {
+		case http.StatusServiceUnavailable:
+			return true
+		case http.StatusTooManyRequests:
+			return resp.Header.Get("Retry-After") != ""
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+)
+
+// newRetryTestClient returns a client whose requests fail with the given status until the
+// number of failures reaches failures.
+func newRetryTestClient(t *testing.T, status int, failures int32, header http.Header) (*Client, *int32, func()) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&requests, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error": {"status": "UNAVAILABLE", "message": "test error"}}`))
+			return
+		}
+		w.Write([]byte("{ \"name\":\"" + testMessageID + "\" }"))
+	}))
+
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+	return client, &requests, ts.Close
+}
+
+func TestSetRetryConfig(t *testing.T) {
+	client, requests, done := newRetryTestClient(t, http.StatusServiceUnavailable, 2, nil)
+	defer done()
+	if err := client.SetRetryConfig(&RetryConfig{MaxRetries: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := client.Send(context.Background(), &Message{Topic: "topic"})
+	if err != nil || name != testMessageID {
+		t.Errorf("Send() = (%q, %v); want = (%q, nil)", name, err, testMessageID)
+	}
+	if *requests != 3 {
+		t.Errorf("Send() requests = %d; want = 3", *requests)
+	}
+}
+
+func TestSetRetryConfigDefaultStatusCodes(t *testing.T) {
+	statuses := []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusGatewayTimeout,
+	}
+	for _, status := range statuses {
+		client, requests, done := newRetryTestClient(t, status, 1, nil)
+		if err := client.SetRetryConfig(&RetryConfig{MaxRetries: 2}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Send(context.Background(), &Message{Topic: "topic"}); err == nil {
+			t.Errorf("Send() on %d = nil; want = error", status)
+		}
+		if *requests != 1 {
+			t.Errorf("Send() on %d requests = %d; want = 1", status, *requests)
+		}
+		done()
+	}
+}
+
+func TestSetRetryConfigMaxRetries(t *testing.T) {
+	client, requests, done := newRetryTestClient(t, http.StatusServiceUnavailable, 10, nil)
+	defer done()
+	if err := client.SetRetryConfig(&RetryConfig{MaxRetries: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	br, err := client.SendEach(context.Background(), []*Message{{Topic: "topic"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if br.FailureCount != 1 || !errorutils.IsUnavailable(br.Responses[0].Error) {
+		t.Errorf("SendEach() = %v; want = unavailable error", br.Responses[0].Error)
+	}
+	if *requests != 2 {
+		t.Errorf("SendEach() requests = %d; want = 2", *requests)
+	}
+}
+
+func TestSetRetryConfigStatusCodes(t *testing.T) {
+	client, requests, done := newRetryTestClient(t, http.StatusServiceUnavailable, 1, nil)
+	defer done()
+	if err := client.SetRetryConfig(&RetryConfig{
+		MaxRetries:  2,
+		StatusCodes: []int{http.StatusInternalServerError},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Send(context.Background(), &Message{Topic: "topic"}); err == nil {
+		t.Errorf("Send() = nil; want = error")
+	}
+	if *requests != 1 {
+		t.Errorf("Send() requests = %d; want = 1", *requests)
+	}
+
+	client, requests, done = newRetryTestClient(t, http.StatusInternalServerError, 1, nil)
+	defer done()
+	if err := client.SetRetryConfig(&RetryConfig{
+		MaxRetries:  2,
+		StatusCodes: []int{http.StatusInternalServerError},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Send(context.Background(), &Message{Topic: "topic"}); err != nil {
+		t.Errorf("Send() = %v; want = nil", err)
+	}
+	if *requests != 2 {
+		t.Errorf("Send() requests = %d; want = 2", *requests)
+	}
+}
+
+func TestSetRetryConfigRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"1"}}
+	client, requests, done := newRetryTestClient(t, http.StatusTooManyRequests, 1, header)
+	defer done()
+	if err := client.SetRetryConfig(&RetryConfig{MaxRetries: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := client.Send(context.Background(), &Message{Topic: "topic"}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Send() took %v; want >= 1s", elapsed)
+	}
+	if *requests != 2 {
+		t.Errorf("Send() requests = %d; want = 2", *requests)
+	}
+
+	// Retry-After delays longer than MaxDelay are not waited for.
+	atomic.StoreInt32(requests, 0)
+	if err := client.SetRetryConfig(&RetryConfig{MaxRetries: 1, MaxDelay: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Send(context.Background(), &Message{Topic: "topic"}); err == nil {
+		t.Errorf("Send() = nil; want = error")
+	}
+	if *requests != 1 {
+		t.Errorf("Send() requests = %d; want = 1", *requests)
+	}
+}
+
+func TestSetRetryConfigNil(t *testing.T) {
+	client, requests, done := newRetryTestClient(t, http.StatusServiceUnavailable, 1, nil)
+	defer done()
+	if err := client.SetRetryConfig(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Send(context.Background(), &Message{Topic: "topic"}); err == nil {
+		t.Errorf("Send() = nil; want = error")
+	}
+	if *requests != 1 {
+		t.Errorf("Send() requests = %d; want = 1", *requests)
+	}
+}
+
+func TestSetRetryConfigInvalid(t *testing.T) {
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs := []*RetryConfig{
+		{MaxRetries: -1},
+		{BackoffFactor: -1},
+		{MaxDelay: -time.Second},
+		{Jitter: -0.1},
+		{Jitter: 1.1},
+	}
+	for _, config := range configs {
+		if err := client.SetRetryConfig(config); err == nil {
+			t.Errorf("SetRetryConfig(%+v) = nil; want = error", config)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxConditionTopics is the maximum number of topics a condition can refer to.
+const maxConditionTopics = 5
+
+// Condition is a boolean expression over topics, used to target the devices subscribed to a
+// combination of topics via Message.Condition.
+//
+// Conditions are built from TopicCondition, and combined with And and Or:
+//
+//	cond := messaging.TopicCondition("news").And(
+//		messaging.Or(messaging.TopicCondition("sports"), messaging.TopicCondition("weather")))
+//	expr, err := cond.Build() // "'news' in topics && ('sports' in topics || 'weather' in topics)"
+type Condition struct {
+	topic    string
+	op       string
+	operands []*Condition
+}
+
+// TopicCondition returns a Condition that matches the devices subscribed to the given topic. The
+// topic name may include the "/topics/" prefix.
+func TopicCondition(topic string) *Condition {
+	return &Condition{topic: bareTopic(topic)}
+}
+
+// And returns a Condition that matches the devices matching all the given conditions.
+func And(conditions ...*Condition) *Condition {
+	return &Condition{op: "&&", operands: conditions}
+}
+
+// Or returns a Condition that matches the devices matching any of the given conditions.
+func Or(conditions ...*Condition) *Condition {
+	return &Condition{op: "||", operands: conditions}
+}
+
+// And returns a Condition that matches the devices matching this condition and all the given
+// conditions.
+func (c *Condition) And(conditions ...*Condition) *Condition {
+	return And(append([]*Condition{c}, conditions...)...)
+}
+
+// Or returns a Condition that matches the devices matching this condition or any of the given
+// conditions.
+func (c *Condition) Or(conditions ...*Condition) *Condition {
+	return Or(append([]*Condition{c}, conditions...)...)
+}
+
+// Build validates the condition, and returns it as an expression that can be set as the
+// Condition of a Message.
+//
+// Build returns an error if a topic name is malformed, if an And or Or has no operands, or if the
+// condition refers to more than 5 distinct topics.
+func (c *Condition) Build() (string, error) {
+	topics := make(map[string]bool)
+	expr, err := c.build(topics)
+	if err != nil {
+		return "", err
+	}
+	if len(topics) > maxConditionTopics {
+		return "", fmt.Errorf("condition must not refer to more than %d topics; got %d", maxConditionTopics, len(topics))
+	}
+	return expr, nil
+}
+
+// String returns the expression of the condition, or an empty string if it is invalid.
+func (c *Condition) String() string {
+	expr, _ := c.Build()
+	return expr
+}
+
+func (c *Condition) build(topics map[string]bool) (string, error) {
+	if c == nil {
+		return "", errors.New("condition must not be nil")
+	}
+	if c.op == "" {
+		if !bareTopicNamePattern.MatchString(c.topic) {
+			return "", fmt.Errorf("malformed topic name: %q", c.topic)
+		}
+		topics[c.topic] = true
+		return fmt.Sprintf("'%s' in topics", c.topic), nil
+	}
+
+	if len(c.operands) == 0 {
+		return "", fmt.Errorf("%q condition must have at least one operand", c.op)
+	}
+	parts := make([]string, len(c.operands))
+	for i, operand := range c.operands {
+		expr, err := operand.build(topics)
+		if err != nil {
+			return "", err
+		}
+		// Nested And and Or conditions are parenthesized, unless they consist of a single operand.
+		if operand.op != "" && len(operand.operands) > 1 && operand.op != c.op {
+			expr = "(" + expr + ")"
+		}
+		parts[i] = expr
+	}
+	return strings.Join(parts, " "+c.op+" "), nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"testing"
+)
+
+func TestTopicCondition(t *testing.T) {
+	cases := []struct {
+		name string
+		cond *Condition
+		want string
+	}{
+		{
+			name: "SingleTopic",
+			cond: TopicCondition("/topics/news"),
+			want: "'news' in topics",
+		},
+		{
+			name: "And",
+			cond: TopicCondition("a").And(TopicCondition("b"), TopicCondition("c")),
+			want: "'a' in topics && 'b' in topics && 'c' in topics",
+		},
+		{
+			name: "AndOr",
+			cond: TopicCondition("a").And(Or(TopicCondition("b"), TopicCondition("c"))),
+			want: "'a' in topics && ('b' in topics || 'c' in topics)",
+		},
+		{
+			name: "OrAnd",
+			cond: Or(And(TopicCondition("a"), TopicCondition("b")), TopicCondition("c")),
+			want: "('a' in topics && 'b' in topics) || 'c' in topics",
+		},
+		{
+			name: "NestedSameOperator",
+			cond: Or(TopicCondition("a"), Or(TopicCondition("b"), TopicCondition("c"))),
+			want: "'a' in topics || 'b' in topics || 'c' in topics",
+		},
+		{
+			name: "SingleOperand",
+			cond: TopicCondition("a").And(Or(TopicCondition("b"))),
+			want: "'a' in topics && 'b' in topics",
+		},
+		{
+			name: "RepeatedTopics",
+			cond: Or(
+				And(TopicCondition("a"), TopicCondition("b")),
+				And(TopicCondition("a"), TopicCondition("c")),
+				And(TopicCondition("d"), TopicCondition("e")),
+			),
+			want: "('a' in topics && 'b' in topics) || ('a' in topics && 'c' in topics) || " +
+				"('d' in topics && 'e' in topics)",
+		},
+	}
+	for _, tc := range cases {
+		got, err := tc.cond.Build()
+		if err != nil || got != tc.want {
+			t.Errorf("Build(%s) = (%q, %v); want = (%q, nil)", tc.name, got, err, tc.want)
+		}
+		if s := tc.cond.String(); s != tc.want {
+			t.Errorf("String(%s) = %q; want = %q", tc.name, s, tc.want)
+		}
+
+		msg := &Message{Condition: got}
+		if err := msg.Validate(); err != nil {
+			t.Errorf("Validate(%s) = %v; want = nil", tc.name, err)
+		}
+	}
+}
+
+func TestTopicConditionErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		cond *Condition
+		want string
+	}{
+		{
+			name: "MalformedTopic",
+			cond: TopicCondition("a").And(TopicCondition("b's")),
+			want: `malformed topic name: "b's"`,
+		},
+		{
+			name: "EmptyTopic",
+			cond: TopicCondition(""),
+			want: `malformed topic name: ""`,
+		},
+		{
+			name: "NoOperands",
+			cond: TopicCondition("a").Or(And()),
+			want: `"&&" condition must have at least one operand`,
+		},
+		{
+			name: "NilOperand",
+			cond: Or(TopicCondition("a"), nil),
+			want: "condition must not be nil",
+		},
+		{
+			name: "TooManyTopics",
+			cond: Or(
+				TopicCondition("a"), TopicCondition("b"), TopicCondition("c"),
+				TopicCondition("d"), TopicCondition("e"), TopicCondition("f"),
+			),
+			want: "condition must not refer to more than 5 topics; got 6",
+		},
+	}
+	for _, tc := range cases {
+		got, err := tc.cond.Build()
+		if got != "" || err == nil || err.Error() != tc.want {
+			t.Errorf("Build(%s) = (%q, %v); want = (\"\", %q)", tc.name, got, err, tc.want)
+		}
+		if s := tc.cond.String(); s != "" {
+			t.Errorf("String(%s) = %q; want = \"\"", tc.name, s)
+		}
+	}
+}
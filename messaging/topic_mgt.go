@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"firebase.google.com/go/v4/internal"
 )
@@ -63,12 +62,12 @@ type iidClient struct {
 	httpClient  *internal.HTTPClient
 }
 
-func newIIDClient(hc *http.Client) *iidClient {
+func newIIDClient(hc *http.Client, endpoint string) *iidClient {
 	client := internal.WithDefaultRetryConfig(hc)
 	client.CreateErrFn = handleIIDError
 	client.Opts = []internal.HTTPOption{internal.WithHeader("access_token_auth", "true")}
 	return &iidClient{
-		iidEndpoint: iidEndpoint,
+		iidEndpoint: endpoint,
 		httpClient:  client,
 	}
 }
@@ -131,9 +130,7 @@ func (c *iidClient) makeTopicManagementRequest(ctx context.Context, req *iidRequ
 		return nil, fmt.Errorf("invalid topic name: %q", req.Topic)
 	}
 
-	if !strings.HasPrefix(req.Topic, "/topics/") {
-		req.Topic = "/topics/" + req.Topic
-	}
+	req.Topic = "/topics/" + bareTopic(req.Topic)
 
 	request := &internal.Request{
 		Method: http.MethodPost,
@@ -53,6 +53,32 @@ func TestSubscribe(t *testing.T) {
 	checkTopicMgtResponse(t, resp)
 }
 
+func TestSubscribeWithTopicPrefix(t *testing.T) {
+	var tr *http.Request
+	var b []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr = r
+		b, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{\"results\": [{}, {\"error\": \"error_reason\"}]}"))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.iidEndpoint = ts.URL + "/v1"
+
+	resp, err := client.SubscribeToTopic(ctx, []string{"id1", "id2"}, "/topics/test-topic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkIIDRequest(t, b, tr, iidSubscribe)
+	checkTopicMgtResponse(t, resp)
+}
+
 func TestInvalidSubscribe(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClient(ctx, testMessagingConfig)
@@ -0,0 +1,267 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package messagingtest provides an in-memory fake of the Firebase Cloud Messaging service, for
+// testing code that sends messages without network access.
+//
+// Point an App at the fake server via EmulatorConfig.MessagingHost (or the
+// FIREBASE_MESSAGING_EMULATOR_HOST environment variable), and inspect the messages it received:
+//
+//	srv := messagingtest.NewServer()
+//	defer srv.Close()
+//	app, err := firebase.NewApp(ctx, &firebase.Config{
+//		ProjectID: "test-project",
+//		Emulators: &firebase.EmulatorConfig{MessagingHost: srv.Host()},
+//	})
+//	// Send messages with app.Messaging(ctx)...
+//	for _, m := range srv.Messages() {
+//		// Assert on m.Message.Notification...
+//	}
+//
+// The fake server supports Send, SendEach and their dry run and multicast variants, as well as
+// the topic management APIs. It does not validate messages beyond what the client does.
+package messagingtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"firebase.google.com/go/v4/messaging"
+)
+
+// SentMessage is a message received by the fake server.
+type SentMessage struct {
+	// ProjectID is the ID of the project the message was sent for.
+	ProjectID string
+
+	// Message is the message as received by the server. The Topic field never has the "/topics/"
+	// prefix.
+	Message *messaging.Message
+
+	// DryRun reports whether the message was sent in the dry run (validation only) mode.
+	DryRun bool
+
+	// Name is the message ID returned to the client.
+	Name string
+}
+
+// Server is a fake of the Firebase Cloud Messaging service. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, of the form http://ipaddr:port with no trailing slash.
+	URL string
+
+	srv         *httptest.Server
+	mu          sync.Mutex
+	messages    []*SentMessage
+	tokenErrors map[string]messaging.ErrorCode
+	topics      map[string]map[string]bool
+}
+
+// NewServer starts and returns a new fake server. The caller should call Close when finished, to
+// shut it down.
+func NewServer() *Server {
+	s := &Server{
+		tokenErrors: make(map[string]messaging.ErrorCode),
+		topics:      make(map[string]map[string]bool),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/", s.handleSend)
+	mux.HandleFunc("/iid/v1:batchAdd", s.handleTopicManagement(true))
+	mux.HandleFunc("/iid/v1:batchRemove", s.handleTopicManagement(false))
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Host returns the host of the server in the "host:port" format, as accepted by
+// EmulatorConfig.MessagingHost.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Messages returns the messages received by the server, in the order they were received.
+func (s *Server) Messages() []*SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*SentMessage(nil), s.messages...)
+}
+
+// Reset discards the received messages, topic subscriptions and token errors.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+	s.tokenErrors = make(map[string]messaging.ErrorCode)
+	s.topics = make(map[string]map[string]bool)
+}
+
+// SetTokenError makes the server reject messages sent to the given registration token with the
+// given error code, such as messaging.ErrorCodeUnregistered. Rejected messages are not recorded.
+// Passing messaging.ErrorCodeUnspecified clears the error.
+func (s *Server) SetTokenError(token string, code messaging.ErrorCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code == messaging.ErrorCodeUnspecified {
+		delete(s.tokenErrors, token)
+		return
+	}
+	s.tokenErrors[token] = code
+}
+
+// Subscribers returns the registration tokens subscribed to the given topic, in sorted order.
+// The topic name may include the "/topics/" prefix.
+func (s *Server) Subscribers(topic string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var tokens []string
+	for token := range s.topics[strings.TrimPrefix(topic, "/topics/")] {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+type sendRequest struct {
+	ValidateOnly bool               `json:"validate_only"`
+	Message      *messaging.Message `json:"message"`
+}
+
+// errorStatuses maps the FCM error codes to the HTTP status and canonical status of the
+// responses the backend reports them with.
+var errorStatuses = map[messaging.ErrorCode]struct {
+	code   int
+	status string
+}{
+	messaging.ErrorCodeInvalidArgument:     {http.StatusBadRequest, "INVALID_ARGUMENT"},
+	messaging.ErrorCodeUnregistered:        {http.StatusNotFound, "NOT_FOUND"},
+	messaging.ErrorCodeSenderIDMismatch:    {http.StatusForbidden, "PERMISSION_DENIED"},
+	messaging.ErrorCodeQuotaExceeded:       {http.StatusTooManyRequests, "RESOURCE_EXHAUSTED"},
+	messaging.ErrorCodeUnavailable:         {http.StatusServiceUnavailable, "UNAVAILABLE"},
+	messaging.ErrorCodeInternal:            {http.StatusInternalServerError, "INTERNAL"},
+	messaging.ErrorCodeThirdPartyAuthError: {http.StatusUnauthorized, "UNAUTHENTICATED"},
+	messaging.ErrorCodeAPNSAuthError:       {http.StatusUnauthorized, "UNAUTHENTICATED"},
+}
+
+func (s *Server) handleSend(w http.ResponseWriter, r *http.Request) {
+	// Path format: /v1/projects/{project}/messages:send
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	if r.Method != http.MethodPost || len(segments) != 3 || segments[0] != "projects" ||
+		segments[1] == "" || segments[2] != "messages:send" {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", messaging.ErrorCodeUnspecified,
+			fmt.Sprintf("unsupported request: %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	var req sendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Message == nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", messaging.ErrorCodeInvalidArgument,
+			"request must contain a message")
+		return
+	}
+
+	s.mu.Lock()
+	code, failed := s.tokenErrors[req.Message.Token]
+	var name string
+	if !failed {
+		name = fmt.Sprintf("projects/%s/messages/%d", segments[1], len(s.messages)+1)
+		if req.ValidateOnly {
+			name = fmt.Sprintf("projects/%s/messages/fake_message_id", segments[1])
+		}
+		s.messages = append(s.messages, &SentMessage{
+			ProjectID: segments[1],
+			Message:   req.Message,
+			DryRun:    req.ValidateOnly,
+			Name:      name,
+		})
+	}
+	s.mu.Unlock()
+
+	if failed {
+		status := errorStatuses[code]
+		if status.code == 0 {
+			status.code, status.status = http.StatusInternalServerError, "INTERNAL"
+		}
+		writeError(w, status.code, status.status, code,
+			fmt.Sprintf("message to token %q rejected with %s", req.Message.Token, code))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"name": name})
+}
+
+func (s *Server) handleTopicManagement(subscribe bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Topic  string   `json:"to"`
+			Tokens []string `json:"registration_tokens"`
+		}
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "NotFound"})
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Topic == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "InvalidRequest"})
+			return
+		}
+
+		topic := strings.TrimPrefix(req.Topic, "/topics/")
+		results := make([]map[string]string, len(req.Tokens))
+		s.mu.Lock()
+		for i, token := range req.Tokens {
+			results[i] = map[string]string{}
+			if subscribe {
+				if s.topics[topic] == nil {
+					s.topics[topic] = make(map[string]bool)
+				}
+				s.topics[topic][token] = true
+			} else {
+				delete(s.topics[topic], token)
+			}
+		}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, status string, fcmCode messaging.ErrorCode, msg string) {
+	body := map[string]interface{}{
+		"code":    code,
+		"status":  status,
+		"message": msg,
+	}
+	if fcmCode != messaging.ErrorCodeUnspecified {
+		body["details"] = []map[string]string{
+			{
+				"@type":     "type.googleapis.com/google.firebase.fcm.v1.FcmError",
+				"errorCode": string(fcmCode),
+			},
+		}
+	}
+	writeJSON(w, code, map[string]interface{}{"error": body})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messagingtest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+)
+
+func newTestClient(t *testing.T, srv *Server) *messaging.Client {
+	ctx := context.Background()
+	app, err := firebase.NewApp(ctx, &firebase.Config{
+		ProjectID: "test-project",
+		Emulators: &firebase.EmulatorConfig{MessagingHost: srv.Host()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestSend(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := newTestClient(t, srv)
+
+	ctx := context.Background()
+	msg := &messaging.Message{
+		Token: "token",
+		Notification: &messaging.Notification{
+			Title: "title",
+			Body:  "body",
+		},
+		Data: map[string]string{"k": "v"},
+	}
+	name, err := client.Send(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SendDryRun(ctx, &messaging.Message{Topic: "/topics/news"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := srv.Messages()
+	if len(got) != 2 {
+		t.Fatalf("Messages() = %d messages; want = 2", len(got))
+	}
+	if got[0].Name != name || got[0].ProjectID != "test-project" || got[0].DryRun {
+		t.Errorf("Messages()[0] = %#v; want = (%q, %q, false)", got[0], name, "test-project")
+	}
+	if !reflect.DeepEqual(got[0].Message, msg) {
+		t.Errorf("Messages()[0].Message = %#v; want = %#v", got[0].Message, msg)
+	}
+	if !got[1].DryRun || got[1].Message.Topic != "news" {
+		t.Errorf("Messages()[1] = %#v; want dry run message to %q", got[1], "news")
+	}
+
+	srv.Reset()
+	if got := srv.Messages(); len(got) != 0 {
+		t.Errorf("Messages() after Reset() = %d messages; want = 0", len(got))
+	}
+}
+
+func TestSendEach(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := newTestClient(t, srv)
+	srv.SetTokenError("stale", messaging.ErrorCodeUnregistered)
+
+	br, err := client.SendEachForMulticast(context.Background(), &messaging.MulticastMessage{
+		Tokens: []string{"token1", "stale", "token2"},
+		Data:   map[string]string{"k": "v"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if br.SuccessCount != 2 || br.FailureCount != 1 {
+		t.Errorf("SendEachForMulticast() = (%d, %d); want = (2, 1)", br.SuccessCount, br.FailureCount)
+	}
+	if r := br.Responses[1]; r.Success || !messaging.IsUnregistered(r.Error) || !messaging.IsTokenInvalid(r.Error) {
+		t.Errorf("SendEachForMulticast() Responses[1] = %v; want = unregistered error", r.Error)
+	}
+	if got := srv.Messages(); len(got) != 2 {
+		t.Errorf("Messages() = %d messages; want = 2", len(got))
+	}
+
+	srv.SetTokenError("stale", messaging.ErrorCodeUnspecified)
+	if _, err := client.Send(context.Background(), &messaging.Message{Token: "stale"}); err != nil {
+		t.Errorf("Send() after clearing token error = %v; want = nil", err)
+	}
+}
+
+func TestTopicManagement(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := newTestClient(t, srv)
+
+	ctx := context.Background()
+	resp, err := client.SubscribeToTopic(ctx, []string{"token1", "token2", "token3"}, "news")
+	if err != nil || resp.SuccessCount != 3 {
+		t.Fatalf("SubscribeToTopic() = (%#v, %v); want = 3 successes", resp, err)
+	}
+	if _, err := client.UnsubscribeFromTopic(ctx, []string{"token2"}, "/topics/news"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"token1", "token3"}
+	if got := srv.Subscribers("news"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Subscribers() = %v; want = %v", got, want)
+	}
+	if got := srv.Subscribers("other"); len(got) != 0 {
+		t.Errorf("Subscribers(other) = %v; want = []", got)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// APIKey represents a Google Cloud API key of the project, such as the keys auto-provisioned
+// for Firebase apps.
+//
+// The key string itself is not included.
+type APIKey struct {
+	ResourceName string              `json:"name"`
+	UID          string              `json:"uid"`
+	DisplayName  string              `json:"displayName"`
+	CreateTime   string              `json:"createTime"`
+	UpdateTime   string              `json:"updateTime"`
+	Restrictions *APIKeyRestrictions `json:"restrictions"`
+}
+
+// APIKeyRestrictions describes the restrictions placed on an API key. A nil Restrictions value
+// on an APIKey indicates an unrestricted key.
+type APIKeyRestrictions struct {
+	BrowserKeyRestrictions *BrowserKeyRestrictions `json:"browserKeyRestrictions"`
+	ServerKeyRestrictions  *ServerKeyRestrictions  `json:"serverKeyRestrictions"`
+	AndroidKeyRestrictions *AndroidKeyRestrictions `json:"androidKeyRestrictions"`
+	IOSKeyRestrictions     *IOSKeyRestrictions     `json:"iosKeyRestrictions"`
+	APITargets             []*APITarget            `json:"apiTargets"`
+}
+
+// BrowserKeyRestrictions restricts an API key to requests from the listed HTTP referrers.
+type BrowserKeyRestrictions struct {
+	AllowedReferrers []string `json:"allowedReferrers"`
+}
+
+// ServerKeyRestrictions restricts an API key to requests from the listed IP addresses.
+type ServerKeyRestrictions struct {
+	AllowedIPs []string `json:"allowedIps"`
+}
+
+// AndroidKeyRestrictions restricts an API key to requests from the listed Android apps.
+type AndroidKeyRestrictions struct {
+	AllowedApplications []*AndroidApplication `json:"allowedApplications"`
+}
+
+// AndroidApplication identifies an Android app by its package name and signing certificate.
+type AndroidApplication struct {
+	SHA1Fingerprint string `json:"sha1Fingerprint"`
+	PackageName     string `json:"packageName"`
+}
+
+// IOSKeyRestrictions restricts an API key to requests from the listed Apple apps.
+type IOSKeyRestrictions struct {
+	AllowedBundleIDs []string `json:"allowedBundleIds"`
+}
+
+// APITarget restricts an API key to the specified service, and optionally to a subset of its
+// methods.
+type APITarget struct {
+	Service string   `json:"service"`
+	Methods []string `json:"methods"`
+}
+
+// APIKeys returns all the API keys of the project.
+func (c *Client) APIKeys(ctx context.Context) ([]*APIKey, error) {
+	var keys []*APIKey
+	pageToken := ""
+	for {
+		var parsed struct {
+			Keys          []*APIKey `json:"keys"`
+			NextPageToken string    `json:"nextPageToken"`
+		}
+		opts := []internal.HTTPOption{
+			internal.WithQueryParam("pageSize", fmt.Sprintf("%d", maxPageSize)),
+		}
+		if pageToken != "" {
+			opts = append(opts, internal.WithQueryParam("pageToken", pageToken))
+		}
+
+		url := fmt.Sprintf("%s/projects/%s/locations/global/keys", c.apiKeysEndpoint, c.projectID)
+		if err := c.get(ctx, url, &parsed, opts...); err != nil {
+			return nil, err
+		}
+		keys = append(keys, parsed.Keys...)
+
+		if parsed.NextPageToken == "" {
+			return keys, nil
+		}
+		pageToken = parsed.NextPageToken
+	}
+}
+
+// APIKey returns the API key with the given ID, such as the APIKeyID of a Firebase app.
+func (c *Client) APIKey(ctx context.Context, keyID string) (*APIKey, error) {
+	if keyID == "" {
+		return nil, errors.New("key id must not be empty")
+	}
+
+	var result APIKey
+	url := fmt.Sprintf("%s/projects/%s/locations/global/keys/%s", c.apiKeysEndpoint, c.projectID, keyID)
+	if err := c.get(ctx, url, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// RemoveAppOptions specifies how an app is removed from the project.
+type RemoveAppOptions struct {
+	// Immediate specifies whether the app is deleted permanently. By default, a removed app is
+	// moved to the DELETED state, and can be restored for 30 days before it is deleted permanently.
+	Immediate bool
+
+	// AllowMissing specifies whether removing an app that does not exist succeeds without any
+	// changes. By default, a NotFound error is returned.
+	AllowMissing bool
+}
+
+// RemoveAndroidApp removes the specified Android app from the project.
+//
+// The options may be nil, in which case the app can be restored with UndeleteAndroidApp for 30
+// days. RemoveAndroidApp returns once the removal has completed.
+func (c *Client) RemoveAndroidApp(ctx context.Context, appID string, opts *RemoveAppOptions) error {
+	return c.removeApp(ctx, Android, appID, opts)
+}
+
+// RemoveIOSApp removes the specified Apple app from the project.
+//
+// The options may be nil, in which case the app can be restored with UndeleteIOSApp for 30
+// days. RemoveIOSApp returns once the removal has completed.
+func (c *Client) RemoveIOSApp(ctx context.Context, appID string, opts *RemoveAppOptions) error {
+	return c.removeApp(ctx, IOS, appID, opts)
+}
+
+// RemoveWebApp removes the specified Web app from the project.
+//
+// The options may be nil, in which case the app can be restored with UndeleteWebApp for 30
+// days. RemoveWebApp returns once the removal has completed.
+func (c *Client) RemoveWebApp(ctx context.Context, appID string, opts *RemoveAppOptions) error {
+	return c.removeApp(ctx, Web, appID, opts)
+}
+
+// UndeleteAndroidApp restores an Android app that was removed within the last 30 days, and was
+// not deleted immediately.
+func (c *Client) UndeleteAndroidApp(ctx context.Context, appID string) error {
+	return c.undeleteApp(ctx, Android, appID)
+}
+
+// UndeleteIOSApp restores an Apple app that was removed within the last 30 days, and was not
+// deleted immediately.
+func (c *Client) UndeleteIOSApp(ctx context.Context, appID string) error {
+	return c.undeleteApp(ctx, IOS, appID)
+}
+
+// UndeleteWebApp restores a Web app that was removed within the last 30 days, and was not
+// deleted immediately.
+func (c *Client) UndeleteWebApp(ctx context.Context, appID string) error {
+	return c.undeleteApp(ctx, Web, appID)
+}
+
+func (c *Client) removeApp(ctx context.Context, platform Platform, appID string, opts *RemoveAppOptions) error {
+	if appID == "" {
+		return errors.New("app id must not be empty")
+	}
+	if opts == nil {
+		opts = &RemoveAppOptions{}
+	}
+
+	body := map[string]interface{}{
+		"immediate":    opts.Immediate,
+		"allowMissing": opts.AllowMissing,
+	}
+	return c.runOperation(ctx, c.appURL(platform, appID, ":remove"), body)
+}
+
+func (c *Client) undeleteApp(ctx context.Context, platform Platform, appID string) error {
+	if appID == "" {
+		return errors.New("app id must not be empty")
+	}
+	return c.runOperation(ctx, c.appURL(platform, appID, ":undelete"), map[string]interface{}{})
+}
+
+type operation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// rpcCodes maps the numeric codes of google.rpc.Status to the platform error codes.
+var rpcCodes = map[int]internal.ErrorCode{
+	1:  internal.Cancelled,
+	3:  internal.InvalidArgument,
+	4:  internal.DeadlineExceeded,
+	5:  internal.NotFound,
+	6:  internal.AlreadyExists,
+	7:  internal.PermissionDenied,
+	8:  internal.ResourceExhausted,
+	9:  internal.FailedPrecondition,
+	10: internal.Aborted,
+	11: internal.OutOfRange,
+	13: internal.Internal,
+	14: internal.Unavailable,
+	15: internal.DataLoss,
+	16: internal.Unauthenticated,
+}
+
+// runOperation starts a long-running operation with a POST request to the given URL, and polls
+// the operation until it is done.
+func (c *Client) runOperation(ctx context.Context, url string, body interface{}) error {
+	req := &internal.Request{
+		Method: http.MethodPost,
+		URL:    url,
+		Body:   internal.NewJSONEntity(body),
+	}
+	var op operation
+	if _, err := c.httpClient.DoAndUnmarshal(ctx, req, &op); err != nil {
+		return err
+	}
+
+	for !op.Done {
+		if op.Name == "" {
+			return errors.New("operation has no name")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+		if err := c.get(ctx, fmt.Sprintf("%s/%s", c.firebaseEndpoint, op.Name), &op); err != nil {
+			return err
+		}
+	}
+
+	if op.Error != nil {
+		code, ok := rpcCodes[op.Error.Code]
+		if !ok {
+			code = internal.Unknown
+		}
+		return &internal.FirebaseError{
+			ErrorCode: code,
+			String:    fmt.Sprintf("operation %q failed: %s", op.Name, op.Error.Message),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+)
+
+type operationRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+// operationServer responds to the initial request with a pending operation, and to the
+// subsequent polls with the given responses.
+func operationServer(t *testing.T, polls ...string) (*httptest.Server, *[]operationRequest) {
+	var requests []operationRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := operationRequest{method: r.Method, path: r.URL.Path}
+		if r.Method == http.MethodPost {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(b, &req.body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		requests = append(requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(requests) == 1 {
+			w.Write([]byte(`{"name": "operations/op1"}`))
+			return
+		}
+		w.Write([]byte(polls[len(requests)-2]))
+	}))
+	return ts, &requests
+}
+
+func TestRemoveApps(t *testing.T) {
+	cases := []struct {
+		name   string
+		remove func(*Client) error
+		path   string
+		body   map[string]interface{}
+	}{
+		{
+			name: "RemoveAndroidApp",
+			remove: func(c *Client) error {
+				return c.RemoveAndroidApp(context.Background(), "app1", nil)
+			},
+			path: "/projects/-/androidApps/app1:remove",
+			body: map[string]interface{}{"immediate": false, "allowMissing": false},
+		},
+		{
+			name: "RemoveIOSApp",
+			remove: func(c *Client) error {
+				return c.RemoveIOSApp(context.Background(), "app1", &RemoveAppOptions{Immediate: true})
+			},
+			path: "/projects/-/iosApps/app1:remove",
+			body: map[string]interface{}{"immediate": true, "allowMissing": false},
+		},
+		{
+			name: "RemoveWebApp",
+			remove: func(c *Client) error {
+				return c.RemoveWebApp(context.Background(), "app1", &RemoveAppOptions{AllowMissing: true})
+			},
+			path: "/projects/-/webApps/app1:remove",
+			body: map[string]interface{}{"immediate": false, "allowMissing": true},
+		},
+		{
+			name: "UndeleteAndroidApp",
+			remove: func(c *Client) error {
+				return c.UndeleteAndroidApp(context.Background(), "app1")
+			},
+			path: "/projects/-/androidApps/app1:undelete",
+			body: map[string]interface{}{},
+		},
+		{
+			name: "UndeleteIOSApp",
+			remove: func(c *Client) error {
+				return c.UndeleteIOSApp(context.Background(), "app1")
+			},
+			path: "/projects/-/iosApps/app1:undelete",
+			body: map[string]interface{}{},
+		},
+		{
+			name: "UndeleteWebApp",
+			remove: func(c *Client) error {
+				return c.UndeleteWebApp(context.Background(), "app1")
+			},
+			path: "/projects/-/webApps/app1:undelete",
+			body: map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		ts, requests := operationServer(t, `{"name": "operations/op1"}`, `{"name": "operations/op1", "done": true}`)
+		client := newTestClient(t, ts)
+		client.pollInterval = time.Millisecond
+
+		if err := tc.remove(client); err != nil {
+			t.Errorf("%s() = %v", tc.name, err)
+		}
+
+		want := []operationRequest{
+			{method: http.MethodPost, path: tc.path, body: tc.body},
+			{method: http.MethodGet, path: "/operations/op1"},
+			{method: http.MethodGet, path: "/operations/op1"},
+		}
+		if !reflect.DeepEqual(*requests, want) {
+			t.Errorf("%s() requests = %v; want = %v", tc.name, *requests, want)
+		}
+		ts.Close()
+	}
+}
+
+func TestRemoveAppDoneImmediately(t *testing.T) {
+	var count int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "operations/op1", "done": true}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	if err := client.RemoveAndroidApp(context.Background(), "app1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("Requests = %d; want = 1", count)
+	}
+}
+
+func TestRemoveAppOperationError(t *testing.T) {
+	ts, _ := operationServer(t, `{
+		"name": "operations/op1",
+		"done": true,
+		"error": {"code": 9, "message": "app is not in the DELETED state"}
+	}`)
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	client.pollInterval = time.Millisecond
+	err := client.UndeleteWebApp(context.Background(), "app1")
+	if !errorutils.IsFailedPrecondition(err) {
+		t.Errorf("UndeleteWebApp() = %v; want = FailedPrecondition", err)
+	}
+	want := `operation "operations/op1" failed: app is not in the DELETED state`
+	if err == nil || err.Error() != want {
+		t.Errorf("UndeleteWebApp() = %v; want = %q", err, want)
+	}
+}
+
+func TestRemoveAppError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"status": "NOT_FOUND", "message": "app not found"}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	if err := client.RemoveIOSApp(context.Background(), "app1", nil); !errorutils.IsNotFound(err) {
+		t.Errorf("RemoveIOSApp() = %v; want = NotFound", err)
+	}
+}
+
+func TestRemoveAppContextCancelled(t *testing.T) {
+	ts, _ := operationServer(t)
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	client.pollInterval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if err := client.RemoveWebApp(ctx, "app1", nil); err != context.Canceled {
+		t.Errorf("RemoveWebApp() = %v; want = %v", err, context.Canceled)
+	}
+}
+
+func TestRemoveAppEmptyAppID(t *testing.T) {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := client.RemoveAndroidApp(ctx, "", nil); err == nil {
+		t.Errorf("RemoveAndroidApp(empty) = nil; want error")
+	}
+	if err := client.UndeleteIOSApp(ctx, ""); err == nil {
+		t.Errorf("UndeleteIOSApp(empty) = nil; want error")
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projectmanagement contains functions for managing the apps and inspecting the API keys
+// associated with a Firebase project.
+package projectmanagement
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	firebaseEndpoint = "https://firebase.googleapis.com/v1beta1"
+	apiKeysEndpoint  = "https://apikeys.googleapis.com/v2"
+	clientHeader     = "X-Client-Version"
+	maxPageSize      = 100
+
+	defaultPollInterval = time.Second
+)
+
+// Platform identifies the platform of a Firebase app.
+type Platform string
+
+const (
+	// Android represents a Firebase Android app.
+	Android Platform = "ANDROID"
+
+	// IOS represents a Firebase Apple app.
+	IOS Platform = "IOS"
+
+	// Web represents a Firebase Web app.
+	Web Platform = "WEB"
+)
+
+// AppMetadata contains the metadata associated with a Firebase app.
+type AppMetadata struct {
+	AppID        string
+	DisplayName  string
+	ProjectID    string
+	ResourceName string
+	APIKeyID     string
+	State        string
+	Platform     Platform
+
+	// PackageName is only set for Android apps.
+	PackageName string
+
+	// BundleID is only set for Apple apps.
+	BundleID string
+}
+
+// WebAppConfig is the configuration used by a Firebase Web app to connect to Firebase services.
+type WebAppConfig struct {
+	APIKey            string `json:"apiKey"`
+	AppID             string `json:"appId"`
+	AuthDomain        string `json:"authDomain"`
+	DatabaseURL       string `json:"databaseURL"`
+	LocationID        string `json:"locationId"`
+	MeasurementID     string `json:"measurementId"`
+	MessagingSenderID string `json:"messagingSenderId"`
+	ProjectID         string `json:"projectId"`
+	StorageBucket     string `json:"storageBucket"`
+}
+
+// Client is the interface for the Firebase Project Management service.
+type Client struct {
+	firebaseEndpoint string
+	apiKeysEndpoint  string
+	projectID        string
+	httpClient       *internal.HTTPClient
+	pollInterval     time.Duration
+}
+
+// NewClient creates a new instance of the Firebase Project Management Client.
+//
+// This function can only be invoked from within the SDK. Client applications should access the
+// Project Management service through firebase.App.
+func NewClient(ctx context.Context, conf *internal.ProjectManagementConfig) (*Client, error) {
+	if conf.ProjectID == "" {
+		return nil, errors.New("project id is required to access the project management service")
+	}
+
+	hc, _, err := internal.NewHTTPClient(ctx, conf.Opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	hc.Opts = []internal.HTTPOption{
+		internal.WithHeader(clientHeader, fmt.Sprintf("Go/Admin/%s", conf.Version)),
+	}
+	hc.MetricsFn = conf.MetricsFn
+	return &Client{
+		firebaseEndpoint: firebaseEndpoint,
+		apiKeysEndpoint:  apiKeysEndpoint,
+		projectID:        conf.ProjectID,
+		httpClient:       hc,
+		pollInterval:     defaultPollInterval,
+	}, nil
+}
+
+// AndroidApps returns the metadata of all the Android apps in the project.
+func (c *Client) AndroidApps(ctx context.Context) ([]*AppMetadata, error) {
+	return c.listApps(ctx, Android)
+}
+
+// IOSApps returns the metadata of all the Apple apps in the project.
+func (c *Client) IOSApps(ctx context.Context) ([]*AppMetadata, error) {
+	return c.listApps(ctx, IOS)
+}
+
+// WebApps returns the metadata of all the Web apps in the project.
+func (c *Client) WebApps(ctx context.Context) ([]*AppMetadata, error) {
+	return c.listApps(ctx, Web)
+}
+
+// AndroidAppConfig returns the contents of the google-services.json configuration file of the
+// specified Android app.
+func (c *Client) AndroidAppConfig(ctx context.Context, appID string) (string, error) {
+	return c.configFile(ctx, Android, appID)
+}
+
+// IOSAppConfig returns the contents of the GoogleService-Info.plist configuration file of the
+// specified Apple app.
+func (c *Client) IOSAppConfig(ctx context.Context, appID string) (string, error) {
+	return c.configFile(ctx, IOS, appID)
+}
+
+// WebAppConfig returns the configuration of the specified Web app.
+func (c *Client) WebAppConfig(ctx context.Context, appID string) (*WebAppConfig, error) {
+	if appID == "" {
+		return nil, errors.New("app id must not be empty")
+	}
+
+	var result WebAppConfig
+	if err := c.get(ctx, c.appURL(Web, appID, "/config"), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+type appResponse struct {
+	AppID       string `json:"appId"`
+	DisplayName string `json:"displayName"`
+	ProjectID   string `json:"projectId"`
+	Name        string `json:"name"`
+	APIKeyID    string `json:"apiKeyId"`
+	State       string `json:"state"`
+	PackageName string `json:"packageName"`
+	BundleID    string `json:"bundleId"`
+}
+
+func (r *appResponse) metadata(platform Platform) *AppMetadata {
+	return &AppMetadata{
+		AppID:        r.AppID,
+		DisplayName:  r.DisplayName,
+		ProjectID:    r.ProjectID,
+		ResourceName: r.Name,
+		APIKeyID:     r.APIKeyID,
+		State:        r.State,
+		Platform:     platform,
+		PackageName:  r.PackageName,
+		BundleID:     r.BundleID,
+	}
+}
+
+func (c *Client) listApps(ctx context.Context, platform Platform) ([]*AppMetadata, error) {
+	var apps []*AppMetadata
+	pageToken := ""
+	for {
+		var parsed struct {
+			Apps          []*appResponse `json:"apps"`
+			NextPageToken string         `json:"nextPageToken"`
+		}
+		opts := []internal.HTTPOption{
+			internal.WithQueryParam("pageSize", fmt.Sprintf("%d", maxPageSize)),
+		}
+		if pageToken != "" {
+			opts = append(opts, internal.WithQueryParam("pageToken", pageToken))
+		}
+
+		url := fmt.Sprintf("%s/projects/%s/%s", c.firebaseEndpoint, c.projectID, collection(platform))
+		if err := c.get(ctx, url, &parsed, opts...); err != nil {
+			return nil, err
+		}
+		for _, app := range parsed.Apps {
+			apps = append(apps, app.metadata(platform))
+		}
+
+		if parsed.NextPageToken == "" {
+			return apps, nil
+		}
+		pageToken = parsed.NextPageToken
+	}
+}
+
+func (c *Client) configFile(ctx context.Context, platform Platform, appID string) (string, error) {
+	if appID == "" {
+		return "", errors.New("app id must not be empty")
+	}
+
+	var parsed struct {
+		ConfigFileContents string `json:"configFileContents"`
+	}
+	if err := c.get(ctx, c.appURL(platform, appID, "/config"), &parsed); err != nil {
+		return "", err
+	}
+
+	b, err := base64.StdEncoding.DecodeString(parsed.ConfigFileContents)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the config file: %v", err)
+	}
+	return string(b), nil
+}
+
+func (c *Client) appURL(platform Platform, appID, suffix string) string {
+	return fmt.Sprintf("%s/projects/-/%s/%s%s", c.firebaseEndpoint, collection(platform), appID, suffix)
+}
+
+func (c *Client) get(ctx context.Context, url string, v interface{}, opts ...internal.HTTPOption) error {
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    url,
+		Opts:   opts,
+	}
+	_, err := c.httpClient.DoAndUnmarshal(ctx, req, v)
+	return err
+}
+
+func collection(platform Platform) string {
+	switch platform {
+	case Android:
+		return "androidApps"
+	case IOS:
+		return "iosApps"
+	default:
+		return "webApps"
+	}
+}
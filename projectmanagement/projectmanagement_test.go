@@ -0,0 +1,335 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+)
+
+var testConfig = &internal.ProjectManagementConfig{
+	ProjectID: "test-project",
+	Opts: []option.ClientOption{
+		option.WithTokenSource(&internal.MockTokenSource{AccessToken: "test-token"}),
+	},
+	Version: "test-version",
+}
+
+func TestNoProjectID(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.ProjectManagementConfig{})
+	if client != nil || err == nil {
+		t.Errorf("NewClient() = (%v, %v); want = (nil, error)", client, err)
+	}
+}
+
+func TestAndroidApps(t *testing.T) {
+	var paths []string
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		tokens = append(tokens, r.URL.Query().Get("pageToken"))
+		if got := r.Header.Get("X-Client-Version"); got != "Go/Admin/test-version" {
+			t.Errorf("X-Client-Version = %q; want = %q", got, "Go/Admin/test-version")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			w.Write([]byte(`{
+				"apps": [{
+					"appId": "app1",
+					"displayName": "App 1",
+					"projectId": "test-project",
+					"name": "projects/test-project/androidApps/app1",
+					"apiKeyId": "key1",
+					"state": "ACTIVE",
+					"packageName": "com.example.app1"
+				}],
+				"nextPageToken": "token"
+			}`))
+			return
+		}
+		w.Write([]byte(`{"apps": [{"appId": "app2", "packageName": "com.example.app2"}]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	apps, err := client.AndroidApps(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*AppMetadata{
+		{
+			AppID:        "app1",
+			DisplayName:  "App 1",
+			ProjectID:    "test-project",
+			ResourceName: "projects/test-project/androidApps/app1",
+			APIKeyID:     "key1",
+			State:        "ACTIVE",
+			Platform:     Android,
+			PackageName:  "com.example.app1",
+		},
+		{
+			AppID:       "app2",
+			Platform:    Android,
+			PackageName: "com.example.app2",
+		},
+	}
+	if !reflect.DeepEqual(apps, want) {
+		t.Errorf("AndroidApps() = %v; want = %v", apps, want)
+	}
+	wantPaths := []string{"/projects/test-project/androidApps", "/projects/test-project/androidApps"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("Paths = %v; want = %v", paths, wantPaths)
+	}
+	wantTokens := []string{"", "token"}
+	if !reflect.DeepEqual(tokens, wantTokens) {
+		t.Errorf("PageTokens = %v; want = %v", tokens, wantTokens)
+	}
+}
+
+func TestIOSAndWebApps(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apps": [{"appId": "app1", "bundleId": "com.example.app1"}]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	ctx := context.Background()
+	apps, err := client.IOSApps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].Platform != IOS || apps[0].BundleID != "com.example.app1" {
+		t.Errorf("IOSApps() = %v; want = [app1]", apps)
+	}
+	if path != "/projects/test-project/iosApps" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/test-project/iosApps")
+	}
+
+	apps, err = client.WebApps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].Platform != Web {
+		t.Errorf("WebApps() = %v; want = [app1]", apps)
+	}
+	if path != "/projects/test-project/webApps" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/test-project/webApps")
+	}
+}
+
+func TestAppConfigFiles(t *testing.T) {
+	const contents = `{"project_info": {}}`
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"configFilename": "config", "configFileContents": %q}`,
+			base64.StdEncoding.EncodeToString([]byte(contents)))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	ctx := context.Background()
+	config, err := client.AndroidAppConfig(ctx, "app1")
+	if err != nil || config != contents {
+		t.Errorf("AndroidAppConfig() = (%q, %v); want = (%q, nil)", config, err, contents)
+	}
+	if path != "/projects/-/androidApps/app1/config" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/-/androidApps/app1/config")
+	}
+
+	config, err = client.IOSAppConfig(ctx, "app2")
+	if err != nil || config != contents {
+		t.Errorf("IOSAppConfig() = (%q, %v); want = (%q, nil)", config, err, contents)
+	}
+	if path != "/projects/-/iosApps/app2/config" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/-/iosApps/app2/config")
+	}
+}
+
+func TestWebAppConfig(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiKey": "api-key", "appId": "app1", "projectId": "test-project"}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	config, err := client.WebAppConfig(context.Background(), "app1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &WebAppConfig{
+		APIKey:    "api-key",
+		AppID:     "app1",
+		ProjectID: "test-project",
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("WebAppConfig() = %v; want = %v", config, want)
+	}
+	if path != "/projects/-/webApps/app1/config" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/-/webApps/app1/config")
+	}
+}
+
+func TestEmptyAppID(t *testing.T) {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.AndroidAppConfig(ctx, ""); err == nil {
+		t.Errorf("AndroidAppConfig(empty) = nil; want error")
+	}
+	if _, err := client.IOSAppConfig(ctx, ""); err == nil {
+		t.Errorf("IOSAppConfig(empty) = nil; want error")
+	}
+	if _, err := client.WebAppConfig(ctx, ""); err == nil {
+		t.Errorf("WebAppConfig(empty) = nil; want error")
+	}
+	if _, err := client.APIKey(ctx, ""); err == nil {
+		t.Errorf("APIKey(empty) = nil; want error")
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"keys": [{
+				"name": "projects/123/locations/global/keys/key1",
+				"uid": "key1",
+				"displayName": "Android key (auto created by Firebase)",
+				"restrictions": {
+					"androidKeyRestrictions": {
+						"allowedApplications": [{"sha1Fingerprint": "aa:bb", "packageName": "com.example"}]
+					},
+					"apiTargets": [{"service": "firebase.googleapis.com"}]
+				}
+			}, {
+				"name": "projects/123/locations/global/keys/key2",
+				"uid": "key2"
+			}]
+		}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	keys, err := client.APIKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*APIKey{
+		{
+			ResourceName: "projects/123/locations/global/keys/key1",
+			UID:          "key1",
+			DisplayName:  "Android key (auto created by Firebase)",
+			Restrictions: &APIKeyRestrictions{
+				AndroidKeyRestrictions: &AndroidKeyRestrictions{
+					AllowedApplications: []*AndroidApplication{
+						{SHA1Fingerprint: "aa:bb", PackageName: "com.example"},
+					},
+				},
+				APITargets: []*APITarget{
+					{Service: "firebase.googleapis.com"},
+				},
+			},
+		},
+		{
+			ResourceName: "projects/123/locations/global/keys/key2",
+			UID:          "key2",
+		},
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("APIKeys() = %v; want = %v", keys, want)
+	}
+	if path != "/projects/test-project/locations/global/keys" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/test-project/locations/global/keys")
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"uid": "key1",
+			"restrictions": {"browserKeyRestrictions": {"allowedReferrers": ["example.com/*"]}}
+		}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	key, err := client.APIKey(context.Background(), "key1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"example.com/*"}
+	if key.UID != "key1" || key.Restrictions == nil || key.Restrictions.BrowserKeyRestrictions == nil ||
+		!reflect.DeepEqual(key.Restrictions.BrowserKeyRestrictions.AllowedReferrers, want) {
+		t.Errorf("APIKey() = %v; want = {UID: key1, AllowedReferrers: %v}", key, want)
+	}
+	if path != "/projects/test-project/locations/global/keys/key1" {
+		t.Errorf("Path = %q; want = %q", path, "/projects/test-project/locations/global/keys/key1")
+	}
+}
+
+func TestAPIKeyError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"status": "NOT_FOUND", "message": "key not found"}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	key, err := client.APIKey(context.Background(), "key1")
+	if key != nil || !errorutils.IsNotFound(err) {
+		t.Errorf("APIKey() = (%v, %v); want = (nil, NotFound)", key, err)
+	}
+}
+
+func newTestClient(t *testing.T, ts *httptest.Server) *Client {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.firebaseEndpoint = ts.URL
+	client.apiKeysEndpoint = ts.URL
+	client.httpClient.RetryConfig = nil
+	return client
+}
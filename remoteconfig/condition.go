@@ -0,0 +1,157 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AppID returns an expression that matches the app with the given ID.
+func AppID(id string) string {
+	return fmt.Sprintf("app.id == %s", quote(id))
+}
+
+// DeviceOS returns an expression that matches devices running the given operating system, such
+// as "ios" or "android".
+func DeviceOS(os string) string {
+	return fmt.Sprintf("device.os == %s", quote(os))
+}
+
+// DeviceCountry returns an expression that matches devices in any of the given countries,
+// identified by their ISO 3166-1 alpha-2 codes (e.g. "US").
+func DeviceCountry(codes ...string) string {
+	return fmt.Sprintf("device.country in %s", quoteList(codes))
+}
+
+// DeviceLanguage returns an expression that matches devices using any of the given languages,
+// identified by their language tags (e.g. "en-US").
+func DeviceLanguage(tags ...string) string {
+	return fmt.Sprintf("device.language in %s", quoteList(tags))
+}
+
+// PercentBetween returns an expression that matches the app instances whose random percentile
+// lies between lower and upper, which range from 0 to 100.
+//
+// The percentile of an app instance is derived from the seed, so that a given instance falls in
+// the same percentile for all the conditions that use the same seed. An empty seed uses the
+// default seed of the project.
+func PercentBetween(seed string, lower, upper float64) string {
+	return fmt.Sprintf("%s between %s and %s", percent(seed), formatNumber(lower), formatNumber(upper))
+}
+
+// PercentAtMost returns an expression that matches the app instances whose random percentile is
+// lower than or equal to the given value, which ranges from 0 to 100. See PercentBetween for a
+// description of the seed.
+func PercentAtMost(seed string, value float64) string {
+	return fmt.Sprintf("%s <= %s", percent(seed), formatNumber(value))
+}
+
+// ComparisonOperator is an operator used to compare a numeric user property with a value.
+type ComparisonOperator string
+
+const (
+	// OperatorLessThan matches user properties lower than the value.
+	OperatorLessThan ComparisonOperator = "<"
+
+	// OperatorLessThanOrEqual matches user properties lower than or equal to the value.
+	OperatorLessThanOrEqual ComparisonOperator = "<="
+
+	// OperatorEqual matches user properties equal to the value.
+	OperatorEqual ComparisonOperator = "=="
+
+	// OperatorNotEqual matches user properties different from the value.
+	OperatorNotEqual ComparisonOperator = "!="
+
+	// OperatorGreaterThanOrEqual matches user properties greater than or equal to the value.
+	OperatorGreaterThanOrEqual ComparisonOperator = ">="
+
+	// OperatorGreaterThan matches user properties greater than the value.
+	OperatorGreaterThan ComparisonOperator = ">"
+)
+
+// UserPropertyCompare returns an expression that compares a numeric user property with the given
+// value, using one of the ComparisonOperator constants.
+func UserPropertyCompare(name string, op ComparisonOperator, value float64) string {
+	return fmt.Sprintf("%s %s %s", userProperty(name), op, formatNumber(value))
+}
+
+// UserPropertyContains returns an expression that matches the app instances whose user property
+// contains any of the given values.
+func UserPropertyContains(name string, values ...string) string {
+	return fmt.Sprintf("%s.contains(%s)", userProperty(name), quoteList(values))
+}
+
+// UserPropertyExactlyMatches returns an expression that matches the app instances whose user
+// property is equal to any of the given values.
+func UserPropertyExactlyMatches(name string, values ...string) string {
+	return fmt.Sprintf("%s.exactlyMatches(%s)", userProperty(name), quoteList(values))
+}
+
+// And returns an expression that matches when all of the given expressions match.
+//
+// For example, the following condition matches 10% of the app instances in the US:
+//
+//	cond := &remoteconfig.Condition{
+//		Name: "beta_us",
+//		Expression: remoteconfig.And(
+//			remoteconfig.DeviceCountry("US"),
+//			remoteconfig.PercentBetween("beta", 0, 10),
+//		),
+//	}
+func And(exprs ...string) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		if strings.Contains(e, "||") {
+			e = "(" + e + ")"
+		}
+		parts[i] = e
+	}
+	return strings.Join(parts, " && ")
+}
+
+// Or returns an expression that matches when any of the given expressions matches.
+func Or(exprs ...string) string {
+	return strings.Join(exprs, " || ")
+}
+
+func percent(seed string) string {
+	if seed == "" {
+		return "percent"
+	}
+	return fmt.Sprintf("percent(%s)", quote(seed))
+}
+
+func userProperty(name string) string {
+	return fmt.Sprintf("app.userProperty[%s]", quote(name))
+}
+
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import "testing"
+
+func TestConditionExpressions(t *testing.T) {
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{AppID("1:123:ios:abc"), "app.id == '1:123:ios:abc'"},
+		{DeviceOS("ios"), "device.os == 'ios'"},
+		{DeviceCountry("US", "CA"), "device.country in ['US', 'CA']"},
+		{DeviceLanguage("en-US"), "device.language in ['en-US']"},
+		{PercentBetween("", 0, 10), "percent between 0 and 10"},
+		{PercentBetween("beta", 12.5, 50), "percent('beta') between 12.5 and 50"},
+		{PercentAtMost("beta", 5), "percent('beta') <= 5"},
+		{UserPropertyCompare("level", OperatorGreaterThanOrEqual, 3), "app.userProperty['level'] >= 3"},
+		{UserPropertyCompare("score", OperatorNotEqual, 0.5), "app.userProperty['score'] != 0.5"},
+		{UserPropertyContains("plan", "pro", "team"), "app.userProperty['plan'].contains(['pro', 'team'])"},
+		{UserPropertyExactlyMatches("plan", "free"), "app.userProperty['plan'].exactlyMatches(['free'])"},
+		{DeviceCountry("it's"), `device.country in ['it\'s']`},
+		{
+			And(DeviceOS("ios"), PercentAtMost("", 10)),
+			"device.os == 'ios' && percent <= 10",
+		},
+		{
+			And(Or(DeviceCountry("US"), DeviceCountry("CA")), DeviceOS("android")),
+			"(device.country in ['US'] || device.country in ['CA']) && device.os == 'android'",
+		},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("Expression = %q; want = %q", tc.got, tc.want)
+		}
+	}
+}
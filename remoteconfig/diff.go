@@ -0,0 +1,229 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeType describes how an element of a template changed between two templates.
+type ChangeType string
+
+const (
+	// Added indicates that the element only exists in the new template.
+	Added ChangeType = "ADDED"
+
+	// Removed indicates that the element only exists in the old template.
+	Removed ChangeType = "REMOVED"
+
+	// Modified indicates that the element exists in both templates, with different contents.
+	Modified ChangeType = "MODIFIED"
+)
+
+// TemplateDiff is the set of changes between two Remote Config templates.
+type TemplateDiff struct {
+	// Parameters are the changed parameters, sorted by key.
+	Parameters []*ParameterChange
+
+	// Conditions are the changed conditions, sorted by name.
+	Conditions []*ConditionChange
+
+	// ConditionOrderChanged indicates that the conditions present in both templates are in a
+	// different order, which changes their priority.
+	ConditionOrderChanged bool
+}
+
+// ParameterChange is a change to a parameter.
+type ParameterChange struct {
+	Key  string
+	Type ChangeType
+
+	// Before and After are the old and new values of the parameter. Before is nil for added
+	// parameters, and After is nil for removed ones.
+	Before, After *Parameter
+
+	// BeforeGroup and AfterGroup are the names of the groups that contain the parameter in the
+	// old and new templates, or empty if the parameter does not belong to a group.
+	BeforeGroup, AfterGroup string
+}
+
+// ConditionChange is a change to a condition.
+type ConditionChange struct {
+	Name string
+	Type ChangeType
+
+	// Before and After are the old and new values of the condition. Before is nil for added
+	// conditions, and After is nil for removed ones.
+	Before, After *Condition
+}
+
+// Diff returns the changes required to turn template a into template b.
+//
+// Parameters are matched by key, regardless of the groups they belong to, and moving a parameter
+// to another group is reported as a modification. The version metadata and ETags of the templates
+// are ignored. A nil template is treated as an empty one, and nil conditions are ignored.
+// Parameters and conditions are compared by their JSON representation, so that nil and empty
+// collections are equivalent. The templates are expected to be valid, as reported by
+// Template.Validate.
+func Diff(a, b *Template) *TemplateDiff {
+	if a == nil {
+		a = &Template{}
+	}
+	if b == nil {
+		b = &Template{}
+	}
+
+	diff := &TemplateDiff{}
+	before, after := indexParameters(a), indexParameters(b)
+	for key, pa := range before {
+		pb, ok := after[key]
+		if !ok {
+			diff.Parameters = append(diff.Parameters, &ParameterChange{
+				Key: key, Type: Removed, Before: pa.param, BeforeGroup: pa.group,
+			})
+		} else if pa.group != pb.group || !equalJSON(pa.param, pb.param) {
+			diff.Parameters = append(diff.Parameters, &ParameterChange{
+				Key: key, Type: Modified, Before: pa.param, After: pb.param,
+				BeforeGroup: pa.group, AfterGroup: pb.group,
+			})
+		}
+	}
+	for key, pb := range after {
+		if _, ok := before[key]; !ok {
+			diff.Parameters = append(diff.Parameters, &ParameterChange{
+				Key: key, Type: Added, After: pb.param, AfterGroup: pb.group,
+			})
+		}
+	}
+	sort.Slice(diff.Parameters, func(i, j int) bool {
+		return diff.Parameters[i].Key < diff.Parameters[j].Key
+	})
+
+	ca, cb := indexConditions(a), indexConditions(b)
+	var commonA, commonB []string
+	for _, c := range a.Conditions {
+		if c == nil {
+			continue
+		}
+		other, ok := cb[c.Name]
+		if !ok {
+			diff.Conditions = append(diff.Conditions, &ConditionChange{Name: c.Name, Type: Removed, Before: c})
+			continue
+		}
+		commonA = append(commonA, c.Name)
+		if !equalJSON(c, other) {
+			diff.Conditions = append(diff.Conditions, &ConditionChange{
+				Name: c.Name, Type: Modified, Before: c, After: other,
+			})
+		}
+	}
+	for _, c := range b.Conditions {
+		if c == nil {
+			continue
+		}
+		if _, ok := ca[c.Name]; !ok {
+			diff.Conditions = append(diff.Conditions, &ConditionChange{Name: c.Name, Type: Added, After: c})
+			continue
+		}
+		commonB = append(commonB, c.Name)
+	}
+	sort.Slice(diff.Conditions, func(i, j int) bool {
+		return diff.Conditions[i].Name < diff.Conditions[j].Name
+	})
+	diff.ConditionOrderChanged = !reflect.DeepEqual(commonA, commonB)
+	return diff
+}
+
+// Empty checks whether the templates compared by Diff are equivalent.
+func (d *TemplateDiff) Empty() bool {
+	return len(d.Parameters) == 0 && len(d.Conditions) == 0 && !d.ConditionOrderChanged
+}
+
+// String returns a human-readable summary of the changes, with one change per line.
+func (d *TemplateDiff) String() string {
+	var lines []string
+	for _, c := range d.Conditions {
+		lines = append(lines, fmt.Sprintf("%s condition %q", changeSymbol(c.Type), c.Name))
+	}
+	if d.ConditionOrderChanged {
+		lines = append(lines, "~ condition order")
+	}
+	for _, p := range d.Parameters {
+		line := fmt.Sprintf("%s parameter %q", changeSymbol(p.Type), p.Key)
+		if p.Type == Modified && p.BeforeGroup != p.AfterGroup {
+			line = fmt.Sprintf("%s (group %q -> %q)", line, p.BeforeGroup, p.AfterGroup)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func changeSymbol(t ChangeType) string {
+	switch t {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+type groupedParameter struct {
+	param *Parameter
+	group string
+}
+
+func indexParameters(t *Template) map[string]*groupedParameter {
+	result := make(map[string]*groupedParameter)
+	for key, p := range t.Parameters {
+		result[key] = &groupedParameter{param: p}
+	}
+	for name, g := range t.ParameterGroups {
+		if g == nil {
+			continue
+		}
+		for key, p := range g.Parameters {
+			result[key] = &groupedParameter{param: p, group: name}
+		}
+	}
+	return result
+}
+
+func indexConditions(t *Template) map[string]*Condition {
+	result := make(map[string]*Condition, len(t.Conditions))
+	for _, c := range t.Conditions {
+		if c != nil {
+			result[c.Name] = c
+		}
+	}
+	return result
+}
+
+// equalJSON checks whether a and b have the same JSON representation, so that nil and empty maps
+// and slices compare as equal, as they do on the backend.
+func equalJSON(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return bytes.Equal(ja, jb)
+}
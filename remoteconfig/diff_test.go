@@ -0,0 +1,125 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffEqual(t *testing.T) {
+	b := *testTemplate
+	b.Version = nil
+	b.ETag = "other"
+	for _, d := range []*TemplateDiff{Diff(testTemplate, &b), Diff(nil, &Template{})} {
+		if !d.Empty() || d.String() != "" {
+			t.Errorf("Diff() = %v; want = empty", d)
+		}
+	}
+}
+
+func TestDiffEmptyCollections(t *testing.T) {
+	ios := &Condition{Name: "ios", Expression: DeviceOS("ios")}
+	a := &Template{
+		Conditions: []*Condition{ios},
+		Parameters: map[string]*Parameter{
+			"welcome": {DefaultValue: NewParameterValue("Welcome!")},
+		},
+	}
+	b := &Template{
+		Conditions: []*Condition{nil, ios, nil},
+		Parameters: map[string]*Parameter{
+			"welcome": {
+				DefaultValue:      NewParameterValue("Welcome!"),
+				ConditionalValues: map[string]*ParameterValue{},
+			},
+		},
+	}
+	for _, d := range []*TemplateDiff{Diff(a, b), Diff(b, a)} {
+		if !d.Empty() {
+			t.Errorf("Diff() = %v; want = empty", d)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ios := &Condition{Name: "ios", Expression: DeviceOS("ios")}
+	android := &Condition{Name: "android", Expression: DeviceOS("android")}
+	beta := &Condition{Name: "beta", Expression: PercentAtMost("beta", 10)}
+	betaWider := &Condition{Name: "beta", Expression: PercentAtMost("beta", 20)}
+	us := &Condition{Name: "us", Expression: DeviceCountry("US")}
+
+	welcome := &Parameter{DefaultValue: NewParameterValue("Welcome!")}
+	welcomeBack := &Parameter{DefaultValue: NewParameterValue("Welcome back!")}
+	maxItems := &Parameter{DefaultValue: NewParameterValue("10")}
+	theme := &Parameter{DefaultValue: NewParameterValue("dark")}
+	legacy := &Parameter{DefaultValue: InAppDefaultValue()}
+
+	a := &Template{
+		Conditions: []*Condition{ios, android, beta, us},
+		Parameters: map[string]*Parameter{
+			"welcome":   welcome,
+			"max_items": maxItems,
+			"legacy":    legacy,
+		},
+	}
+	b := &Template{
+		Conditions: []*Condition{android, ios, betaWider},
+		Parameters: map[string]*Parameter{
+			"welcome": welcomeBack,
+			"theme":   theme,
+		},
+		ParameterGroups: map[string]*ParameterGroup{
+			"checkout": {Parameters: map[string]*Parameter{"max_items": maxItems}},
+		},
+	}
+
+	d := Diff(a, b)
+	wantParams := []*ParameterChange{
+		{Key: "legacy", Type: Removed, Before: legacy},
+		{Key: "max_items", Type: Modified, Before: maxItems, After: maxItems, AfterGroup: "checkout"},
+		{Key: "theme", Type: Added, After: theme},
+		{Key: "welcome", Type: Modified, Before: welcome, After: welcomeBack},
+	}
+	if !reflect.DeepEqual(d.Parameters, wantParams) {
+		t.Errorf("Diff().Parameters = %v; want = %v", d.Parameters, wantParams)
+	}
+	wantConditions := []*ConditionChange{
+		{Name: "beta", Type: Modified, Before: beta, After: betaWider},
+		{Name: "us", Type: Removed, Before: us},
+	}
+	if !reflect.DeepEqual(d.Conditions, wantConditions) {
+		t.Errorf("Diff().Conditions = %v; want = %v", d.Conditions, wantConditions)
+	}
+	if !d.ConditionOrderChanged || d.Empty() {
+		t.Errorf("Diff() = (%v, %v); want = (order changed, not empty)", d.ConditionOrderChanged, d.Empty())
+	}
+
+	want := `~ condition "beta"
+- condition "us"
+~ condition order
+- parameter "legacy"
+~ parameter "max_items" (group "" -> "checkout")
++ parameter "theme"
+~ parameter "welcome"`
+	if got := d.String(); got != want {
+		t.Errorf("String() = %s; want = %s", got, want)
+	}
+
+	reverse := Diff(b, a)
+	if len(reverse.Parameters) != 4 || reverse.Parameters[0].Type != Added || reverse.Parameters[2].Type != Removed {
+		t.Errorf("Diff(b, a).Parameters = %v; want = reversed changes", reverse.Parameters)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintRule identifies a check performed by Template.Lint.
+type LintRule string
+
+// Rules checked by Template.Lint.
+const (
+	// LintUnusedCondition reports conditions that no parameter has a conditional value for.
+	LintUnusedCondition LintRule = "UNUSED_CONDITION"
+
+	// LintMissingDefaultValue reports parameters without a default value, which resolve to the
+	// in-app default, or to an empty value, when no condition matches.
+	LintMissingDefaultValue LintRule = "MISSING_DEFAULT_VALUE"
+
+	// LintUnknownCustomSignal reports conditions that refer to custom signals that are not listed
+	// in the LintOptions.
+	LintUnknownCustomSignal LintRule = "UNKNOWN_CUSTOM_SIGNAL"
+)
+
+// LintOptions configures the checks performed by Template.Lint.
+type LintOptions struct {
+	// CustomSignals lists the keys of the custom signals set by the apps. When set, conditions
+	// that refer to other custom signals, as in app.customSignal['key'], are reported. Optional.
+	CustomSignals []string
+}
+
+// LintWarning is an issue found by Template.Lint.
+type LintWarning struct {
+	// Rule is the check that found the issue.
+	Rule LintRule
+
+	// Subject is the name of the condition, or the key of the parameter, that the issue is about.
+	Subject string
+
+	// Message describes the issue.
+	Message string
+}
+
+func (w *LintWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Rule, w.Message)
+}
+
+var customSignalPattern = regexp.MustCompile(`app\.customSignal\[\s*'((?:[^'\\]|\\.)*)'\s*\]`)
+
+// Lint checks the template for issues that do not prevent it from being published, but are
+// likely mistakes, such as unused conditions and parameters without default values.
+//
+// Lint is performed locally, and is typically run before publishing a template, so that CI
+// pipelines can fail on warnings. Conditions are reported in the order of the template, followed
+// by the parameters in the order of their keys. The options may be nil, in which case custom
+// signals are not checked. Lint does not report the errors reported by Validate.
+func (t *Template) Lint(opts *LintOptions) []*LintWarning {
+	var warnings []*LintWarning
+	used := make(map[string]bool)
+	params := t.allParameters()
+	keys := make([]string, 0, len(params))
+	for key, p := range params {
+		keys = append(keys, key)
+		if p == nil {
+			continue
+		}
+		for name := range p.ConditionalValues {
+			used[name] = true
+		}
+	}
+	sort.Strings(keys)
+
+	var signals map[string]bool
+	if opts != nil && opts.CustomSignals != nil {
+		signals = make(map[string]bool, len(opts.CustomSignals))
+		for _, s := range opts.CustomSignals {
+			signals[s] = true
+		}
+	}
+
+	for _, c := range t.Conditions {
+		if c == nil {
+			continue
+		}
+		if !used[c.Name] {
+			warnings = append(warnings, &LintWarning{
+				Rule:    LintUnusedCondition,
+				Subject: c.Name,
+				Message: fmt.Sprintf("condition %q is not used by any parameter", c.Name),
+			})
+		}
+		if signals == nil {
+			continue
+		}
+		for _, m := range customSignalPattern.FindAllStringSubmatch(c.Expression, -1) {
+			if key := unquote(m[1]); !signals[key] {
+				warnings = append(warnings, &LintWarning{
+					Rule:    LintUnknownCustomSignal,
+					Subject: c.Name,
+					Message: fmt.Sprintf("condition %q refers to unknown custom signal %q", c.Name, key),
+				})
+			}
+		}
+	}
+
+	for _, key := range keys {
+		if p := params[key]; p != nil && p.DefaultValue == nil {
+			warnings = append(warnings, &LintWarning{
+				Rule:    LintMissingDefaultValue,
+				Subject: key,
+				Message: fmt.Sprintf("parameter %q has no default value", key),
+			})
+		}
+	}
+	return warnings
+}
+
+// allParameters returns the parameters of the template, including the parameters in groups.
+func (t *Template) allParameters() map[string]*Parameter {
+	params := make(map[string]*Parameter, len(t.Parameters))
+	for key, p := range t.Parameters {
+		params[key] = p
+	}
+	for _, g := range t.ParameterGroups {
+		if g == nil {
+			continue
+		}
+		for key, p := range g.Parameters {
+			params[key] = p
+		}
+	}
+	return params
+}
+
+// unquote reverses the escaping performed by quote.
+func unquote(s string) string {
+	return strings.NewReplacer(`\'`, "'", `\\`, `\`).Replace(s)
+}
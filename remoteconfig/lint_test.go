@@ -0,0 +1,97 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	template := &Template{
+		Conditions: []*Condition{
+			{Name: "ios", Expression: DeviceOS("ios")},
+			{Name: "unused", Expression: "true"},
+			{Name: "vip", Expression: "app.customSignal['tier'] == 'vip' && app.customSignal['plan'] == 'pro'"},
+		},
+		Parameters: map[string]*Parameter{
+			"welcome": {
+				DefaultValue:      NewParameterValue("Welcome!"),
+				ConditionalValues: map[string]*ParameterValue{"ios": InAppDefaultValue()},
+			},
+			"banner": {
+				ConditionalValues: map[string]*ParameterValue{"vip": NewParameterValue("gold")},
+			},
+		},
+		ParameterGroups: map[string]*ParameterGroup{
+			"checkout": {
+				Parameters: map[string]*Parameter{"max_items": {}},
+			},
+		},
+	}
+
+	got := template.Lint(&LintOptions{CustomSignals: []string{"tier"}})
+	want := []*LintWarning{
+		{
+			Rule:    LintUnusedCondition,
+			Subject: "unused",
+			Message: `condition "unused" is not used by any parameter`,
+		},
+		{
+			Rule:    LintUnknownCustomSignal,
+			Subject: "vip",
+			Message: `condition "vip" refers to unknown custom signal "plan"`,
+		},
+		{
+			Rule:    LintMissingDefaultValue,
+			Subject: "banner",
+			Message: `parameter "banner" has no default value`,
+		},
+		{
+			Rule:    LintMissingDefaultValue,
+			Subject: "max_items",
+			Message: `parameter "max_items" has no default value`,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %v; want = %v", got, want)
+	}
+
+	// Custom signals are only checked when they are specified.
+	if got := template.Lint(nil); len(got) != 3 {
+		t.Errorf("Lint(nil) = %v; want = 3 warnings", got)
+	}
+}
+
+func TestLintNoWarnings(t *testing.T) {
+	if got := testTemplate.Lint(nil); len(got) != 0 {
+		t.Errorf("Lint() = %v; want = []", got)
+	}
+	if got := (&Template{}).Lint(&LintOptions{}); len(got) != 0 {
+		t.Errorf("Lint() = %v; want = []", got)
+	}
+}
+
+func TestLintWarningString(t *testing.T) {
+	w := &LintWarning{
+		Rule:    LintMissingDefaultValue,
+		Subject: "param",
+		Message: `parameter "param" has no default value`,
+	}
+	want := `MISSING_DEFAULT_VALUE: parameter "param" has no default value`
+	if got := w.String(); got != want {
+		t.Errorf("String() = %q; want = %q", got, want)
+	}
+}
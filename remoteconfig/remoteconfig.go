@@ -0,0 +1,171 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remoteconfig contains functions for managing the Remote Config templates of a Firebase
+// project.
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	remoteConfigEndpoint = "https://firebaseremoteconfig.googleapis.com/v1"
+	clientHeader         = "X-Firebase-Client"
+)
+
+// Client is the interface for the Firebase Remote Config service.
+type Client struct {
+	endpoint   string
+	projectID  string
+	httpClient *internal.HTTPClient
+}
+
+// NewClient creates a new instance of the Firebase Remote Config Client.
+//
+// This function can only be invoked from within the SDK. Client applications should access the
+// Remote Config service through firebase.App.
+func NewClient(ctx context.Context, conf *internal.RemoteConfigConfig) (*Client, error) {
+	if conf.ProjectID == "" {
+		return nil, errors.New("project id is required to access the remote config service")
+	}
+
+	hc, _, err := internal.NewHTTPClient(ctx, conf.Opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	hc.Opts = []internal.HTTPOption{
+		internal.WithHeader(clientHeader, fmt.Sprintf("fire-admin-go/%s", conf.Version)),
+	}
+	hc.MetricsFn = conf.MetricsFn
+	endpoint := remoteConfigEndpoint
+	if conf.Endpoint != "" {
+		endpoint = conf.Endpoint + "/v1"
+	}
+	return &Client{
+		endpoint:   endpoint,
+		projectID:  conf.ProjectID,
+		httpClient: hc,
+	}, nil
+}
+
+// GetTemplate returns the current active version of the Remote Config template of the project.
+//
+// The ETag of the returned template can be used to detect concurrent changes when the template is
+// published.
+func (c *Client) GetTemplate(ctx context.Context) (*Template, error) {
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    c.templateURL(),
+	}
+	return c.sendTemplate(ctx, req)
+}
+
+// PublishTemplate publishes the given template as the new active version of the Remote Config
+// template of the project.
+//
+// The template is only published if its ETag matches the ETag of the current active version, so
+// that concurrent changes are not overwritten. Typically the template is obtained with
+// GetTemplate, modified, and then published. If the template was changed in the meantime, an
+// error is returned, which can be checked with errorutils.IsFailedPrecondition. Returns the
+// published template, along with its new version metadata and ETag.
+func (c *Client) PublishTemplate(ctx context.Context, template *Template) (*Template, error) {
+	if template == nil {
+		return nil, errors.New("template must not be nil")
+	}
+	if template.ETag == "" {
+		return nil, errors.New("template etag must not be empty")
+	}
+	return c.publish(ctx, template, template.ETag, false)
+}
+
+// ForcePublishTemplate publishes the given template as the new active version of the Remote
+// Config template of the project, regardless of its ETag.
+//
+// Unlike PublishTemplate, ForcePublishTemplate overwrites any changes made since the template
+// was read.
+func (c *Client) ForcePublishTemplate(ctx context.Context, template *Template) (*Template, error) {
+	if template == nil {
+		return nil, errors.New("template must not be nil")
+	}
+	return c.publish(ctx, template, "*", false)
+}
+
+// ValidateTemplate checks that the given template can be published, without publishing it.
+//
+// Unlike Template.Validate, ValidateTemplate has the server check the template. The ETag of the
+// template is checked just like PublishTemplate does. Returns the validated template, with the
+// same ETag as the given one.
+func (c *Client) ValidateTemplate(ctx context.Context, template *Template) (*Template, error) {
+	if template == nil {
+		return nil, errors.New("template must not be nil")
+	}
+	if template.ETag == "" {
+		return nil, errors.New("template etag must not be empty")
+	}
+	result, err := c.publish(ctx, template, template.ETag, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server does not return a usable ETag for templates that are only validated.
+	result.ETag = template.ETag
+	return result, nil
+}
+
+func (c *Client) publish(
+	ctx context.Context, template *Template, etag string, validateOnly bool) (*Template, error) {
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := &internal.Request{
+		Method: http.MethodPut,
+		URL:    c.templateURL(),
+		Body:   internal.NewJSONEntity(newTemplateRequest(template)),
+		Opts: []internal.HTTPOption{
+			internal.WithHeader("If-Match", etag),
+		},
+	}
+	if validateOnly {
+		req.Opts = append(req.Opts, internal.WithQueryParam("validate_only", "true"))
+	}
+	return c.sendTemplate(ctx, req)
+}
+
+func (c *Client) templateURL() string {
+	return fmt.Sprintf("%s/projects/%s/remoteConfig", c.endpoint, c.projectID)
+}
+
+// sendTemplate sends a request that returns a template, and sets the ETag of the template from
+// the response headers.
+func (c *Client) sendTemplate(ctx context.Context, req *internal.Request) (*Template, error) {
+	var result Template
+	resp, err := c.httpClient.DoAndUnmarshal(ctx, req, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ETag = resp.Header.Get("ETag")
+	if result.ETag == "" {
+		return nil, errors.New("etag not found in the remote config response")
+	}
+	return &result, nil
+}
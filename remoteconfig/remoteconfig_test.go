@@ -0,0 +1,290 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+)
+
+var testConfig = &internal.RemoteConfigConfig{
+	ProjectID: "test-project",
+	Opts: []option.ClientOption{
+		option.WithTokenSource(&internal.MockTokenSource{AccessToken: "test-token"}),
+	},
+	Version: "test-version",
+}
+
+const testTemplateJSON = `{
+	"conditions": [{
+		"name": "ios",
+		"expression": "device.os == 'ios'",
+		"tagColor": "BLUE"
+	}],
+	"parameters": {
+		"welcome_message": {
+			"defaultValue": {"value": "Welcome!"},
+			"conditionalValues": {"ios": {"useInAppDefault": true}},
+			"description": "Greeting shown on launch",
+			"valueType": "STRING"
+		}
+	},
+	"parameterGroups": {
+		"checkout": {
+			"description": "Checkout flow",
+			"parameters": {
+				"max_items": {"defaultValue": {"value": "10"}, "valueType": "NUMBER"}
+			}
+		}
+	},
+	"version": {
+		"versionNumber": "42",
+		"updateTime": "2026-01-02T03:04:05.678Z",
+		"updateOrigin": "CONSOLE",
+		"updateType": "INCREMENTAL_UPDATE",
+		"updateUser": {"email": "user@example.com"},
+		"description": "Add checkout group"
+	}
+}`
+
+var testTemplate = &Template{
+	Conditions: []*Condition{
+		{Name: "ios", Expression: "device.os == 'ios'", TagColor: TagColorBlue},
+	},
+	Parameters: map[string]*Parameter{
+		"welcome_message": {
+			DefaultValue:      NewParameterValue("Welcome!"),
+			ConditionalValues: map[string]*ParameterValue{"ios": InAppDefaultValue()},
+			Description:       "Greeting shown on launch",
+			ValueType:         ValueTypeString,
+		},
+	},
+	ParameterGroups: map[string]*ParameterGroup{
+		"checkout": {
+			Description: "Checkout flow",
+			Parameters: map[string]*Parameter{
+				"max_items": {DefaultValue: NewParameterValue("10"), ValueType: ValueTypeNumber},
+			},
+		},
+	},
+	Version: &Version{
+		VersionNumber: 42,
+		UpdateTime:    time.Date(2026, 1, 2, 3, 4, 5, 678000000, time.UTC),
+		UpdateOrigin:  "CONSOLE",
+		UpdateType:    "INCREMENTAL_UPDATE",
+		UpdateUser:    &User{Email: "user@example.com"},
+		Description:   "Add checkout group",
+	},
+	ETag: "etag-123",
+}
+
+func TestNoProjectID(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.RemoteConfigConfig{})
+	if client != nil || err == nil {
+		t.Errorf("NewClient() = (%v, %v); want = (nil, error)", client, err)
+	}
+}
+
+func TestGetTemplate(t *testing.T) {
+	var req *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", "etag-123")
+		w.Write([]byte(testTemplateJSON))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	template, err := client.GetTemplate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(template, testTemplate) {
+		t.Errorf("GetTemplate() = %#v; want = %#v", template, testTemplate)
+	}
+	if req.Method != http.MethodGet || req.URL.Path != "/v1/projects/test-project/remoteConfig" {
+		t.Errorf("GetTemplate() = %s %s; want = GET /v1/projects/test-project/remoteConfig", req.Method, req.URL.Path)
+	}
+	if got := req.Header.Get("X-Firebase-Client"); got != "fire-admin-go/test-version" {
+		t.Errorf("X-Firebase-Client = %q; want = %q", got, "fire-admin-go/test-version")
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer test-token" {
+		t.Errorf("Authorization = %q; want = %q", got, "Bearer test-token")
+	}
+}
+
+func TestGetTemplateWithoutETag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testTemplateJSON))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	if template, err := client.GetTemplate(context.Background()); template != nil || err == nil {
+		t.Errorf("GetTemplate() = (%v, %v); want = (nil, error)", template, err)
+	}
+}
+
+func TestGetTemplateError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"status": "NOT_FOUND", "message": "template not found"}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	template, err := client.GetTemplate(context.Background())
+	if template != nil || !errorutils.IsNotFound(err) || err.Error() != "template not found" {
+		t.Errorf("GetTemplate() = (%v, %v); want = (nil, %q)", template, err, "template not found")
+	}
+}
+
+func TestPublishTemplate(t *testing.T) {
+	var reqs []*http.Request
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", "etag-456")
+		w.Write([]byte(testTemplateJSON))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	ctx := context.Background()
+	published, err := client.PublishTemplate(ctx, testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.ETag != "etag-456" || published.Version.VersionNumber != 42 {
+		t.Errorf("PublishTemplate() = %v; want = etag-456, version 42", published)
+	}
+	if _, err := client.ForcePublishTemplate(ctx, &Template{}); err != nil {
+		t.Fatal(err)
+	}
+	validated, err := client.ValidateTemplate(ctx, testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validated.ETag != "etag-123" {
+		t.Errorf("ValidateTemplate() = %q; want = %q", validated.ETag, "etag-123")
+	}
+
+	wantIfMatch := []string{"etag-123", "*", "etag-123"}
+	wantValidate := []string{"", "", "true"}
+	for i, r := range reqs {
+		if r.Method != http.MethodPut || r.URL.Path != "/v1/projects/test-project/remoteConfig" {
+			t.Errorf("Request(%d) = %s %s; want = PUT /v1/projects/test-project/remoteConfig", i, r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("If-Match"); got != wantIfMatch[i] {
+			t.Errorf("Request(%d) If-Match = %q; want = %q", i, got, wantIfMatch[i])
+		}
+		if got := r.URL.Query().Get("validate_only"); got != wantValidate[i] {
+			t.Errorf("Request(%d) validate_only = %q; want = %q", i, got, wantValidate[i])
+		}
+	}
+
+	var want map[string]interface{}
+	b, _ := json.Marshal(map[string]interface{}{
+		"conditions":      testTemplate.Conditions,
+		"parameters":      testTemplate.Parameters,
+		"parameterGroups": testTemplate.ParameterGroups,
+		"version":         map[string]string{"description": "Add checkout group"},
+	})
+	json.Unmarshal(b, &want)
+	if !reflect.DeepEqual(bodies[0], want) {
+		t.Errorf("PublishTemplate() body = %v; want = %v", bodies[0], want)
+	}
+	if len(bodies[1]) != 0 {
+		t.Errorf("ForcePublishTemplate() body = %v; want = empty", bodies[1])
+	}
+}
+
+func TestPublishTemplateConflict(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`{"error": {"status": "FAILED_PRECONDITION", "message": "etag mismatch"}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	template, err := client.PublishTemplate(context.Background(), testTemplate)
+	if template != nil || !errorutils.IsFailedPrecondition(err) {
+		t.Errorf("PublishTemplate() = (%v, %v); want = (nil, failed precondition)", template, err)
+	}
+}
+
+func TestPublishInvalidTemplate(t *testing.T) {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, template := range []*Template{nil, {}} {
+		if _, err := client.PublishTemplate(ctx, template); err == nil {
+			t.Errorf("PublishTemplate(%v) = nil; want = error", template)
+		}
+		if _, err := client.ValidateTemplate(ctx, template); err == nil {
+			t.Errorf("ValidateTemplate(%v) = nil; want = error", template)
+		}
+	}
+	if _, err := client.ForcePublishTemplate(ctx, nil); err == nil {
+		t.Errorf("ForcePublishTemplate(nil) = nil; want = error")
+	}
+}
+
+func TestParameterValueJSON(t *testing.T) {
+	cases := []struct {
+		value *ParameterValue
+		want  string
+	}{
+		{NewParameterValue("foo"), `{"value":"foo"}`},
+		{NewParameterValue(""), `{"value":""}`},
+		{InAppDefaultValue(), `{"useInAppDefault":true}`},
+		{&ParameterValue{Value: "ignored", UseInAppDefault: true}, `{"useInAppDefault":true}`},
+	}
+	for _, tc := range cases {
+		b, err := tc.value.MarshalJSON()
+		if err != nil || string(b) != tc.want {
+			t.Errorf("MarshalJSON(%v) = (%s, %v); want = (%s, nil)", tc.value, b, err, tc.want)
+		}
+	}
+}
+
+func newTestClient(t *testing.T, ts *httptest.Server) *Client {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.endpoint = ts.URL + "/v1"
+	client.httpClient.RetryConfig = nil
+	return client
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+const maxRolloutPercent = 100
+
+// RolloutValue is the value of a parameter that is gradually rolled out to a percentage of the
+// app instances.
+type RolloutValue struct {
+	// RolloutID identifies the rollout that the value belongs to.
+	RolloutID string `json:"rolloutId"`
+
+	// Value is the value of the parameter for the app instances included in the rollout.
+	Value string `json:"value"`
+
+	// Percent is the percentage of the app instances included in the rollout, from 0 to 100.
+	Percent int `json:"percent"`
+}
+
+func (r *RolloutValue) validate() error {
+	if r.RolloutID == "" {
+		return errors.New("rollout id must not be empty")
+	}
+	if r.Percent < 0 || r.Percent > maxRolloutPercent {
+		return fmt.Errorf("rollout percent must be between 0 and %d", maxRolloutPercent)
+	}
+	return nil
+}
+
+// NewRolloutValue returns a ParameterValue that rolls out the given value to the given percentage
+// of the app instances, as part of the rollout with the given ID.
+func NewRolloutValue(rolloutID, value string, percent int) *ParameterValue {
+	return &ParameterValue{
+		Rollout: &RolloutValue{
+			RolloutID: rolloutID,
+			Value:     value,
+			Percent:   percent,
+		},
+	}
+}
+
+// AdvanceRollout increases the percentage of the app instances included in the rollout with the
+// given ID, for all the parameter values of the template that belong to the rollout.
+//
+// The percentage must not be lower than the current percentage of any of the values. Use
+// HaltRollout to stop a rollout. The changes take effect once the template is published.
+func (t *Template) AdvanceRollout(rolloutID string, percent int) error {
+	if percent < 0 || percent > maxRolloutPercent {
+		return fmt.Errorf("rollout percent must be between 0 and %d", maxRolloutPercent)
+	}
+	values, err := t.rolloutValues(rolloutID)
+	if err != nil {
+		return err
+	}
+	for _, r := range values {
+		if percent < r.Percent {
+			return fmt.Errorf(
+				"rollout %q is at %d percent; use HaltRollout to decrease it", rolloutID, r.Percent)
+		}
+	}
+	for _, r := range values {
+		r.Percent = percent
+	}
+	return nil
+}
+
+// HaltRollout stops the rollout with the given ID, by excluding all the app instances from it.
+//
+// The parameter values that belong to the rollout are kept in the template, so that the rollout
+// can be resumed with AdvanceRollout. The changes take effect once the template is published.
+func (t *Template) HaltRollout(rolloutID string) error {
+	values, err := t.rolloutValues(rolloutID)
+	if err != nil {
+		return err
+	}
+	for _, r := range values {
+		r.Percent = 0
+	}
+	return nil
+}
+
+// rolloutValues returns the parameter values of the template that belong to the rollout with the
+// given ID, including the values of the parameters in groups.
+func (t *Template) rolloutValues(rolloutID string) ([]*RolloutValue, error) {
+	if rolloutID == "" {
+		return nil, errors.New("rollout id must not be empty")
+	}
+
+	var values []*RolloutValue
+	for _, p := range t.allParameters() {
+		if p == nil {
+			continue
+		}
+		if v := p.DefaultValue; v != nil && v.Rollout != nil && v.Rollout.RolloutID == rolloutID {
+			values = append(values, v.Rollout)
+		}
+		for _, v := range p.ConditionalValues {
+			if v != nil && v.Rollout != nil && v.Rollout.RolloutID == rolloutID {
+				values = append(values, v.Rollout)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("rollout not found: %q", rolloutID)
+	}
+	return values, nil
+}
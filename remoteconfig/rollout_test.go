@@ -0,0 +1,148 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func rolloutTemplateForTests() *Template {
+	return &Template{
+		Conditions: []*Condition{
+			{Name: "beta", Expression: "app.id == 'beta'"},
+		},
+		Parameters: map[string]*Parameter{
+			"new_ui": {
+				DefaultValue: NewParameterValue("false"),
+				ConditionalValues: map[string]*ParameterValue{
+					"beta": NewRolloutValue("rollout_1", "true", 10),
+				},
+			},
+		},
+		ParameterGroups: map[string]*ParameterGroup{
+			"checkout": {
+				Parameters: map[string]*Parameter{
+					"new_checkout": {DefaultValue: NewRolloutValue("rollout_1", "true", 10)},
+					"other":        {DefaultValue: NewRolloutValue("rollout_2", "x", 50)},
+				},
+			},
+		},
+	}
+}
+
+func TestRolloutValueJSON(t *testing.T) {
+	v := NewRolloutValue("rollout_1", "true", 25)
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"rolloutValue":{"rolloutId":"rollout_1","value":"true","percent":25}}`
+	if string(b) != want {
+		t.Errorf("Marshal() = %s; want = %s", b, want)
+	}
+
+	var got ParameterValue
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, v) {
+		t.Errorf("Unmarshal() = %#v; want = %#v", got, v)
+	}
+}
+
+func TestAdvanceRollout(t *testing.T) {
+	template := rolloutTemplateForTests()
+	if err := template.AdvanceRollout("rollout_1", 50); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := template.Parameters["new_ui"].ConditionalValues["beta"].Rollout.Percent; got != 50 {
+		t.Errorf("Percent = %d; want = 50", got)
+	}
+	checkout := template.ParameterGroups["checkout"].Parameters
+	if got := checkout["new_checkout"].DefaultValue.Rollout.Percent; got != 50 {
+		t.Errorf("Percent = %d; want = 50", got)
+	}
+	if got := checkout["other"].DefaultValue.Rollout.Percent; got != 50 {
+		t.Errorf("Percent of other rollout = %d; want = 50", got)
+	}
+	if err := template.Validate(); err != nil {
+		t.Errorf("Validate() = %v; want = nil", err)
+	}
+}
+
+func TestAdvanceRolloutError(t *testing.T) {
+	cases := []struct {
+		name      string
+		rolloutID string
+		percent   int
+	}{
+		{"EmptyID", "", 50},
+		{"UnknownID", "unknown", 50},
+		{"NegativePercent", "rollout_1", -1},
+		{"PercentTooLarge", "rollout_1", 101},
+		{"Decrease", "rollout_1", 5},
+	}
+	for _, tc := range cases {
+		template := rolloutTemplateForTests()
+		if err := template.AdvanceRollout(tc.rolloutID, tc.percent); err == nil {
+			t.Errorf("AdvanceRollout(%s) = nil; want = error", tc.name)
+		}
+		if !reflect.DeepEqual(template, rolloutTemplateForTests()) {
+			t.Errorf("AdvanceRollout(%s) modified the template", tc.name)
+		}
+	}
+}
+
+func TestHaltRollout(t *testing.T) {
+	template := rolloutTemplateForTests()
+	if err := template.HaltRollout("rollout_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := template.Parameters["new_ui"].ConditionalValues["beta"].Rollout.Percent; got != 0 {
+		t.Errorf("Percent = %d; want = 0", got)
+	}
+	checkout := template.ParameterGroups["checkout"].Parameters
+	if got := checkout["new_checkout"].DefaultValue.Rollout.Percent; got != 0 {
+		t.Errorf("Percent = %d; want = 0", got)
+	}
+	if got := checkout["other"].DefaultValue.Rollout.Percent; got != 50 {
+		t.Errorf("Percent of other rollout = %d; want = 50", got)
+	}
+
+	if err := template.HaltRollout("unknown"); err == nil {
+		t.Errorf("HaltRollout(unknown) = nil; want = error")
+	}
+}
+
+func TestValidateRolloutValues(t *testing.T) {
+	cases := []*ParameterValue{
+		NewRolloutValue("", "true", 10),
+		NewRolloutValue("rollout_1", "true", -1),
+		NewRolloutValue("rollout_1", "true", 101),
+		{UseInAppDefault: true, Rollout: &RolloutValue{RolloutID: "rollout_1"}},
+	}
+	for i, v := range cases {
+		template := &Template{
+			Parameters: map[string]*Parameter{"param": {DefaultValue: v}},
+		}
+		if err := template.Validate(); err == nil {
+			t.Errorf("Validate(%d) = nil; want = error", i)
+		}
+	}
+}
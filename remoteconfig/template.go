@@ -0,0 +1,327 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	maxConditions = 500
+	maxParameters = 2000
+)
+
+// Template represents a Remote Config template.
+type Template struct {
+	// Conditions are the conditions of the template, in descending order of priority. The values
+	// of the first matching condition are used.
+	Conditions []*Condition `json:"conditions,omitempty"`
+
+	// Parameters maps the keys of the parameters that do not belong to a group to their values.
+	Parameters map[string]*Parameter `json:"parameters,omitempty"`
+
+	// ParameterGroups maps the names of the parameter groups to their parameters.
+	ParameterGroups map[string]*ParameterGroup `json:"parameterGroups,omitempty"`
+
+	// Version is the metadata of the version of the template.
+	Version *Version `json:"version,omitempty"`
+
+	// ETag identifies the version of the template that was read from the server.
+	ETag string `json:"-"`
+}
+
+// Validate checks that the template can be published, as far as can be determined without
+// contacting the server.
+//
+// A template has at most 500 conditions and 2000 parameters, including the parameters in groups.
+// Conditions must have unique names and non-empty expressions. Parameter keys must be unique
+// across groups, and conditional values must refer to conditions of the template. Rollout values
+// must have a rollout ID, and a percentage between 0 and 100.
+func (t *Template) Validate() error {
+	if len(t.Conditions) > maxConditions {
+		return fmt.Errorf("template must not have more than %d conditions", maxConditions)
+	}
+	conditions := make(map[string]bool, len(t.Conditions))
+	for i, c := range t.Conditions {
+		if c == nil {
+			return fmt.Errorf("condition at index %d must not be nil", i)
+		}
+		if c.Name == "" {
+			return fmt.Errorf("condition at index %d must have a name", i)
+		}
+		if conditions[c.Name] {
+			return fmt.Errorf("duplicate condition name: %q", c.Name)
+		}
+		if c.Expression == "" {
+			return fmt.Errorf("condition %q must have an expression", c.Name)
+		}
+		conditions[c.Name] = true
+	}
+
+	keys := make(map[string]bool)
+	validate := func(params map[string]*Parameter) error {
+		for key, p := range params {
+			if key == "" {
+				return errors.New("parameter key must not be empty")
+			}
+			if keys[key] {
+				return fmt.Errorf("duplicate parameter key: %q", key)
+			}
+			keys[key] = true
+			if err := p.validate(conditions); err != nil {
+				return fmt.Errorf("parameter %q: %v", key, err)
+			}
+		}
+		return nil
+	}
+	if err := validate(t.Parameters); err != nil {
+		return err
+	}
+	for name, g := range t.ParameterGroups {
+		if name == "" {
+			return errors.New("parameter group name must not be empty")
+		}
+		if g == nil {
+			return fmt.Errorf("parameter group %q must not be nil", name)
+		}
+		if err := validate(g.Parameters); err != nil {
+			return err
+		}
+	}
+	if len(keys) > maxParameters {
+		return fmt.Errorf("template must not have more than %d parameters", maxParameters)
+	}
+	return nil
+}
+
+// TagColor is the color of a condition in the Firebase console.
+type TagColor string
+
+// Colors that can be assigned to conditions.
+const (
+	TagColorBlue       TagColor = "BLUE"
+	TagColorBrown      TagColor = "BROWN"
+	TagColorCyan       TagColor = "CYAN"
+	TagColorDeepOrange TagColor = "DEEP_ORANGE"
+	TagColorGreen      TagColor = "GREEN"
+	TagColorIndigo     TagColor = "INDIGO"
+	TagColorLime       TagColor = "LIME"
+	TagColorOrange     TagColor = "ORANGE"
+	TagColorPink       TagColor = "PINK"
+	TagColorPurple     TagColor = "PURPLE"
+	TagColorTeal       TagColor = "TEAL"
+)
+
+// Condition targets a subset of the app instances, as described by its expression.
+type Condition struct {
+	// Name identifies the condition in the conditional values of the parameters.
+	Name string `json:"name"`
+
+	// Expression is the logic of the condition, such as "device.os == 'ios'".
+	Expression string `json:"expression"`
+
+	// TagColor is the color of the condition in the Firebase console. Optional.
+	TagColor TagColor `json:"tagColor,omitempty"`
+}
+
+// ParameterValueType is the data type of the values of a parameter.
+type ParameterValueType string
+
+// Data types of parameter values.
+const (
+	ValueTypeUnspecified ParameterValueType = "PARAMETER_VALUE_TYPE_UNSPECIFIED"
+	ValueTypeString      ParameterValueType = "STRING"
+	ValueTypeBoolean     ParameterValueType = "BOOLEAN"
+	ValueTypeNumber      ParameterValueType = "NUMBER"
+	ValueTypeJSON        ParameterValueType = "JSON"
+)
+
+// Parameter is a Remote Config parameter.
+type Parameter struct {
+	// DefaultValue is the value of the parameter when no condition matches.
+	DefaultValue *ParameterValue `json:"defaultValue,omitempty"`
+
+	// ConditionalValues maps the names of conditions to the value of the parameter when the
+	// condition matches.
+	ConditionalValues map[string]*ParameterValue `json:"conditionalValues,omitempty"`
+
+	// Description is a description of the parameter. Optional.
+	Description string `json:"description,omitempty"`
+
+	// ValueType is the data type of the values of the parameter.
+	ValueType ParameterValueType `json:"valueType,omitempty"`
+}
+
+func (p *Parameter) validate(conditions map[string]bool) error {
+	if p == nil {
+		return errors.New("parameter must not be nil")
+	}
+	if err := p.DefaultValue.validate(); err != nil {
+		return fmt.Errorf("default value: %v", err)
+	}
+	for name, v := range p.ConditionalValues {
+		if !conditions[name] {
+			return fmt.Errorf("conditional value refers to unknown condition: %q", name)
+		}
+		if v == nil {
+			return fmt.Errorf("conditional value for condition %q must not be nil", name)
+		}
+		if err := v.validate(); err != nil {
+			return fmt.Errorf("conditional value for condition %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// ParameterValue is a value of a parameter.
+//
+// A ParameterValue is either an explicit value, a value that is gradually rolled out, or
+// instructs the app to use the default value defined in the app.
+type ParameterValue struct {
+	// Value is the value of the parameter, as a string.
+	Value string
+
+	// UseInAppDefault indicates that the app uses its in-app default value. Value is ignored if
+	// set.
+	UseInAppDefault bool
+
+	// Rollout is the value of the parameter for the app instances included in a rollout. Value is
+	// ignored if set.
+	Rollout *RolloutValue
+}
+
+type parameterValueJSON struct {
+	Value           *string       `json:"value,omitempty"`
+	UseInAppDefault bool          `json:"useInAppDefault,omitempty"`
+	RolloutValue    *RolloutValue `json:"rolloutValue,omitempty"`
+}
+
+func (v *ParameterValue) validate() error {
+	if v == nil || v.Rollout == nil {
+		return nil
+	}
+	if v.UseInAppDefault {
+		return errors.New("value must not both use the in-app default and belong to a rollout")
+	}
+	return v.Rollout.validate()
+}
+
+// NewParameterValue returns a ParameterValue with the given explicit value.
+func NewParameterValue(value string) *ParameterValue {
+	return &ParameterValue{Value: value}
+}
+
+// InAppDefaultValue returns a ParameterValue that instructs the app to use its in-app default
+// value.
+func InAppDefaultValue() *ParameterValue {
+	return &ParameterValue{UseInAppDefault: true}
+}
+
+// MarshalJSON marshals a ParameterValue into JSON.
+func (v *ParameterValue) MarshalJSON() ([]byte, error) {
+	if v.UseInAppDefault {
+		return json.Marshal(&parameterValueJSON{UseInAppDefault: true})
+	}
+	if v.Rollout != nil {
+		return json.Marshal(&parameterValueJSON{RolloutValue: v.Rollout})
+	}
+	return json.Marshal(&parameterValueJSON{Value: &v.Value})
+}
+
+// UnmarshalJSON unmarshals a JSON string into a ParameterValue.
+func (v *ParameterValue) UnmarshalJSON(b []byte) error {
+	var p parameterValueJSON
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	*v = ParameterValue{UseInAppDefault: p.UseInAppDefault, Rollout: p.RolloutValue}
+	if p.Value != nil {
+		v.Value = *p.Value
+	}
+	return nil
+}
+
+// ParameterGroup is a named group of parameters, used to organize the template.
+type ParameterGroup struct {
+	// Description is a description of the group. Optional.
+	Description string `json:"description,omitempty"`
+
+	// Parameters maps the keys of the parameters in the group to their values.
+	Parameters map[string]*Parameter `json:"parameters,omitempty"`
+}
+
+// Version is the metadata of a version of a Remote Config template.
+type Version struct {
+	// VersionNumber is the number of the version, assigned by the server.
+	VersionNumber int64 `json:"versionNumber,string,omitempty"`
+
+	// UpdateTime is the time at which the version was published.
+	UpdateTime time.Time `json:"updateTime"`
+
+	// UpdateOrigin is the origin of the update, such as "ADMIN_SDK_NODE" or "CONSOLE".
+	UpdateOrigin string `json:"updateOrigin,omitempty"`
+
+	// UpdateType is the type of the update, such as "INCREMENTAL_UPDATE", "FORCED_UPDATE" or
+	// "ROLLBACK".
+	UpdateType string `json:"updateType,omitempty"`
+
+	// UpdateUser is the user that published the version.
+	UpdateUser *User `json:"updateUser,omitempty"`
+
+	// Description is a description of the changes in the version. It can be set when publishing a
+	// template.
+	Description string `json:"description,omitempty"`
+
+	// RollbackSource is the version number of the template that was restored, for rollbacks.
+	RollbackSource string `json:"rollbackSource,omitempty"`
+
+	// IsLegacy indicates that the version was published before version history was supported.
+	IsLegacy bool `json:"isLegacy,omitempty"`
+}
+
+// User is the user that published a version of a Remote Config template.
+type User struct {
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// templateRequest is the payload sent to publish a template. Only the description of the version
+// can be set by the client.
+type templateRequest struct {
+	Conditions      []*Condition               `json:"conditions,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	ParameterGroups map[string]*ParameterGroup `json:"parameterGroups,omitempty"`
+	Version         *versionRequest            `json:"version,omitempty"`
+}
+
+type versionRequest struct {
+	Description string `json:"description,omitempty"`
+}
+
+func newTemplateRequest(t *Template) *templateRequest {
+	req := &templateRequest{
+		Conditions:      t.Conditions,
+		Parameters:      t.Parameters,
+		ParameterGroups: t.ParameterGroups,
+	}
+	if t.Version != nil && t.Version.Description != "" {
+		req.Version = &versionRequest{Description: t.Version.Description}
+	}
+	return req
+}
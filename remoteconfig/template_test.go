@@ -0,0 +1,77 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	if err := testTemplate.Validate(); err != nil {
+		t.Errorf("Validate() = %v; want = nil", err)
+	}
+	if err := (&Template{}).Validate(); err != nil {
+		t.Errorf("Validate() = %v; want = nil", err)
+	}
+
+	tooManyConditions := &Template{}
+	for i := 0; i <= maxConditions; i++ {
+		tooManyConditions.Conditions = append(tooManyConditions.Conditions, &Condition{
+			Name:       fmt.Sprintf("cond%d", i),
+			Expression: "true",
+		})
+	}
+	tooManyParameters := &Template{
+		Parameters:      make(map[string]*Parameter),
+		ParameterGroups: map[string]*ParameterGroup{"group": {Parameters: make(map[string]*Parameter)}},
+	}
+	for i := 0; i < maxParameters; i++ {
+		tooManyParameters.Parameters[fmt.Sprintf("param%d", i)] = &Parameter{}
+	}
+	tooManyParameters.ParameterGroups["group"].Parameters["extra"] = &Parameter{}
+
+	cond := &Condition{Name: "cond", Expression: "true"}
+	cases := []*Template{
+		tooManyConditions,
+		tooManyParameters,
+		{Conditions: []*Condition{nil}},
+		{Conditions: []*Condition{{Expression: "true"}}},
+		{Conditions: []*Condition{{Name: "cond"}}},
+		{Conditions: []*Condition{cond, cond}},
+		{Parameters: map[string]*Parameter{"": {}}},
+		{Parameters: map[string]*Parameter{"param": nil}},
+		{Parameters: map[string]*Parameter{
+			"param": {ConditionalValues: map[string]*ParameterValue{"unknown": NewParameterValue("x")}},
+		}},
+		{
+			Conditions: []*Condition{cond},
+			Parameters: map[string]*Parameter{
+				"param": {ConditionalValues: map[string]*ParameterValue{"cond": nil}},
+			},
+		},
+		{
+			Parameters:      map[string]*Parameter{"param": {}},
+			ParameterGroups: map[string]*ParameterGroup{"group": {Parameters: map[string]*Parameter{"param": {}}}},
+		},
+		{ParameterGroups: map[string]*ParameterGroup{"group": nil}},
+		{ParameterGroups: map[string]*ParameterGroup{"": {}}},
+	}
+	for i, tc := range cases {
+		if err := tc.Validate(); err == nil {
+			t.Errorf("Validate(%d) = nil; want = error", i)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/iterator"
+)
+
+const maxVersionsPageSize = 300
+
+// ListVersionsOptions filters the versions returned by ListVersions.
+type ListVersionsOptions struct {
+	// StartTime limits the results to the versions published at or after the given time.
+	// Optional.
+	StartTime time.Time
+
+	// EndTime limits the results to the versions published before the given time. Optional.
+	EndTime time.Time
+
+	// EndVersionNumber limits the results to the versions with a number lower than or equal to
+	// the given one. Optional.
+	EndVersionNumber int64
+}
+
+// ListVersions returns an iterator over the published versions of the Remote Config template of
+// the project, from the most recent to the oldest.
+//
+// At most 300 versions are retained, along with all the versions published in the last 90 days.
+// The options may be nil, in which case all the retained versions are returned.
+func (c *Client) ListVersions(ctx context.Context, opts *ListVersionsOptions) *VersionIterator {
+	it := &VersionIterator{
+		ctx:    ctx,
+		client: c,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(
+		it.fetch,
+		func() int { return len(it.versions) },
+		func() interface{} { b := it.versions; it.versions = nil; return b })
+	it.pageInfo.MaxSize = maxVersionsPageSize
+	return it
+}
+
+// VersionIterator is an iterator over the versions of a Remote Config template.
+type VersionIterator struct {
+	client   *Client
+	ctx      context.Context
+	opts     ListVersionsOptions
+	nextFunc func() error
+	pageInfo *iterator.PageInfo
+	versions []*Version
+}
+
+// PageInfo supports pagination.
+func (it *VersionIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next Version. The error value of [iterator.Done] is returned if there are no
+// more results. Once Next returns [iterator.Done], all subsequent calls will return
+// [iterator.Done].
+func (it *VersionIterator) Next() (*Version, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+
+	version := it.versions[0]
+	it.versions = it.versions[1:]
+	return version, nil
+}
+
+func (it *VersionIterator) fetch(pageSize int, pageToken string) (string, error) {
+	if it.opts.EndVersionNumber < 0 {
+		return "", errors.New("end version number must not be negative")
+	}
+
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+	}
+	if pageToken != "" {
+		params["pageToken"] = pageToken
+	}
+	if !it.opts.StartTime.IsZero() {
+		params["startTime"] = it.opts.StartTime.UTC().Format(time.RFC3339Nano)
+	}
+	if !it.opts.EndTime.IsZero() {
+		params["endTime"] = it.opts.EndTime.UTC().Format(time.RFC3339Nano)
+	}
+	if it.opts.EndVersionNumber != 0 {
+		params["endVersionNumber"] = strconv.FormatInt(it.opts.EndVersionNumber, 10)
+	}
+
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s:listVersions", it.client.templateURL()),
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParams(params),
+		},
+	}
+
+	var result struct {
+		Versions      []*Version `json:"versions"`
+		NextPageToken string     `json:"nextPageToken"`
+	}
+	if _, err := it.client.httpClient.DoAndUnmarshal(it.ctx, req, &result); err != nil {
+		return "", err
+	}
+
+	it.versions = append(it.versions, result.Versions...)
+	return result.NextPageToken, nil
+}
+
+// GetTemplateAtVersion returns the given version of the Remote Config template of the project.
+//
+// Unlike Rollback, GetTemplateAtVersion does not change the active version of the template, so
+// that historical versions can be inspected, or modified and published. The ETag of the returned
+// template identifies the requested version, so PublishTemplate fails unless it is the active
+// version. Use ForcePublishTemplate to publish it regardless.
+func (c *Client) GetTemplateAtVersion(ctx context.Context, versionNumber int64) (*Template, error) {
+	if versionNumber <= 0 {
+		return nil, errors.New("version number must be positive")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    c.templateURL(),
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParam("versionNumber", strconv.FormatInt(versionNumber, 10)),
+		},
+	}
+	return c.sendTemplate(ctx, req)
+}
+
+// Rollback publishes a previous version of the Remote Config template of the project as the new
+// active version.
+//
+// The rollback creates a new version, with the contents of the given version. Returns the
+// published template, along with its new version metadata and ETag.
+func (c *Client) Rollback(ctx context.Context, versionNumber int64) (*Template, error) {
+	if versionNumber <= 0 {
+		return nil, errors.New("version number must be positive")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("%s:rollback", c.templateURL()),
+		Body: internal.NewJSONEntity(map[string]string{
+			"versionNumber": strconv.FormatInt(versionNumber, 10),
+		}),
+	}
+	return c.sendTemplate(ctx, req)
+}
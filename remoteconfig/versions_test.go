@@ -0,0 +1,195 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/iterator"
+)
+
+func TestListVersions(t *testing.T) {
+	var queries []url.Values
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			w.Write([]byte(`{
+				"versions": [
+					{"versionNumber": "12", "updateTime": "2026-01-02T00:00:00Z", "updateType": "ROLLBACK", "rollbackSource": "10"},
+					{"versionNumber": "11", "updateTime": "2026-01-01T00:00:00Z"}
+				],
+				"nextPageToken": "token"
+			}`))
+			return
+		}
+		w.Write([]byte(`{"versions": [{"versionNumber": "10", "isLegacy": true}]}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	start := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	it := client.ListVersions(context.Background(), &ListVersionsOptions{
+		StartTime:        start,
+		EndTime:          end,
+		EndVersionNumber: 12,
+	})
+
+	var got []*Version
+	for {
+		v, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+
+	want := []*Version{
+		{
+			VersionNumber:  12,
+			UpdateTime:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			UpdateType:     "ROLLBACK",
+			RollbackSource: "10",
+		},
+		{VersionNumber: 11, UpdateTime: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{VersionNumber: 10, IsLegacy: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListVersions() = %v; want = %v", got, want)
+	}
+	if path != "/v1/projects/test-project/remoteConfig:listVersions" {
+		t.Errorf("Path = %q; want = %q", path, "/v1/projects/test-project/remoteConfig:listVersions")
+	}
+	if len(queries) != 2 {
+		t.Fatalf("ListVersions() = %d requests; want = 2", len(queries))
+	}
+	wantQuery := url.Values{
+		"pageSize":         {"300"},
+		"startTime":        {"2025-12-01T00:00:00Z"},
+		"endTime":          {"2026-02-01T00:00:00Z"},
+		"endVersionNumber": {"12"},
+	}
+	if !reflect.DeepEqual(queries[0], wantQuery) {
+		t.Errorf("Query = %v; want = %v", queries[0], wantQuery)
+	}
+	if got := queries[1].Get("pageToken"); got != "token" {
+		t.Errorf("PageToken = %q; want = %q", got, "token")
+	}
+}
+
+func TestListVersionsInvalid(t *testing.T) {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := client.ListVersions(context.Background(), &ListVersionsOptions{EndVersionNumber: -1})
+	if v, err := it.Next(); v != nil || err == nil || err == iterator.Done {
+		t.Errorf("Next() = (%v, %v); want = (nil, error)", v, err)
+	}
+}
+
+func TestGetTemplateAtVersion(t *testing.T) {
+	var req *http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", "etag-123")
+		w.Write([]byte(testTemplateJSON))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	template, err := client.GetTemplateAtVersion(context.Background(), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(template, testTemplate) {
+		t.Errorf("GetTemplateAtVersion() = %v; want = %v", template, testTemplate)
+	}
+	if req.Method != http.MethodGet || req.URL.Path != "/v1/projects/test-project/remoteConfig" {
+		t.Errorf("GetTemplateAtVersion() = %s %s; want = GET /v1/projects/test-project/remoteConfig", req.Method, req.URL.Path)
+	}
+	if got := req.URL.Query().Get("versionNumber"); got != "42" {
+		t.Errorf("versionNumber = %q; want = %q", got, "42")
+	}
+}
+
+func TestGetTemplateAtVersionInvalidVersion(t *testing.T) {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int64{0, -1} {
+		if template, err := client.GetTemplateAtVersion(context.Background(), n); template != nil || err == nil {
+			t.Errorf("GetTemplateAtVersion(%d) = (%v, %v); want = (nil, error)", n, template, err)
+		}
+	}
+}
+
+func TestRollback(t *testing.T) {
+	var req *http.Request
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", "etag-123")
+		w.Write([]byte(testTemplateJSON))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts)
+	template, err := client.Rollback(context.Background(), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(template, testTemplate) {
+		t.Errorf("Rollback() = %v; want = %v", template, testTemplate)
+	}
+	if req.Method != http.MethodPost || req.URL.Path != "/v1/projects/test-project/remoteConfig:rollback" {
+		t.Errorf("Rollback() = %s %s; want = POST /v1/projects/test-project/remoteConfig:rollback", req.Method, req.URL.Path)
+	}
+	if want := map[string]interface{}{"versionNumber": "6"}; !reflect.DeepEqual(body, want) {
+		t.Errorf("Rollback() body = %v; want = %v", body, want)
+	}
+}
+
+func TestRollbackInvalidVersion(t *testing.T) {
+	client, err := NewClient(context.Background(), testConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int64{0, -1} {
+		if template, err := client.Rollback(context.Background(), n); template != nil || err == nil {
+			t.Errorf("Rollback(%d) = (%v, %v); want = (nil, error)", n, template, err)
+		}
+	}
+}
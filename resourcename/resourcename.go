@@ -0,0 +1,217 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcename provides functions for parsing and building the resource names used by
+// Firebase and Google Cloud APIs, such as "projects/my-project/tenants/my-tenant".
+//
+// A resource name consists of alternating collection and ID segments separated by slashes.
+// Templates describe the names of a kind of resource, with the IDs written as variables:
+//
+//	values, err := resourcename.Tenant.Parse("projects/my-project/tenants/my-tenant")
+//	// values: ["my-project", "my-tenant"]
+//	name, err := resourcename.Tenant.Build("my-project", "my-tenant")
+package resourcename
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	collectionPattern = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9]*$")
+	variablePattern   = regexp.MustCompile(`^\{([a-zA-Z][a-zA-Z0-9_]*)\}$`)
+)
+
+// Templates of the resource names used by the Firebase services.
+var (
+	Project                 = MustCompile("projects/{project}")
+	Tenant                  = MustCompile("projects/{project}/tenants/{tenant}")
+	InboundSAMLConfig       = MustCompile("projects/{project}/inboundSamlConfigs/{config}")
+	TenantInboundSAMLConfig = MustCompile("projects/{project}/tenants/{tenant}/inboundSamlConfigs/{config}")
+	OAuthIdPConfig          = MustCompile("projects/{project}/oauthIdpConfigs/{config}")
+	TenantOAuthIdPConfig    = MustCompile("projects/{project}/tenants/{tenant}/oauthIdpConfigs/{config}")
+	App                     = MustCompile("projects/{project}/apps/{app}")
+	AndroidApp              = MustCompile("projects/{project}/androidApps/{app}")
+	IOSApp                  = MustCompile("projects/{project}/iosApps/{app}")
+	WebApp                  = MustCompile("projects/{project}/webApps/{app}")
+	Model                   = MustCompile("projects/{project}/models/{model}")
+)
+
+// Segment is a collection and ID pair of a resource name.
+type Segment struct {
+	Collection string
+	ID         string
+}
+
+// Name is a parsed resource name.
+type Name []Segment
+
+// Parse parses the given resource name into its segments.
+//
+// The name must consist of alternating collection and ID segments. Collections must be
+// alphanumeric and start with a letter. IDs must be non-empty, and must not contain slashes.
+func Parse(name string) (Name, error) {
+	parts := strings.Split(name, "/")
+	if name == "" || len(parts)%2 != 0 {
+		return nil, fmt.Errorf("malformed resource name: %q", name)
+	}
+
+	n := make(Name, 0, len(parts)/2)
+	for i := 0; i < len(parts); i += 2 {
+		if !collectionPattern.MatchString(parts[i]) || parts[i+1] == "" {
+			return nil, fmt.Errorf("malformed resource name: %q", name)
+		}
+		n = append(n, Segment{Collection: parts[i], ID: parts[i+1]})
+	}
+	return n, nil
+}
+
+// LastID returns the ID of the resource the given name refers to, which is its last segment.
+func LastID(name string) (string, error) {
+	n, err := Parse(name)
+	if err != nil {
+		return "", err
+	}
+	return n.ID(), nil
+}
+
+// ID returns the ID of the resource the name refers to.
+func (n Name) ID() string {
+	if len(n) == 0 {
+		return ""
+	}
+	return n[len(n)-1].ID
+}
+
+// Get returns the ID of the given collection in the name, and whether the collection is present.
+func (n Name) Get(collection string) (string, bool) {
+	for _, s := range n {
+		if s.Collection == collection {
+			return s.ID, true
+		}
+	}
+	return "", false
+}
+
+// Parent returns the name of the parent resource, or nil for a top-level resource.
+func (n Name) Parent() Name {
+	if len(n) <= 1 {
+		return nil
+	}
+	return n[:len(n)-1]
+}
+
+func (n Name) String() string {
+	parts := make([]string, 0, 2*len(n))
+	for _, s := range n {
+		parts = append(parts, s.Collection, s.ID)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Template describes the resource names of a kind of resource, such as
+// "projects/{project}/tenants/{tenant}".
+type Template struct {
+	pattern     string
+	collections []string
+	variables   []string
+}
+
+// Compile parses a template. Templates consist of alternating collection and variable segments,
+// where variables are written in braces.
+func Compile(pattern string) (*Template, error) {
+	parts := strings.Split(pattern, "/")
+	if pattern == "" || len(parts)%2 != 0 {
+		return nil, fmt.Errorf("malformed template: %q", pattern)
+	}
+
+	t := &Template{pattern: pattern}
+	seen := make(map[string]bool)
+	for i := 0; i < len(parts); i += 2 {
+		if !collectionPattern.MatchString(parts[i]) {
+			return nil, fmt.Errorf("malformed template: %q", pattern)
+		}
+		m := variablePattern.FindStringSubmatch(parts[i+1])
+		if m == nil || seen[m[1]] {
+			return nil, fmt.Errorf("malformed template: %q", pattern)
+		}
+		seen[m[1]] = true
+		t.collections = append(t.collections, parts[i])
+		t.variables = append(t.variables, m[1])
+	}
+	return t, nil
+}
+
+// MustCompile is like Compile, but panics if the template is malformed.
+func MustCompile(pattern string) *Template {
+	t, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Variables returns the names of the variables of the template, in order.
+func (t *Template) Variables() []string {
+	return append([]string(nil), t.variables...)
+}
+
+// Parse matches the given resource name against the template, and returns the values of the
+// template variables, in order.
+func (t *Template) Parse(name string) ([]string, error) {
+	n, err := Parse(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) != len(t.collections) {
+		return nil, fmt.Errorf("resource name %q does not match %q", name, t.pattern)
+	}
+
+	values := make([]string, len(n))
+	for i, s := range n {
+		if s.Collection != t.collections[i] {
+			return nil, fmt.Errorf("resource name %q does not match %q", name, t.pattern)
+		}
+		values[i] = s.ID
+	}
+	return values, nil
+}
+
+// Match checks if the given resource name matches the template.
+func (t *Template) Match(name string) bool {
+	_, err := t.Parse(name)
+	return err == nil
+}
+
+// Build returns the resource name obtained by substituting the given values for the template
+// variables, in order. Values must be non-empty, and must not contain slashes.
+func (t *Template) Build(values ...string) (string, error) {
+	if len(values) != len(t.variables) {
+		return "", fmt.Errorf("template %q requires %d values; got %d", t.pattern, len(t.variables), len(values))
+	}
+
+	n := make(Name, len(values))
+	for i, v := range values {
+		if v == "" || strings.Contains(v, "/") {
+			return "", fmt.Errorf("invalid value for %q: %q", t.variables[i], v)
+		}
+		n[i] = Segment{Collection: t.collections[i], ID: v}
+	}
+	return n.String(), nil
+}
+
+func (t *Template) String() string {
+	return t.pattern
+}
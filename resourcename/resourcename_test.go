@@ -0,0 +1,165 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcename
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	n, err := Parse("projects/p/tenants/t/inboundSamlConfigs/saml.provider")
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+
+	want := Name{
+		{Collection: "projects", ID: "p"},
+		{Collection: "tenants", ID: "t"},
+		{Collection: "inboundSamlConfigs", ID: "saml.provider"},
+	}
+	if !reflect.DeepEqual(n, want) {
+		t.Errorf("Parse() = %#v; want = %#v", n, want)
+	}
+	if n.ID() != "saml.provider" {
+		t.Errorf("ID() = %q; want = %q", n.ID(), "saml.provider")
+	}
+	if tenant, ok := n.Get("tenants"); !ok || tenant != "t" {
+		t.Errorf("Get(tenants) = (%q, %v); want = (%q, true)", tenant, ok, "t")
+	}
+	if _, ok := n.Get("apps"); ok {
+		t.Errorf("Get(apps) = true; want = false")
+	}
+	if got := n.Parent().String(); got != "projects/p/tenants/t" {
+		t.Errorf("Parent() = %q; want = %q", got, "projects/p/tenants/t")
+	}
+	if n.Parent().Parent().Parent() != nil {
+		t.Errorf("Parent() of top-level resource = non-nil; want = nil")
+	}
+	if n.String() != "projects/p/tenants/t/inboundSamlConfigs/saml.provider" {
+		t.Errorf("String() = %q", n.String())
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	names := []string{
+		"",
+		"projects",
+		"projects/",
+		"projects/p/tenants",
+		"projects/p/tenants/",
+		"projects//tenants/t",
+		"/projects/p",
+		"projects/p/",
+		"1projects/p",
+		"pro-jects/p",
+	}
+	for _, name := range names {
+		if n, err := Parse(name); err == nil {
+			t.Errorf("Parse(%q) = %v; want = error", name, n)
+		}
+		if id, err := LastID(name); err == nil {
+			t.Errorf("LastID(%q) = %q; want = error", name, id)
+		}
+	}
+}
+
+func TestLastID(t *testing.T) {
+	id, err := LastID("projects/p/oauthIdpConfigs/oidc.provider")
+	if err != nil || id != "oidc.provider" {
+		t.Errorf("LastID() = (%q, %v); want = (%q, nil)", id, err, "oidc.provider")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	values, err := TenantInboundSAMLConfig.Parse("projects/p/tenants/t/inboundSamlConfigs/saml.provider")
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	if want := []string{"p", "t", "saml.provider"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Parse() = %v; want = %v", values, want)
+	}
+
+	name, err := Model.Build("p", "12345")
+	if err != nil || name != "projects/p/models/12345" {
+		t.Errorf("Build() = (%q, %v); want = (%q, nil)", name, err, "projects/p/models/12345")
+	}
+
+	if want := []string{"project", "tenant"}; !reflect.DeepEqual(Tenant.Variables(), want) {
+		t.Errorf("Variables() = %v; want = %v", Tenant.Variables(), want)
+	}
+	if Tenant.String() != "projects/{project}/tenants/{tenant}" {
+		t.Errorf("String() = %q", Tenant.String())
+	}
+}
+
+func TestTemplateMismatch(t *testing.T) {
+	names := []string{
+		"projects/p",
+		"projects/p/tenants/t/inboundSamlConfigs/c",
+		"projects/p/apps/a",
+		"projects/p/tenants/",
+	}
+	for _, name := range names {
+		if Tenant.Match(name) {
+			t.Errorf("Match(%q) = true; want = false", name)
+		}
+	}
+	if !Tenant.Match("projects/p/tenants/t") {
+		t.Errorf("Match() = false; want = true")
+	}
+}
+
+func TestTemplateBuildInvalid(t *testing.T) {
+	cases := [][]string{
+		{},
+		{"p"},
+		{"p", "t", "x"},
+		{"", "t"},
+		{"p", ""},
+		{"p", "t/x"},
+	}
+	for _, values := range cases {
+		if name, err := Tenant.Build(values...); err == nil {
+			t.Errorf("Build(%v) = %q; want = error", values, name)
+		}
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	patterns := []string{
+		"",
+		"projects",
+		"projects/p",
+		"projects/{project}/tenants",
+		"projects/{project}/tenants/{project}",
+		"projects/{}",
+		"{project}/projects",
+	}
+	for _, pattern := range patterns {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) = nil; want = error", pattern)
+		}
+	}
+}
+
+func TestMustCompilePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustCompile() did not panic")
+		}
+	}()
+	MustCompile("projects")
+}
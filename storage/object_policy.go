@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"cloud.google.com/go/storage"
+)
+
+// ObjectPolicy specifies the defaults applied to the attributes of objects created through the
+// helpers of this package, so that they can be enforced in one place.
+type ObjectPolicy struct {
+	// CacheControl is the Cache-Control value of objects that do not specify one.
+	CacheControl string
+
+	// ContentDisposition is the Content-Disposition value of objects that do not specify one.
+	ContentDisposition string
+
+	// Metadata contains the custom metadata entries added to objects that do not already have an
+	// entry with the same key.
+	Metadata map[string]string
+
+	// Hook is an optional function called after the defaults above have been applied, which may
+	// further modify the attributes, or reject the object by returning an error.
+	Hook func(attrs *storage.ObjectAttrs) error
+}
+
+// apply sets the defaults of the policy on the given attributes, and runs the hook.
+func (p *ObjectPolicy) apply(attrs *storage.ObjectAttrs) error {
+	if p == nil {
+		return nil
+	}
+	if attrs.CacheControl == "" {
+		attrs.CacheControl = p.CacheControl
+	}
+	if attrs.ContentDisposition == "" {
+		attrs.ContentDisposition = p.ContentDisposition
+	}
+	for k, v := range p.Metadata {
+		if attrs.Metadata == nil {
+			attrs.Metadata = make(map[string]string, len(p.Metadata))
+		}
+		if _, ok := attrs.Metadata[k]; !ok {
+			attrs.Metadata[k] = v
+		}
+	}
+	if p.Hook != nil {
+		return p.Hook(attrs)
+	}
+	return nil
+}
+
+// SetObjectPolicy configures the policy applied to objects created through the helpers of this
+// client, such as NewWriter. Passing nil removes the policy.
+//
+// The policy does not apply to objects created directly through the bucket handles returned by
+// Bucket and DefaultBucket.
+func (c *Client) SetObjectPolicy(policy *ObjectPolicy) {
+	c.policy = policy
+}
+
+// NewWriter returns a storage.Writer that uploads an object with the given name to the specified
+// bucket, or to the default bucket if the bucket name is empty.
+//
+// The attrs specify the attributes of the object, and may be nil. Their Name and Bucket fields
+// are ignored. The ObjectPolicy of the client is applied to a copy of the attrs, which becomes the
+// ObjectAttrs of the Writer, and an error is returned if the policy rejects the object. The
+// ObjectAttrs of the Writer should not be modified afterwards, since the changes are not checked
+// against the policy.
+func (c *Client) NewWriter(ctx context.Context, bucket, object string, attrs *storage.ObjectAttrs) (*storage.Writer, error) {
+	if bucket == "" {
+		bucket = c.bucket
+	}
+	handle, err := c.Bucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	w := handle.Object(object).NewWriter(ctx)
+	if attrs != nil {
+		w.ObjectAttrs = *attrs
+		w.ObjectAttrs.Name = object
+		w.ObjectAttrs.Bucket = ""
+		if attrs.Metadata != nil {
+			w.ObjectAttrs.Metadata = make(map[string]string, len(attrs.Metadata))
+			for k, v := range attrs.Metadata {
+				w.ObjectAttrs.Metadata[k] = v
+			}
+		}
+	}
+	if err := c.policy.apply(&w.ObjectAttrs); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
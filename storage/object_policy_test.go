@@ -0,0 +1,161 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"firebase.google.com/go/v4/internal"
+)
+
+var testPolicy = &ObjectPolicy{
+	CacheControl:       "public, max-age=3600",
+	ContentDisposition: "attachment",
+	Metadata:           map[string]string{"owner": "billing", "env": "prod"},
+}
+
+func TestObjectPolicyApply(t *testing.T) {
+	attrs := &storage.ObjectAttrs{}
+	if err := testPolicy.apply(attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &storage.ObjectAttrs{
+		CacheControl:       "public, max-age=3600",
+		ContentDisposition: "attachment",
+		Metadata:           map[string]string{"owner": "billing", "env": "prod"},
+	}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("apply() = %#v; want = %#v", attrs, want)
+	}
+}
+
+func TestObjectPolicyApplyKeepsExplicitValues(t *testing.T) {
+	attrs := &storage.ObjectAttrs{
+		CacheControl: "no-store",
+		Metadata:     map[string]string{"env": "dev"},
+	}
+	if err := testPolicy.apply(attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &storage.ObjectAttrs{
+		CacheControl:       "no-store",
+		ContentDisposition: "attachment",
+		Metadata:           map[string]string{"owner": "billing", "env": "dev"},
+	}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("apply() = %#v; want = %#v", attrs, want)
+	}
+}
+
+func TestObjectPolicyNil(t *testing.T) {
+	var policy *ObjectPolicy
+	attrs := &storage.ObjectAttrs{}
+	if err := policy.apply(attrs); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(attrs, &storage.ObjectAttrs{}) {
+		t.Errorf("apply() = %#v; want = empty attrs", attrs)
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.StorageConfig{
+		Bucket: "bucket.name",
+		Opts:   opts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hookAttrs *storage.ObjectAttrs
+	client.SetObjectPolicy(&ObjectPolicy{
+		CacheControl: "public, max-age=3600",
+		Metadata:     map[string]string{"owner": "admin"},
+		Hook: func(attrs *storage.ObjectAttrs) error {
+			hookAttrs = attrs
+			if attrs.ContentType != "image/png" {
+				return errors.New("unexpected content type")
+			}
+			attrs.ContentLanguage = "en"
+			return nil
+		},
+	})
+
+	attrs := &storage.ObjectAttrs{
+		Name:        "ignored",
+		ContentType: "image/png",
+		Metadata:    map[string]string{"source": "upload"},
+	}
+	w, err := client.NewWriter(context.Background(), "", "images/logo.png", attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Name != "images/logo.png" {
+		t.Errorf("Name = %q; want = %q", w.Name, "images/logo.png")
+	}
+	if w.CacheControl != "public, max-age=3600" {
+		t.Errorf("CacheControl = %q; want = %q", w.CacheControl, "public, max-age=3600")
+	}
+	if w.ContentType != "image/png" {
+		t.Errorf("ContentType = %q; want = %q", w.ContentType, "image/png")
+	}
+	if w.ContentLanguage != "en" {
+		t.Errorf("ContentLanguage = %q; want = %q", w.ContentLanguage, "en")
+	}
+	wantMetadata := map[string]string{"source": "upload", "owner": "admin"}
+	if !reflect.DeepEqual(w.Metadata, wantMetadata) {
+		t.Errorf("Metadata = %v; want = %v", w.Metadata, wantMetadata)
+	}
+	if len(attrs.Metadata) != 1 {
+		t.Errorf("NewWriter() modified the attrs: Metadata = %v", attrs.Metadata)
+	}
+	if hookAttrs != &w.ObjectAttrs {
+		t.Errorf("Hook() called with %p; want = %p", hookAttrs, &w.ObjectAttrs)
+	}
+}
+
+func TestNewWriterHookError(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.StorageConfig{Opts: opts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetObjectPolicy(&ObjectPolicy{
+		Hook: func(attrs *storage.ObjectAttrs) error {
+			return errors.New("object rejected")
+		},
+	})
+
+	w, err := client.NewWriter(context.Background(), "bucket.name", "object", nil)
+	if w != nil || err == nil || err.Error() != "object rejected" {
+		t.Errorf("NewWriter() = (%v, %v); want = (nil, %q)", w, err, "object rejected")
+	}
+}
+
+func TestNewWriterNoBucket(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.StorageConfig{Opts: opts})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := client.NewWriter(context.Background(), "", "object", nil)
+	if w != nil || err == nil {
+		t.Errorf("NewWriter() = (%v, %v); want = (nil, error)", w, err)
+	}
+}
@@ -19,15 +19,18 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 
 	"cloud.google.com/go/storage"
 	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
 )
 
 // Client is the interface for the Firebase Storage service.
 type Client struct {
 	client *storage.Client
 	bucket string
+	policy *ObjectPolicy
 }
 
 // NewClient creates a new instance of the Firebase Storage Client.
@@ -35,10 +38,18 @@ type Client struct {
 // This function can only be invoked from within the SDK. Client applications should access the
 // the Storage service through firebase.App.
 func NewClient(ctx context.Context, c *internal.StorageConfig) (*Client, error) {
-	if os.Getenv("STORAGE_EMULATOR_HOST") == "" && os.Getenv("FIREBASE_STORAGE_EMULATOR_HOST") != "" {
+	opts := c.Opts
+	if c.EmulatorHost != "" {
+		// Point the client at the emulator directly, instead of via the STORAGE_EMULATOR_HOST
+		// environment variable, which is shared by all the clients in the process.
+		opts = []option.ClientOption{
+			option.WithEndpoint(emulatorEndpoint(c.EmulatorHost)),
+			option.WithoutAuthentication(),
+		}
+	} else if os.Getenv("STORAGE_EMULATOR_HOST") == "" && os.Getenv("FIREBASE_STORAGE_EMULATOR_HOST") != "" {
 		os.Setenv("STORAGE_EMULATOR_HOST", os.Getenv("FIREBASE_STORAGE_EMULATOR_HOST"))
 	}
-	client, err := storage.NewClient(ctx, c.Opts...)
+	client, err := storage.NewClient(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -60,3 +71,12 @@ func (c *Client) Bucket(name string) (*storage.BucketHandle, error) {
 	}
 	return c.client.Bucket(name), nil
 }
+
+// emulatorEndpoint returns the JSON API endpoint of the emulator running at the given host. The
+// host may optionally include a scheme, which defaults to http.
+func emulatorEndpoint(host string) string {
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	return strings.TrimSuffix(host, "/") + "/storage/v1/"
+}
@@ -16,7 +16,10 @@ package storage
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"firebase.google.com/go/v4/internal"
@@ -58,6 +61,35 @@ func TestNewClientEmulatorHostEnvVar(t *testing.T) {
 	}
 }
 
+func TestNewClientEmulatorHost(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "bucket.name"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), &internal.StorageConfig{
+		Opts:         opts,
+		EmulatorHost: strings.TrimPrefix(ts.URL, "http://"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, err := client.Bucket("bucket.name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bucket.Attrs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/storage/v1/b/bucket.name" {
+		t.Errorf("Path = %q; want = %q", path, "/storage/v1/b/bucket.name")
+	}
+}
+
 func TestNoBucketName(t *testing.T) {
 	client, err := NewClient(context.Background(), &internal.StorageConfig{
 		Opts: opts,